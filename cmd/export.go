@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// exportResources lists every resource type once and writes them to path
+// without starting the UI, as a snapshot of the Flux state
+func exportResources(cmd *cobra.Command, cfg *config.Config, path, formatName string) error {
+	format, err := k8s.ParseExportFormat(formatName, path)
+	if err != nil {
+		return err
+	}
+
+	resources, err := listAllResources(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Without --context the client uses the kubeconfig's current context
+	kubeContext := cfg.CurrentContext
+	if kubeContext == "" {
+		if kubeContext, err = k8s.CurrentContext(cfg.CurrentKubeConfig); err != nil {
+			return err
+		}
+	}
+	return k8s.WriteExportFile(path, k8s.NewExport(kubeContext, resources), format)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// useInCluster connects with the pod's service account when fluxcli runs in
+// a cluster, and falls back to the kubeconfig otherwise
+func useInCluster(cfg *config.Config) error {
+	if k8s.InCluster() {
+		cfg.InCluster = true
+		cfg.CurrentContext = k8s.InClusterContext
+		return nil
+	}
+
+	contexts, err := k8s.ListContexts(cfg.CurrentKubeConfig)
+	if err != nil {
+		return fmt.Errorf("not running in a cluster and %w", err)
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("not running in a cluster and no kubeconfig found to fall back to (set --kubeconfig or $KUBECONFIG)")
+	}
+	return nil
+}
+
+// newClient connects to the cluster the flags select
+func newClient(cfg *config.Config) (*k8s.Client, error) {
+	if cfg.InCluster {
+		return k8s.NewInClusterClient(cfg.CurrentNamespace)
+	}
+	return k8s.NewClient(cfg.CurrentKubeConfig, cfg.CurrentContext, cfg.CurrentNamespace)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/metrics"
+)
+
+// dumpMetrics lists every resource type once and writes the health metrics
+// to path without starting the UI, for pushgateway or textfile collectors
+func dumpMetrics(cmd *cobra.Command, cfg *config.Config, path string) error {
+	resources, err := listAllResources(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		return metrics.WriteText(os.Stdout, resources)
+	}
+	return writeMetricsFile(path, resources)
+}
+
+// listAllResources lists every resource type of the current context once
+func listAllResources(cmd *cobra.Command, cfg *config.Config) ([]k8s.Resource, error) {
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	client.Warnings.SetOutput(os.Stderr)
+
+	resources, err := k8s.ListAll(cmd.Context(), client, cfg.CurrentNamespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resources {
+		resources[i].Cluster = cfg.CurrentContext
+	}
+	return resources, nil
+}
+
+// writeMetricsFile writes metrics through a temp file and a rename, so
+// collectors reading the file on a schedule never see a partial dump
+func writeMetricsFile(path string, resources []k8s.Resource) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".fluxcli-metrics-*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := metrics.WriteText(tmp, resources); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write metrics file %s: %w", path, err)
+	}
+	return nil
+}
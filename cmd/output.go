@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// printResources lists every resource type once and prints them to stdout
+// as a table or JSON without starting the UI, for scripts and pipes
+func printResources(cmd *cobra.Command, cfg *config.Config, output string) error {
+	if output != "" && output != "table" && output != "json" {
+		return fmt.Errorf("unknown output format %q, use table or json", output)
+	}
+
+	resources, err := listAllResources(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		if resources == nil {
+			resources = []k8s.Resource{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resources); err != nil {
+			return fmt.Errorf("failed to write resources: %w", err)
+		}
+		return nil
+	}
+	return k8s.WriteTable(os.Stdout, resources)
+}
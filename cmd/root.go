@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/internal/version"
+	"github.com/malagant/fluxcli/pkg/k8s"
 	"github.com/malagant/fluxcli/pkg/ui"
 )
 
@@ -17,18 +20,23 @@ var (
 	namespace   string
 	debug       bool
 	logLevel    string
-	
-	// Version information set by build
-	version   = "dev"
-	commit    = "none"
-	buildTime = "unknown"
+	recordFile  string
+	replayFile  string
+	allContexts bool
+	contexts    []string
+	namespaces  []string
+	metricsDump string
+	metricsAddr string
+	exportFile   string
+	exportFormat string
+	outputFormat string
+	inCluster    bool
 )
 
 // SetVersionInfo sets the version information from the build process
 func SetVersionInfo(v, c, b string) {
-	version = v
-	commit = c
-	buildTime = b
+	version.Set(v, c, b)
+	rootCmd.Version = v
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -53,6 +61,44 @@ Features:
 		if err != nil {
 			return fmt.Errorf("failed to load configuration: %w", err)
 		}
+		cfg.RecordFile = recordFile
+		cfg.ReplayFile = replayFile
+		cfg.Fleet = allContexts || len(contexts) > 0
+		cfg.FleetContexts = contexts
+		cfg.MetricsAddr = metricsAddr
+		if len(namespaces) > 0 {
+			cfg.Defaults.Namespaces = namespaces
+			if namespace == "" {
+				cfg.CurrentNamespace = ""
+			}
+		}
+		if inCluster {
+			if err := useInCluster(cfg); err != nil {
+				return err
+			}
+		}
+		// Catch mistyped contexts before connecting rather than in the UI
+		requested := contexts
+		if context != "" {
+			requested = append([]string{context}, contexts...)
+		}
+		if replayFile == "" && len(requested) > 0 {
+			if err := k8s.CheckContexts(cfg.CurrentKubeConfig, requested...); err != nil {
+				return err
+			}
+		}
+
+		if metricsDump != "" {
+			return dumpMetrics(cmd, cfg, metricsDump)
+		}
+		if exportFile != "" {
+			return exportResources(cmd, cfg, exportFile, exportFormat)
+		}
+		// Without a terminal the UI can't run, so print the resources once
+		headless := !term.IsTerminal(os.Stdout.Fd()) && !cfg.Fleet && recordFile == "" && replayFile == ""
+		if outputFormat != "" || headless {
+			return printResources(cmd, cfg, outputFormat)
+		}
 
 		// Initialize and run the TUI
 		app := ui.NewApp(cfg)
@@ -79,6 +125,27 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace to use")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug mode")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (trace, debug, info, warn, error)")
+	rootCmd.Flags().StringVar(&recordFile, "record", "", "record resource and event snapshots to a file for later replay")
+	rootCmd.Flags().StringVar(&replayFile, "replay", "", "replay a recording instead of connecting to a cluster")
+	rootCmd.Flags().BoolVar(&allContexts, "all-contexts", false, "show resources of every kubeconfig context in one table")
+	rootCmd.Flags().StringSliceVar(&contexts, "contexts", nil, "show resources of the given kubeconfig contexts in one table")
+	rootCmd.Flags().StringSliceVar(&namespaces, "namespaces", nil, "list only these namespaces when showing all namespaces, e.g. team-a,team-b")
+	rootCmd.Flags().StringVar(&metricsDump, "metrics-dump", "", "write resource health metrics in Prometheus text format to a file (- for stdout) and exit")
+	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "serve resource health metrics on /metrics and /healthz at this address while the UI runs, e.g. :9090")
+	rootCmd.Flags().StringVar(&exportFile, "export", "", "write every resource as JSON or YAML to a file (- for stdout) and exit")
+	rootCmd.Flags().StringVar(&exportFormat, "export-format", "", "format of --export, json or yaml (default from the file extension, json otherwise)")
+	rootCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "print every resource as table or json and exit (default table when stdout is not a terminal)")
+	rootCmd.Flags().BoolVar(&inCluster, "in-cluster", false, "connect with the service account of the pod fluxcli runs in, falling back to the kubeconfig outside a cluster")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.MarkFlagsMutuallyExclusive("all-contexts", "contexts")
+	rootCmd.MarkFlagsMutuallyExclusive("metrics-dump", "record", "replay")
+	rootCmd.MarkFlagsMutuallyExclusive("metrics-dump", "all-contexts", "contexts")
+	rootCmd.MarkFlagsMutuallyExclusive("metrics-addr", "metrics-dump", "export")
+	rootCmd.MarkFlagsMutuallyExclusive("export", "metrics-dump", "record", "replay")
+	rootCmd.MarkFlagsMutuallyExclusive("export", "all-contexts", "contexts")
+	rootCmd.MarkFlagsMutuallyExclusive("output", "export", "metrics-dump", "metrics-addr")
+	rootCmd.MarkFlagsMutuallyExclusive("output", "record", "replay", "all-contexts", "contexts")
+	rootCmd.MarkFlagsMutuallyExclusive("in-cluster", "context", "replay", "all-contexts", "contexts")
 
 	// Bind flags to viper
 	viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
@@ -87,17 +154,18 @@ func init() {
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 
-	// Add version command
+	// Add version command and --version flag
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the version information",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("Version: %s\n", version)
-			fmt.Printf("Commit: %s\n", commit)
-			fmt.Printf("Build Time: %s\n", buildTime)
+			fmt.Print(version.Get().String())
 		},
 	}
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.Version = version.Get().Version
+	cobra.AddTemplateFunc("buildInfo", func() string { return version.Get().String() })
+	rootCmd.SetVersionTemplate("{{buildInfo}}")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	waitRevision string
+	waitSelector string
+	waitTimeout  time.Duration
+)
+
+// waitCmd blocks until a FluxCD resource has rolled out a specific revision
+var waitCmd = &cobra.Command{
+	Use:   "wait <resource-type> [name]",
+	Short: "Wait for FluxCD resources to become ready",
+	Long: `Wait blocks until the given FluxCD resource reports Ready, or until the
+timeout elapses. On timeout it exits non-zero with the last Ready condition message.
+
+With --revision, the resource must also have applied the requested revision.
+Short commit SHAs are matched as prefixes, which makes it suitable for gating
+CI pipelines on a specific commit rollout.
+
+With --selector, wait blocks until every resource of the type matching the
+label selector reports Ready, and prints a per-resource summary on timeout.
+
+Examples:
+  fluxcli wait helmrelease podinfo -n apps --timeout 2m
+  fluxcli wait kustomization apps -n flux-system --revision abc123 --timeout 10m
+  fluxcli wait kustomization --selector app.kubernetes.io/part-of=shop --timeout 10m`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resourceType, err := k8s.ParseResourceType(args[0])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case waitSelector != "" && len(args) == 2:
+			return fmt.Errorf("a resource name and --selector are mutually exclusive")
+		case waitSelector != "" && waitRevision != "":
+			return fmt.Errorf("--revision is not supported with --selector")
+		case waitSelector == "" && len(args) != 2:
+			return fmt.Errorf("a resource name or --selector is required")
+		}
+
+		cfg, err := config.Load(cfgFile, kubeconfig, context, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		client, err := newClient(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+		client.Warnings.SetOutput(os.Stderr)
+
+		if waitSelector != "" {
+			return waitForSelector(cmd, client, resourceType, cfg.CurrentNamespace)
+		}
+
+		name := args[1]
+		if waitRevision == "" {
+			if err := client.WaitForReady(cmd.Context(), resourceType, name, cfg.CurrentNamespace, waitTimeout); err != nil {
+				return err
+			}
+			fmt.Printf("%s %s/%s is ready\n", resourceType, cfg.CurrentNamespace, name)
+			return nil
+		}
+
+		if err := client.WaitForRevision(cmd.Context(), resourceType, name, cfg.CurrentNamespace, waitRevision, waitTimeout); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s %s/%s is ready at revision %s\n", resourceType, cfg.CurrentNamespace, name, waitRevision)
+		return nil
+	},
+}
+
+// waitForSelector waits until every resource matching --selector is ready
+func waitForSelector(cmd *cobra.Command, client *k8s.Client, resourceType k8s.ResourceType, namespace string) error {
+	selector, err := labels.Parse(waitSelector)
+	if err != nil {
+		return fmt.Errorf("invalid selector %q: %w", waitSelector, err)
+	}
+
+	all, err := k8s.ListResources(cmd.Context(), client, resourceType, namespace)
+	if err != nil {
+		return err
+	}
+
+	var resources []k8s.Resource
+	for _, resource := range all {
+		if selector.Matches(labels.Set(resource.Labels)) {
+			resources = append(resources, resource)
+		}
+	}
+	if len(resources) == 0 {
+		return fmt.Errorf("no %s resources in namespace %q match selector %q", resourceType, namespace, waitSelector)
+	}
+
+	if err := client.WaitForAll(cmd.Context(), resources, waitTimeout); err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		fmt.Printf("%s %s/%s is ready\n", resource.Type, resource.Namespace, resource.Name)
+	}
+	return nil
+}
+
+func init() {
+	waitCmd.Flags().StringVar(&waitRevision, "revision", "", "revision (or short commit SHA) the resource must apply")
+	waitCmd.Flags().StringVarP(&waitSelector, "selector", "l", "", "label selector; wait for every matching resource to be ready")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", 5*time.Minute, "maximum time to wait")
+
+	rootCmd.AddCommand(waitCmd)
+}
@@ -1,12 +1,17 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 	"k8s.io/client-go/util/homedir"
 )
 
@@ -15,13 +20,26 @@ type Config struct {
 	Clusters         []ClusterConfig `yaml:"clusters"`
 	Defaults         DefaultConfig   `yaml:"defaults"`
 	UI               UIConfig        `yaml:"ui"`
+	SessionLog       SessionLogConfig `yaml:"session_log"`
 	Debug            bool            `yaml:"debug"`
 	LogLevel         string          `yaml:"log_level"`
+	LastNamespace    string          `yaml:"last_namespace"` // Picked in the UI, restored when no namespace flag is given; AllNamespaces for all
 	CurrentKubeConfig string         `yaml:"-"` // Runtime only
 	CurrentContext   string          `yaml:"-"` // Runtime only
 	CurrentNamespace string          `yaml:"-"` // Runtime only
+	RecordFile       string          `yaml:"-"` // Runtime only
+	ReplayFile       string          `yaml:"-"` // Runtime only
+	Fleet            bool            `yaml:"-"` // Runtime only: aggregate all clusters in one table
+	FleetContexts    []string        `yaml:"-"` // Runtime only: contexts to aggregate, all when empty
+	MetricsAddr      string          `yaml:"-"` // Runtime only: address of the metrics server, disabled when empty
+	InCluster        bool            `yaml:"-"` // Runtime only: CurrentContext connects with the pod's service account, see --in-cluster
+
+	file string // Config file loaded from, see updateFile
 }
 
+// AllNamespaces is the LastNamespace value for all namespaces
+const AllNamespaces = "*"
+
 // ClusterConfig represents a single cluster configuration
 type ClusterConfig struct {
 	Name        string `yaml:"name"`
@@ -35,9 +53,24 @@ type ClusterConfig struct {
 // DefaultConfig represents default settings
 type DefaultConfig struct {
 	Namespace            string        `yaml:"namespace"`
+	Namespaces           []string      `yaml:"namespaces"` // Listed instead of all namespaces, for RBAC scoped to a few of them
 	RefreshInterval      time.Duration `yaml:"refresh_interval"`
 	MaxConcurrentClusters int          `yaml:"max_concurrent_clusters"`
 	EventsEnabled        bool          `yaml:"events_enabled"`
+	LargeListWarning     int           `yaml:"large_list_warning"` // Warn before listing more objects than this across all namespaces
+	ReconcileDedupWindow time.Duration `yaml:"reconcile_dedup_window"` // Skip reconcile while a younger request is unhandled, 0 disables
+	OverdueMargin        time.Duration `yaml:"overdue_margin"` // How long a reconcile request or spec change may go unhandled before a resource is flagged overdue
+	EventsLookback       time.Duration `yaml:"events_lookback"` // How far back events are shown, 0 or negative shows all
+	APIRetries           int           `yaml:"api_retries"` // Retries of API calls failing with timeouts, 429s or internal errors, 0 disables
+	RequestTimeout       time.Duration `yaml:"request_timeout"` // Deadline of a single API request, 0 disables
+	ListPageSize         int64         `yaml:"list_page_size"` // Objects read per list request, 0 lists in one request
+	ListLimit            int           `yaml:"list_limit"` // Stop listing a type after this many objects, 0 lists all
+}
+
+// SessionLogConfig controls the persistent log of mutating actions
+type SessionLogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"` // JSON lines file, defaults to ~/.fluxcli/session.jsonl
 }
 
 // UIConfig represents UI-specific settings
@@ -46,9 +79,94 @@ type UIConfig struct {
 	ShowAge         bool   `yaml:"show_age"`
 	ShowMessage     bool   `yaml:"show_message"`
 	ShowNamespace   bool   `yaml:"show_namespace"`
+	ShowNextSync    bool   `yaml:"show_next_sync"` // Add a column counting down to the next scheduled reconcile
+	AgeColumn       string `yaml:"age_column"` // "created" ages rows from their creation, "ready" from their last Ready transition
 	PaneEventsHeight int   `yaml:"pane_events_height"`
 	ColumnsName     int    `yaml:"columns_name"`
 	ColumnsStatus   int    `yaml:"columns_status"`
+	Accessible      bool   `yaml:"accessible"` // No colors, ASCII-only glyphs
+	NoColor         bool   `yaml:"no_color"` // No colors, keeping unicode glyphs
+	TenantLabel     string `yaml:"tenant_label"` // Label key used to group resources by tenant
+	GroupByNamespace bool  `yaml:"group_by_namespace"` // Group rows by namespace when listing all namespaces
+	TypeLabels      map[string]string `yaml:"type_labels"` // Per-type prefix overrides for mixed-type views
+	TimeFormat      string `yaml:"time_format"` // Go time layout for absolute timestamps, or "relative"
+	TimeZone        string `yaml:"time_zone"` // "Local", "UTC" or an IANA zone name
+	ChangeHighlight bool   `yaml:"change_highlight"` // Mark rows whose Ready/Status/Revision changed in a refresh, ▲/▼ when they became or stopped being Ready
+	ChangeHighlightDuration time.Duration `yaml:"change_highlight_duration"` // How long the mark stays
+	Confirm         ConfirmConfig `yaml:"confirm"` // Actions asked about before they run
+
+	location *time.Location // Parsed TimeZone
+	override *time.Location // Session toggle between UTC and local time, see ToggleUTC
+}
+
+// Colors reports whether the UI may use colors
+func (u UIConfig) Colors() bool {
+	return !u.Accessible && !u.NoColor
+}
+
+// ConfirmConfig selects the actions that ask for confirmation before running
+type ConfirmConfig struct {
+	Suspend   bool `yaml:"suspend"`
+	Resume    bool `yaml:"resume"`
+	Reconcile bool `yaml:"reconcile"` // Also covers reconciling with the source
+	Delete    bool `yaml:"delete"` // Asks to type the resource's name
+}
+
+// TimeFormatRelative renders timestamps as "3m ago" instead of absolute times
+const TimeFormatRelative = "relative"
+
+// Values of UIConfig.AgeColumn
+const (
+	// AgeColumnCreated ages rows from their creation timestamp
+	AgeColumnCreated = "created"
+	// AgeColumnReady ages rows from their last Ready transition, telling a
+	// resource Ready for two minutes from one Ready for a month
+	AgeColumnReady = "ready"
+)
+
+// ReadyAge reports whether the Age column shows the time since the last
+// Ready transition
+func (u UIConfig) ReadyAge() bool {
+	return u.AgeColumn == AgeColumnReady
+}
+
+// LocalZone is the zone "Local" resolves to, time.Local unless swapped out
+// by tests that need a zone other than the machine's
+var LocalZone = time.Local
+
+// Location returns the time zone timestamps are shown in: the session
+// toggle if any, else the configured zone, defaulting to local time
+func (u UIConfig) Location() *time.Location {
+	if u.override != nil {
+		return u.override
+	}
+	if u.location == nil || u.location == time.Local {
+		return LocalZone
+	}
+	return u.location
+}
+
+// ToggleUTC flips timestamps to UTC, or back to local time when already in
+// UTC. The change lasts for the session and is not written to the config.
+func (u *UIConfig) ToggleUTC() {
+	if u.Location().String() == "UTC" {
+		u.override = LocalZone
+	} else {
+		u.override = time.UTC
+	}
+}
+
+// TimeZoneLabel names the zone timestamps are shown in for the status bar
+func (u UIConfig) TimeZoneLabel() string {
+	location := u.Location()
+	switch {
+	case location.String() == "UTC":
+		return "UTC"
+	case location == LocalZone:
+		return "local"
+	default:
+		return location.String()
+	}
 }
 
 // Load loads configuration from file and command line arguments
@@ -59,15 +177,31 @@ func Load(configFile, kubeconfig, context, namespace string) (*Config, error) {
 			RefreshInterval:      5 * time.Second,
 			MaxConcurrentClusters: 10,
 			EventsEnabled:        true,
+			LargeListWarning:     5000,
+			ReconcileDedupWindow: 30 * time.Second,
+			OverdueMargin:        5 * time.Minute,
+			EventsLookback:       time.Hour,
+			APIRetries:           3,
+			RequestTimeout:       30 * time.Second,
+			ListPageSize:         500,
 		},
 		UI: UIConfig{
 			Theme:           "dark",
 			ShowAge:         true,
 			ShowMessage:     true,
 			ShowNamespace:   true,
+			AgeColumn:       AgeColumnCreated,
 			PaneEventsHeight: 4,
 			ColumnsName:     30,
 			ColumnsStatus:   15,
+			TimeFormat:      "2006-01-02 15:04:05",
+			TimeZone:        "Local",
+			ChangeHighlight: true,
+			ChangeHighlightDuration: 5 * time.Second,
+			Confirm: ConfirmConfig{
+				Suspend: true,
+				Delete:  true,
+			},
 		},
 		Debug:    viper.GetBool("debug"),
 		LogLevel: viper.GetString("log-level"),
@@ -93,12 +227,42 @@ func Load(configFile, kubeconfig, context, namespace string) (*Config, error) {
 		}
 	}
 
+	// Honor https://no-color.org by switching to accessibility mode
+	if os.Getenv("NO_COLOR") != "" {
+		cfg.UI.Accessible = true
+	}
+
+	if cfg.UI.AgeColumn != AgeColumnCreated && cfg.UI.AgeColumn != AgeColumnReady {
+		return nil, fmt.Errorf("invalid age column %q, use %q or %q", cfg.UI.AgeColumn, AgeColumnCreated, AgeColumnReady)
+	}
+
+	location, err := time.LoadLocation(cfg.UI.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", cfg.UI.TimeZone, err)
+	}
+	cfg.UI.location = location
+
+	if home := homedir.HomeDir(); home != "" {
+		if cfg.SessionLog.Path == "" {
+			cfg.SessionLog.Path = filepath.Join(home, ".fluxcli", "session.jsonl")
+		} else if strings.HasPrefix(cfg.SessionLog.Path, "~/") {
+			cfg.SessionLog.Path = filepath.Join(home, cfg.SessionLog.Path[2:])
+		}
+	}
+
 	if context != "" {
 		cfg.CurrentContext = context
 	}
 
 	if namespace != "" {
 		cfg.CurrentNamespace = namespace
+	} else if cfg.LastNamespace == AllNamespaces {
+		cfg.CurrentNamespace = ""
+	} else if cfg.LastNamespace != "" {
+		cfg.CurrentNamespace = cfg.LastNamespace
+	} else if len(cfg.Defaults.Namespaces) > 0 {
+		// All namespaces stands for the configured set
+		cfg.CurrentNamespace = ""
 	} else if cfg.CurrentNamespace == "" {
 		cfg.CurrentNamespace = cfg.Defaults.Namespace
 	}
@@ -140,8 +304,12 @@ func loadConfigFile(cfg *Config, configFile string) error {
 			return fmt.Errorf("failed to read config file: %w", err)
 		}
 	}
+	cfg.file = viper.ConfigFileUsed()
 
-	return viper.Unmarshal(cfg)
+	// Decode using the yaml tags so snake_case keys in the file map onto fields
+	return viper.Unmarshal(cfg, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	})
 }
 
 // createDefaultConfig creates a default configuration file
@@ -155,15 +323,46 @@ defaults:
   refresh_interval: 5s
   max_concurrent_clusters: 10
   events_enabled: true
+  large_list_warning: 5000
+  reconcile_dedup_window: 30s
+  overdue_margin: 5m
+  api_retries: 3 # retry timeouts, 429s and internal errors with backoff
+  request_timeout: 30s # give up on an API request after this long, 0 disables
+  list_page_size: 500 # objects per list request
+  list_limit: 0 # stop listing a type after this many objects, 0 lists all
 
 ui:
   theme: dark
   show_age: true
   show_message: true
   show_namespace: true
+  show_next_sync: false # column counting down to the next scheduled reconcile
+  age_column: created # or "ready" for how long resources have been in their current Ready state
   pane_events_height: 4
   columns_name: 30
   columns_status: 15
+  accessible: false
+  no_color: false # plain text without switching to ASCII glyphs
+  time_format: "2006-01-02 15:04:05" # or "relative"
+  time_zone: Local
+  group_by_namespace: false # group rows under a header per namespace when listing all namespaces
+  change_highlight: true # mark rows whose status changed in the last refresh
+  change_highlight_duration: 5s
+  confirm: # ask before running these actions
+    suspend: true
+    resume: false
+    reconcile: false
+    delete: true
+  # type_labels:
+  #   GitRepository: GR
+  #   HelmRepository: HR
+  #   Kustomization: KS
+  #   HelmRelease: HL
+
+# Append every suspend/resume/reconcile/reset to a JSON lines audit log
+session_log:
+  enabled: false
+  # path: ~/.fluxcli/session.jsonl
 `
 
 	return os.WriteFile(path, []byte(defaultConfig), 0644)
@@ -208,6 +407,137 @@ func (c *Config) Save() error {
 	return viper.WriteConfig()
 }
 
+// SaveLastNamespace records the namespace picked in the UI ("" for all) in the
+// config file. Only that key is rewritten, so comments and formatting of the
+// rest of the file are kept.
+func (c *Config) SaveLastNamespace(namespace string) error {
+	if namespace == "" {
+		namespace = AllNamespaces
+	}
+	c.LastNamespace = namespace
+	return c.updateFile(func(root *yaml.Node) {
+		setMappingValue(root, "last_namespace", namespace)
+	})
+}
+
+// SaveUIPreferences records the layout adjusted in the UI, the namespace
+// display and column widths, in the ui section of the config file
+func (c *Config) SaveUIPreferences() error {
+	return c.updateFile(func(root *yaml.Node) {
+		ui := mappingChild(root, "ui")
+		setMappingScalar(ui, "show_namespace", "!!bool", strconv.FormatBool(c.UI.ShowNamespace))
+		setMappingScalar(ui, "columns_name", "!!int", strconv.Itoa(c.UI.ColumnsName))
+		setMappingScalar(ui, "columns_status", "!!int", strconv.Itoa(c.UI.ColumnsStatus))
+	})
+}
+
+// updateFile applies edit to the config file's top-level mapping and writes
+// it back, keeping comments and formatting of untouched keys
+func (c *Config) updateFile(edit func(root *yaml.Node)) error {
+	if c.file == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update config file: %s is not a mapping", c.file)
+	}
+	edit(root)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	return writeFileAtomic(c.file, out.Bytes())
+}
+
+// writeFileAtomic replaces a file through a temp file and a rename, so a
+// crash mid-write leaves the old file rather than a truncated one
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingChild returns the mapping under a key of a YAML mapping, replacing
+// a missing or empty value with a new mapping
+func mappingChild(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			child := mapping.Content[i+1]
+			if child.Kind != yaml.MappingNode {
+				*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			return child
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
+
+// setMappingScalar sets a non-string key of a YAML mapping, such as a bool
+// or int, appending it if missing
+func setMappingScalar(mapping *yaml.Node, key, tag, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			node := mapping.Content[i+1]
+			node.Kind, node.Tag, node.Value, node.Style, node.Content = yaml.ScalarNode, tag, value, 0, nil
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+}
+
+// setMappingValue sets a string key of a YAML mapping, appending it if missing
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].SetString(value)
+			return
+		}
+	}
+	valueNode := &yaml.Node{}
+	valueNode.SetString(value)
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+}
+
 // SaveTo saves the configuration to the specified file path
 func (c *Config) SaveTo(filepath string) error {
 	return viper.WriteConfigAs(filepath)
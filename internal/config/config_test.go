@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -71,6 +73,27 @@ func TestLoadWithKubeconfigEnvVar(t *testing.T) {
 	assert.Contains(t, config3.CurrentKubeConfig, ".kube/config")
 }
 
+func TestLoadWithNoColorEnvVar(t *testing.T) {
+	originalNoColor, hadNoColor := os.LookupEnv("NO_COLOR")
+	defer func() {
+		if hadNoColor {
+			os.Setenv("NO_COLOR", originalNoColor)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+
+	os.Unsetenv("NO_COLOR")
+	config, err := Load("", "", "", "")
+	require.NoError(t, err)
+	assert.False(t, config.UI.Accessible)
+
+	os.Setenv("NO_COLOR", "1")
+	config, err = Load("", "", "", "")
+	require.NoError(t, err)
+	assert.True(t, config.UI.Accessible)
+}
+
 func TestAddCluster(t *testing.T) {
 	config, err := Load("", "", "", "")
 	require.NoError(t, err)
@@ -138,3 +161,214 @@ func TestRemoveCluster(t *testing.T) {
 	assert.False(t, removed)
 	assert.Len(t, config.Clusters, 1)
 }
+
+func TestLoadLegacyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	// The file earlier releases wrote on first start still loads unchanged
+	legacy := `# FluxCLI Configuration
+
+clusters: []
+
+defaults:
+  namespace: flux-system
+  refresh_interval: 5s
+  max_concurrent_clusters: 10
+  events_enabled: true
+
+ui:
+  theme: dark
+  show_age: true
+  show_message: true
+  show_namespace: true
+  pane_events_height: 4
+  columns_name: 30
+  columns_status: 15
+`
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0644))
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "flux-system", config.Defaults.Namespace)
+	assert.Equal(t, 5*time.Second, config.Defaults.RefreshInterval)
+	assert.Equal(t, 10, config.Defaults.MaxConcurrentClusters)
+	assert.Equal(t, 30, config.UI.ColumnsName)
+
+	// So does the README example, keys unknown to this version included
+	readme := `clusters:
+  - name: "production"
+    kubeconfig: "~/.kube/config"
+    context: "prod-cluster"
+  - name: "staging"
+    kubeconfig: "~/.kube/staging-config"
+    context: "staging-cluster"
+
+defaults:
+  namespace: "apps"
+  refresh_interval: "10s"
+  max_concurrent_clusters: 3
+
+ui:
+  theme: "light"
+  show_events: true
+  columns:
+    - "Name"
+    - "Namespace"
+    - "Age"
+`
+	require.NoError(t, os.WriteFile(path, []byte(readme), 0644))
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	require.Len(t, config.Clusters, 2)
+	assert.Equal(t, ClusterConfig{Name: "production", Kubeconfig: "~/.kube/config", Context: "prod-cluster"}, config.Clusters[0])
+	assert.Equal(t, "apps", config.Defaults.Namespace)
+	assert.Equal(t, 10*time.Second, config.Defaults.RefreshInterval)
+	assert.Equal(t, 3, config.Defaults.MaxConcurrentClusters)
+	assert.Equal(t, "light", config.UI.Theme)
+}
+
+func TestLoadTimeSettings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  time_format: relative\n  time_zone: Europe/Berlin\n"), 0644))
+
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, TimeFormatRelative, config.UI.TimeFormat)
+	assert.Equal(t, "Europe/Berlin", config.UI.Location().String())
+
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  time_zone: Mars/Olympus\n"), 0644))
+	_, err = Load(path, "", "", "")
+	assert.ErrorContains(t, err, "invalid time zone")
+}
+
+func TestLoadAgeColumn(t *testing.T) {
+	config, err := Load("", "", "", "")
+	require.NoError(t, err)
+	assert.False(t, config.UI.ReadyAge())
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  age_column: ready\n"), 0644))
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.True(t, config.UI.ReadyAge())
+
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  age_column: updated\n"), 0644))
+	_, err = Load(path, "", "", "")
+	assert.ErrorContains(t, err, `invalid age column "updated"`)
+}
+
+func TestLoadConfirmSettings(t *testing.T) {
+	config, err := Load("", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, ConfirmConfig{Suspend: true, Delete: true}, config.UI.Confirm)
+
+	// Settings left out keep their defaults
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  confirm:\n    reconcile: true\n    delete: false\n"), 0644))
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, ConfirmConfig{Suspend: true, Reconcile: true}, config.UI.Confirm)
+}
+
+func TestToggleUTC(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("ui:\n  time_zone: Europe/Berlin\n"), 0644))
+
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", config.UI.TimeZoneLabel())
+
+	config.UI.ToggleUTC()
+	assert.Equal(t, time.UTC, config.UI.Location())
+	assert.Equal(t, "UTC", config.UI.TimeZoneLabel())
+
+	// From UTC the toggle goes to local time, not back to the configured zone
+	config.UI.ToggleUTC()
+	assert.Equal(t, time.Local, config.UI.Location())
+	assert.Equal(t, "local", config.UI.TimeZoneLabel())
+}
+
+func TestLoadNamespaceSet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("defaults:\n  namespaces: [team-a, team-b]\n"), 0644))
+
+	// A namespace set starts in all namespaces, which it narrows
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, config.Defaults.Namespaces)
+	assert.Equal(t, "", config.CurrentNamespace)
+
+	config, err = Load(path, "", "", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", config.CurrentNamespace)
+}
+
+func TestSaveLastNamespace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("# my settings\ndefaults:\n  namespace: flux-system # default\n"), 0644))
+
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "flux-system", config.CurrentNamespace)
+
+	require.NoError(t, config.SaveLastNamespace("apps"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# my settings")
+	assert.Contains(t, string(data), "namespace: flux-system # default")
+	assert.Contains(t, string(data), "last_namespace: apps")
+
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "apps", config.CurrentNamespace)
+
+	// The namespace flag wins over the saved namespace
+	config, err = Load(path, "", "", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", config.CurrentNamespace)
+
+	// All namespaces is saved as "*" and replaced in place
+	require.NoError(t, config.SaveLastNamespace(""))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "last_namespace"))
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "", config.CurrentNamespace)
+	assert.Equal(t, AllNamespaces, config.LastNamespace)
+}
+
+func TestSaveUIPreferences(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("# my settings\nui:\n  theme: dark # keep\n  show_namespace: true\n"), 0600))
+
+	config, err := Load(path, "", "", "")
+	require.NoError(t, err)
+	config.UI.ShowNamespace = false
+	config.UI.ColumnsName = 45
+	require.NoError(t, config.SaveUIPreferences())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# my settings")
+	assert.Contains(t, string(data), "theme: dark # keep")
+	assert.Contains(t, string(data), "show_namespace: false\n")
+	assert.Contains(t, string(data), "columns_name: 45\n")
+
+	// Written through a rename, keeping the file's mode and no temp files
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	config, err = Load(path, "", "", "")
+	require.NoError(t, err)
+	assert.False(t, config.UI.ShowNamespace)
+	assert.Equal(t, 45, config.UI.ColumnsName)
+}
@@ -0,0 +1,78 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// Build information set by the build process
+var (
+	version   = "dev"
+	commit    = "none"
+	buildTime = "unknown"
+)
+
+// Info describes the running fluxcli build
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+	GoVersion string
+	// FluxAPIs maps compiled-in fluxcd API modules to their versions
+	FluxAPIs map[string]string
+}
+
+// Set sets the build information
+func Set(v, c, b string) {
+	version = v
+	commit = c
+	buildTime = b
+}
+
+// Get returns the build information including the fluxcd API module versions
+func Get() Info {
+	info := Info{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+		FluxAPIs:  make(map[string]string),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range bi.Deps {
+			if strings.HasPrefix(dep.Path, "github.com/fluxcd/") && strings.HasSuffix(dep.Path, "/api") {
+				info.FluxAPIs[dep.Path] = dep.Version
+			}
+		}
+	}
+
+	return info
+}
+
+// String renders the build information for display
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Version: %s\n", i.Version)
+	fmt.Fprintf(&b, "Commit: %s\n", i.Commit)
+	fmt.Fprintf(&b, "Build Time: %s\n", i.BuildTime)
+	fmt.Fprintf(&b, "Go Version: %s\n", i.GoVersion)
+
+	if len(i.FluxAPIs) > 0 {
+		modules := make([]string, 0, len(i.FluxAPIs))
+		for module := range i.FluxAPIs {
+			modules = append(modules, module)
+		}
+		sort.Strings(modules)
+
+		b.WriteString("Flux APIs:\n")
+		for _, module := range modules {
+			fmt.Fprintf(&b, "  %s %s\n", strings.TrimPrefix(module, "github.com/fluxcd/"), i.FluxAPIs[module])
+		}
+	}
+
+	return b.String()
+}
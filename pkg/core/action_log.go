@@ -0,0 +1,127 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// Action log outcomes
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// actionLogBuffer is how many entries may wait for the writer before new ones are dropped
+const actionLogBuffer = 256
+
+// ActionLogEntry is a mutating action recorded in the session log
+type ActionLogEntry struct {
+	Time      time.Time        `json:"time"`
+	Action    k8s.Action       `json:"action"`
+	Cluster   string           `json:"cluster"`
+	Type      k8s.ResourceType `json:"type"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Outcome   string           `json:"outcome"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// ActionLog appends mutating actions to a JSON lines file that persists across
+// sessions. Entries are written by a background goroutine so disk I/O never
+// blocks the caller.
+type ActionLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries chan ActionLogEntry
+	done    chan struct{}
+	closed  bool
+}
+
+// NewActionLog opens path for appending, creating it and its directory if needed
+func NewActionLog(path string) (*ActionLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create session log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session log %s: %w", path, err)
+	}
+
+	l := &ActionLog{
+		file:    file,
+		entries: make(chan ActionLogEntry, actionLogBuffer),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+	return l, nil
+}
+
+// Record queues an entry for writing, dropping it when the writer is backed up
+func (l *ActionLog) Record(entry ActionLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+	select {
+	case l.entries <- entry:
+	default:
+		// Drop rather than block the UI on a slow disk
+	}
+}
+
+// Close flushes queued entries and closes the file
+func (l *ActionLog) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.entries)
+	l.mu.Unlock()
+
+	<-l.done
+	return l.file.Close()
+}
+
+// run writes queued entries until the log is closed
+func (l *ActionLog) run() {
+	defer close(l.done)
+
+	encoder := json.NewEncoder(l.file)
+	for entry := range l.entries {
+		// A failed write must not take the session down; the entry is lost
+		_ = encoder.Encode(entry)
+	}
+}
+
+// logAction records the outcome of a mutating action on a cluster when the
+// session log is enabled
+func (m *Manager) logAction(cluster string, action k8s.Action, resourceType k8s.ResourceType, name, namespace string, err error) {
+	if m.actionLog == nil {
+		return
+	}
+
+	entry := ActionLogEntry{
+		Time:      time.Now(),
+		Action:    action,
+		Cluster:   cluster,
+		Type:      resourceType,
+		Namespace: namespace,
+		Name:      name,
+		Outcome:   OutcomeSuccess,
+	}
+	if err != nil {
+		entry.Outcome = OutcomeError
+		entry.Error = err.Error()
+	}
+	m.actionLog.Record(entry)
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/k8s/fake"
+)
+
+// readActionLog parses every entry of a session log
+func readActionLog(t *testing.T, path string) []ActionLogEntry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []ActionLogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry ActionLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestManager_SessionLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "session.jsonl")
+
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	cfg.SessionLog = config.SessionLogConfig{Enabled: true, Path: path}
+
+	client := fake.NewClient()
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+
+	require.NoError(t, manager.SuspendResource(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps"}))
+	client.Err = errors.New("forbidden")
+	assert.Error(t, manager.ReconcileResource(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps"}))
+
+	// Stop flushes the log
+	manager.Stop()
+
+	entries := readActionLog(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, k8s.ActionSuspend, entries[0].Action)
+	assert.Equal(t, "default", entries[0].Cluster)
+	assert.Equal(t, "flux-system", entries[0].Namespace)
+	assert.Equal(t, "apps", entries[0].Name)
+	assert.Equal(t, OutcomeSuccess, entries[0].Outcome)
+	assert.False(t, entries[0].Time.IsZero())
+
+	assert.Equal(t, k8s.ActionReconcile, entries[1].Action)
+	assert.Equal(t, OutcomeError, entries[1].Outcome)
+	assert.Equal(t, "forbidden", entries[1].Error)
+}
+
+func TestManager_SessionLogCluster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	cfg.SessionLog = config.SessionLogConfig{Enabled: true, Path: path}
+	cfg.Clusters = append(cfg.Clusters, config.ClusterConfig{Name: "production", Context: "production"})
+
+	clients := map[string]*fake.Client{"default": fake.NewClient(), "production": fake.NewClient()}
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return clients[context], nil
+	})
+	require.NoError(t, manager.Start())
+
+	// Bulk actions are logged under the cluster each resource lives on
+	results := manager.SuspendResources([]k8s.Resource{{Cluster: "production", Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}})
+	require.NoError(t, results[0].Err)
+	manager.Stop()
+
+	entries := readActionLog(t, path)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "production", entries[0].Cluster)
+}
+
+func TestActionLog_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	// Each session appends to the entries of earlier ones
+	for i := 0; i < 2; i++ {
+		log, err := NewActionLog(path)
+		require.NoError(t, err)
+		log.Record(ActionLogEntry{Action: k8s.ActionResume, Name: "apps", Outcome: OutcomeSuccess})
+		require.NoError(t, log.Close())
+
+		// Records after close are ignored
+		log.Record(ActionLogEntry{Action: k8s.ActionSuspend})
+	}
+
+	assert.Len(t, readActionLog(t, path), 2)
+}
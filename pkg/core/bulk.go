@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// bulkConcurrency caps how many calls of a bulk action run at once
+const bulkConcurrency = 10
+
+// BulkResult is the outcome of a bulk action on one resource
+type BulkResult struct {
+	Resource k8s.Resource
+	Err      error
+}
+
+// SuspendResources suspends resources concurrently, each in its own cluster
+// and namespace. Every resource is attempted; the results are in input order.
+func (m *Manager) SuspendResources(resources []k8s.Resource) []BulkResult {
+	return m.runBulk(k8s.ActionSuspend, resources, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.SuspendResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// ResumeResources resumes resources concurrently, each in its own cluster and
+// namespace. Every resource is attempted; the results are in input order.
+func (m *Manager) ResumeResources(resources []k8s.Resource) []BulkResult {
+	return m.runBulk(k8s.ActionResume, resources, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.ResumeResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// ReconcileResources requests a reconcile of resources concurrently, each in
+// its own cluster and namespace. Every resource is attempted; the results are
+// in input order.
+func (m *Manager) ReconcileResources(resources []k8s.Resource) []BulkResult {
+	return m.runBulk(k8s.ActionReconcile, resources, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.ReconcileResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// runBulk runs an action on each resource, collecting errors rather than
+// stopping at the first
+func (m *Manager) runBulk(action k8s.Action, resources []k8s.Resource, run func(context.Context, k8s.FluxClient, k8s.Resource) error) []BulkResult {
+	results := make([]BulkResult, len(resources))
+	semaphore := make(chan struct{}, bulkConcurrency)
+
+	var wg sync.WaitGroup
+	for i, resource := range resources {
+		wg.Add(1)
+		go func(i int, r k8s.Resource) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = BulkResult{Resource: r, Err: m.runOn(action, r, 10*time.Second, run)}
+		}(i, resource)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runOn runs an action on one resource, on the cluster it was listed from.
+// Resources named on the command line carry no cluster or namespace and run
+// in the current ones.
+func (m *Manager) runOn(action k8s.Action, r k8s.Resource, timeout time.Duration, run func(context.Context, k8s.FluxClient, k8s.Resource) error) error {
+	if r.Namespace == "" {
+		r.Namespace = m.GetCurrentNamespace()
+	}
+	client, cluster, err := m.resourceClient(r)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), timeout)
+	defer cancel()
+
+	err = run(ctx, client, r)
+	m.logAction(cluster, action, r.Type, r.Name, r.Namespace, err)
+	return err
+}
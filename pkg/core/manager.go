@@ -3,30 +3,87 @@ package core
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/malagant/fluxcli/internal/config"
 	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Manager manages FluxCD resources across multiple clusters
 type Manager struct {
 	config   *config.Config
-	clusters map[string]*k8s.Client
+	clusters map[string]k8s.FluxClient
 	mu       sync.RWMutex
+	workers  sync.WaitGroup // Background refresh loops
+	startMu  sync.Mutex     // Held by Start, so Stop waits for a start in flight
+	recorder *k8s.Recorder
+	actionLog *ActionLog
+	newClient ClientFactory
+	unreachable map[string]error // Fleet clusters that failed to connect
+	installed   map[string]bool  // "<cluster>/<type>" known to be installed
+	controllers map[string]controllerCheck // Per cluster, the latest controller readiness check
+	metricsListener net.Listener // Metrics server listener, nil when disabled
+	snapshotMu      sync.Mutex
+	snapshot        map[snapshotKey][]k8s.Resource // Latest list of each type on each cluster, for metrics
 	
 	// Event channels for UI updates
 	resourceUpdates chan ResourceUpdate
 	eventUpdates    chan EventUpdate
 	errorUpdates    chan ErrorUpdate
+	warningUpdates  chan WarningUpdate
+	refreshRequests chan struct{} // Lists all types ahead of the next tick
 	
 	// Internal state
 	currentCluster   string
 	currentNamespace string
+	labelSelector    labels.Selector // Narrows every list, nil lists all
 	ctx              context.Context
 	cancel           context.CancelFunc
+
+	// The cluster connected through the kubeconfig context, which
+	// SwitchContext replaces; cancelling contextCtx aborts its in-flight calls
+	contextCluster string
+	contextCtx     context.Context
+	contextCancel  context.CancelFunc
+	kubeContexts   map[string]string // Cluster name -> kubeconfig context
+}
+
+// ClientFactory creates a client for a cluster
+type ClientFactory func(kubeconfig, context, namespace string) (k8s.FluxClient, error)
+
+// defaultClientFactory connects to real clusters
+func defaultClientFactory(cfg *config.Config) ClientFactory {
+	return func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		// With --in-cluster only the startup connection uses the service
+		// account, contexts switched to later come from the kubeconfig
+		var client *k8s.Client
+		var err error
+		if cfg.InCluster && context == k8s.InClusterContext {
+			client, err = k8s.NewInClusterClient(namespace)
+		} else {
+			client, err = k8s.NewClient(kubeconfig, context, namespace)
+		}
+		if err != nil {
+			return nil, err
+		}
+		client.ReconcileDedupWindow = cfg.Defaults.ReconcileDedupWindow
+		client.OverdueMargin = cfg.Defaults.OverdueMargin
+		client.EventsLookback = cfg.Defaults.EventsLookback
+		client.APIRetries = cfg.Defaults.APIRetries
+		client.RequestTimeout = cfg.Defaults.RequestTimeout
+		client.ListPageSize = cfg.Defaults.ListPageSize
+		client.ListLimit = cfg.Defaults.ListLimit
+		return client, nil
+	}
 }
 
 // ResourceUpdate represents a resource state update
@@ -34,6 +91,10 @@ type ResourceUpdate struct {
 	Cluster   string
 	Resources []k8s.Resource
 	Type      k8s.ResourceType
+	Err       error // Fleet mode only: the cluster could not be listed
+	NotInstalled bool // The type's CRD is missing, so the empty list is expected
+	Truncated    bool // The list stopped at the list limit with more on the server
+	Controller   *k8s.ControllerStatus // The controller reconciling Type, nil when unknown
 }
 
 // EventUpdate represents an event update
@@ -48,25 +109,84 @@ type ErrorUpdate struct {
 	Error   error
 }
 
+// WarningUpdate represents a warning returned by the API server
+type WarningUpdate struct {
+	Cluster string
+	Message string
+}
+
 // NewManager creates a new resource manager
 func NewManager(cfg *config.Config) *Manager {
+	return NewManagerWithClientFactory(cfg, defaultClientFactory(cfg))
+}
+
+// NewManagerWithClientFactory creates a resource manager that builds cluster
+// clients with the given factory, which lets tests substitute fake clients
+func NewManagerWithClientFactory(cfg *config.Config, factory ClientFactory) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
+	contextCtx, contextCancel := context.WithCancel(ctx)
 	
 	return &Manager{
 		config:          cfg,
-		clusters:        make(map[string]*k8s.Client),
+		clusters:        make(map[string]k8s.FluxClient),
+		unreachable:     make(map[string]error),
+		installed:       make(map[string]bool),
+		controllers:     make(map[string]controllerCheck),
+		snapshot:        make(map[snapshotKey][]k8s.Resource),
 		resourceUpdates: make(chan ResourceUpdate, 100),
 		eventUpdates:    make(chan EventUpdate, 100),
 		errorUpdates:    make(chan ErrorUpdate, 100),
+		warningUpdates:  make(chan WarningUpdate, 100),
+		refreshRequests: make(chan struct{}, 1),
 		currentCluster:  cfg.CurrentContext,
 		currentNamespace: cfg.CurrentNamespace,
 		ctx:             ctx,
 		cancel:          cancel,
+		newClient:       factory,
+		contextCluster:  cfg.CurrentContext,
+		contextCtx:      contextCtx,
+		contextCancel:   contextCancel,
+		kubeContexts:    make(map[string]string),
 	}
 }
 
-// Start initializes the manager and starts background processes
+// Start initializes the manager and starts background processes. A Start
+// still connecting when Stop is called returns without starting them.
 func (m *Manager) Start() error {
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+	if err := m.ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := m.listenMetrics(); err != nil {
+		return err
+	}
+
+	if m.config.ReplayFile != "" {
+		return m.startReplay()
+	}
+
+	if m.config.RecordFile != "" {
+		recorder, err := k8s.NewRecorder(m.config.RecordFile)
+		if err != nil {
+			return err
+		}
+		m.recorder = recorder
+	}
+
+	if m.config.SessionLog.Enabled {
+		actionLog, err := NewActionLog(m.config.SessionLog.Path)
+		if err != nil {
+			return err
+		}
+		m.actionLog = actionLog
+	}
+
+	if m.config.Fleet {
+		return m.startFleet()
+	}
+
 	// Initialize default cluster connection
 	if err := m.connectToCluster(m.currentCluster, m.config.CurrentKubeConfig, m.config.CurrentContext); err != nil {
 		return fmt.Errorf("failed to connect to default cluster: %w", err)
@@ -75,31 +195,138 @@ func (m *Manager) Start() error {
 	// Initialize configured clusters
 	for _, clusterCfg := range m.config.Clusters {
 		if err := m.connectToCluster(clusterCfg.Name, clusterCfg.Kubeconfig, clusterCfg.Context); err != nil {
-			m.errorUpdates <- ErrorUpdate{
+			m.sendError(ErrorUpdate{
 				Cluster: clusterCfg.Name,
 				Error:   fmt.Errorf("failed to connect to cluster %s: %w", clusterCfg.Name, err),
-			}
+			})
 		}
 	}
 
 	// Start background refresh
-	go m.startResourceRefresh()
-	go m.startEventRefresh()
+	return m.startBackground()
+}
+
+// startReplay serves recorded snapshots instead of connecting to clusters
+func (m *Manager) startReplay() error {
+	clients, err := k8s.LoadRecording(m.config.ReplayFile)
+	if err != nil {
+		return err
+	}
 
+	m.mu.Lock()
+	for name, client := range clients {
+		m.clusters[name] = client
+	}
+	m.mu.Unlock()
+
+	// Fall back to a recorded cluster when the current one wasn't recorded
+	if _, exists := clients[m.currentCluster]; !exists {
+		names := make([]string, 0, len(clients))
+		for name := range clients {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		m.currentCluster = names[0]
+	}
+
+	return m.startBackground()
+}
+
+// startFleet connects to every fleet context in parallel. Contexts that fail
+// to connect are reported as unreachable rows instead of aborting startup.
+func (m *Manager) startFleet() error {
+	contexts := m.config.FleetContexts
+	if len(contexts) == 0 {
+		var err error
+		contexts, err = k8s.ListContexts(m.config.CurrentKubeConfig)
+		if err != nil {
+			return err
+		}
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no kubeconfig contexts found")
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, m.config.Defaults.MaxConcurrentClusters)
+	for _, name := range contexts {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := m.connectToCluster(name, m.config.CurrentKubeConfig, name); err != nil {
+				m.mu.Lock()
+				m.unreachable[name] = fmt.Errorf("failed to connect: %w", err)
+				m.mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if !containsString(contexts, m.currentCluster) {
+		m.currentCluster = contexts[0]
+	}
+
+	return m.startBackground()
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// startBackground starts the resource and event refresh loops, and the
+// metrics server if enabled, unless the manager was stopped while connecting
+func (m *Manager) startBackground() error {
+	if err := m.ctx.Err(); err != nil {
+		if m.metricsListener != nil {
+			m.metricsListener.Close()
+		}
+		return err
+	}
+	m.serveMetrics()
+	m.workers.Add(2)
+	go func() {
+		defer m.workers.Done()
+		m.startResourceRefresh()
+	}()
+	go func() {
+		defer m.workers.Done()
+		m.startEventRefresh()
+	}()
 	return nil
 }
 
 // Stop stops the manager and closes all connections
 func (m *Manager) Stop() {
 	m.cancel()
+	// A Start in flight would otherwise spawn loops that send on closed channels
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+	// Refresh loops may be mid-send, let them finish before closing channels
+	m.workers.Wait()
+	if m.recorder != nil {
+		m.recorder.Close()
+	}
+	if m.actionLog != nil {
+		m.actionLog.Close()
+	}
 	close(m.resourceUpdates)
 	close(m.eventUpdates)
 	close(m.errorUpdates)
+	close(m.warningUpdates)
 }
 
 // connectToCluster establishes a connection to a Kubernetes cluster
 func (m *Manager) connectToCluster(name, kubeconfig, context string) error {
-	client, err := k8s.NewClient(kubeconfig, context, m.currentNamespace)
+	client, err := m.newClient(kubeconfig, context, m.GetCurrentNamespace())
 	if err != nil {
 		return err
 	}
@@ -109,13 +336,77 @@ func (m *Manager) connectToCluster(name, kubeconfig, context string) error {
 		return fmt.Errorf("connection test failed: %w", err)
 	}
 
+	// An empty context means the kubeconfig's current context
+	if context == "" {
+		context, _ = k8s.CurrentContext(kubeconfig)
+	}
+
 	m.mu.Lock()
 	m.clusters[name] = client
+	m.kubeContexts[name] = context
+	m.mu.Unlock()
+
+	m.watchCluster(m.clusterContext(name), name, client)
+	return nil
+}
+
+// SwitchContext reconnects the kubeconfig context cluster to another context
+// and makes it current. Calls still in flight against the old context are
+// cancelled and their results dropped.
+func (m *Manager) SwitchContext(kubeContext string) error {
+	if m.config.Fleet || m.config.ReplayFile != "" {
+		return fmt.Errorf("switching contexts is not supported in fleet or replay mode")
+	}
+
+	client, err := m.newClient(m.config.CurrentKubeConfig, kubeContext, m.GetCurrentNamespace())
+	if err != nil {
+		return fmt.Errorf("failed to connect to context %s: %w", kubeContext, err)
+	}
+	if err := client.TestConnection(m.ctx); err != nil {
+		return fmt.Errorf("failed to connect to context %s: connection test failed: %w", kubeContext, err)
+	}
+
+	m.mu.Lock()
+	m.contextCancel()
+	delete(m.clusters, m.contextCluster)
+	delete(m.kubeContexts, m.contextCluster)
+	m.clusters[kubeContext] = client
+	// Recorded here under the lock rather than in m.config, which the UI
+	// reads without it; GetCurrentContext reports it from here
+	m.kubeContexts[kubeContext] = kubeContext
+	m.contextCluster = kubeContext
+	m.contextCtx, m.contextCancel = context.WithCancel(m.ctx)
+	m.currentCluster = kubeContext
+	ctx := m.contextCtx
 	m.mu.Unlock()
 
+	m.watchCluster(ctx, kubeContext, client)
+	m.RequestRefresh()
 	return nil
 }
 
+// GetCurrentContext returns the kubeconfig context of the current cluster
+func (m *Manager) GetCurrentContext() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.kubeContexts[m.currentCluster]
+}
+
+// ListContexts returns the contexts defined in the kubeconfig
+func (m *Manager) ListContexts() ([]string, error) {
+	return k8s.ListContexts(m.config.CurrentKubeConfig)
+}
+
+// clusterContext returns the context calls against a cluster run under
+func (m *Manager) clusterContext(name string) context.Context {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if name == m.contextCluster {
+		return m.contextCtx
+	}
+	return m.ctx
+}
+
 // GetResourceUpdates returns the channel for resource updates
 func (m *Manager) GetResourceUpdates() <-chan ResourceUpdate {
 	return m.resourceUpdates
@@ -131,6 +422,11 @@ func (m *Manager) GetErrorUpdates() <-chan ErrorUpdate {
 	return m.errorUpdates
 }
 
+// GetWarningUpdates returns the channel for API server warnings
+func (m *Manager) GetWarningUpdates() <-chan WarningUpdate {
+	return m.warningUpdates
+}
+
 // GetClusters returns the list of available clusters
 func (m *Manager) GetClusters() []string {
 	m.mu.RLock()
@@ -153,22 +449,72 @@ func (m *Manager) SetCurrentCluster(cluster string) error {
 		return fmt.Errorf("cluster %s not found", cluster)
 	}
 	
+	m.mu.Lock()
 	m.currentCluster = cluster
+	m.mu.Unlock()
 	return nil
 }
 
 // GetCurrentCluster returns the current active cluster
 func (m *Manager) GetCurrentCluster() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.currentCluster
 }
 
-// SetCurrentNamespace sets the current namespace
+// SetCurrentNamespace sets the current namespace and re-lists every type in it
 func (m *Manager) SetCurrentNamespace(namespace string) {
+	m.mu.Lock()
 	m.currentNamespace = namespace
+	m.mu.Unlock()
+	m.RequestRefresh()
+}
+
+// SetLabelSelector narrows every list to the resources matching a selector
+// such as "team=payments" and re-lists every type; "" lists all of them
+func (m *Manager) SetLabelSelector(selector string) error {
+	parsed, err := k8s.ParseLabelSelector(selector)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.labelSelector = parsed
+	m.mu.Unlock()
+	m.RequestRefresh()
+	return nil
+}
+
+// GetLabelSelector returns the current label selector, "" when lists aren't
+// narrowed
+func (m *Manager) GetLabelSelector() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.labelSelector == nil {
+		return ""
+	}
+	return m.labelSelector.String()
+}
+
+// listOptions returns the options every list runs with
+func (m *Manager) listOptions() []k8s.ListOption {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return []k8s.ListOption{k8s.WithLabelSelector(m.labelSelector)}
+}
+
+// RequestRefresh lists all resource types right away instead of waiting for
+// the next refresh tick. Requests made while one is pending are merged.
+func (m *Manager) RequestRefresh() {
+	select {
+	case m.refreshRequests <- struct{}{}:
+	default:
+	}
 }
 
 // GetCurrentNamespace returns the current namespace
 func (m *Manager) GetCurrentNamespace() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.currentNamespace
 }
 
@@ -182,69 +528,323 @@ func (m *Manager) ListResources(resourceType k8s.ResourceType) ([]k8s.Resource,
 		return nil, fmt.Errorf("cluster %s not connected", m.currentCluster)
 	}
 
-	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+	ctx, cancel := context.WithTimeout(m.clusterContext(m.currentCluster), 10*time.Second)
 	defer cancel()
 
-	switch resourceType {
-	case k8s.ResourceTypeGitRepository:
-		return client.ListGitRepositories(ctx, m.currentNamespace)
-	case k8s.ResourceTypeHelmRepository:
-		return client.ListHelmRepositories(ctx, m.currentNamespace)
-	case k8s.ResourceTypeKustomization:
-		return client.ListKustomizations(ctx, m.currentNamespace)
-	case k8s.ResourceTypeHelmRelease:
-		return client.ListHelmReleases(ctx, m.currentNamespace)
-	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	// A configured namespace set narrows all namespaces to those it names
+	namespace := m.GetCurrentNamespace()
+	if namespace == "" && len(m.config.Defaults.Namespaces) > 0 {
+		return k8s.ListInNamespaces(ctx, client, resourceType, m.config.Defaults.Namespaces, m.listOptions()...)
 	}
+	return k8s.ListResources(ctx, client, resourceType, namespace, m.listOptions()...)
 }
 
-// SuspendResource suspends a FluxCD resource
-func (m *Manager) SuspendResource(resourceType k8s.ResourceType, name string) error {
+// CountResources estimates how many resources of a type exist in a namespace
+// ("" for all namespaces) on the current cluster
+func (m *Manager) CountResources(resourceType k8s.ResourceType, namespace string) (int64, error) {
 	m.mu.RLock()
 	client, exists := m.clusters[m.currentCluster]
 	m.mu.RUnlock()
 	
 	if !exists {
-		return fmt.Errorf("cluster %s not connected", m.currentCluster)
+		return 0, fmt.Errorf("cluster %s not connected", m.currentCluster)
 	}
 
 	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
 	defer cancel()
 
-	return client.SuspendResource(ctx, resourceType, name, m.currentNamespace)
+	return client.CountResources(ctx, resourceType, namespace)
+}
+
+// SuspendResource suspends a FluxCD resource on the cluster it was listed from
+func (m *Manager) SuspendResource(resource k8s.Resource) error {
+	return m.runOn(k8s.ActionSuspend, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.SuspendResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// ResumeResource resumes a FluxCD resource on the cluster it was listed from
+func (m *Manager) ResumeResource(resource k8s.Resource) error {
+	return m.runOn(k8s.ActionResume, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.ResumeResource(ctx, r.Type, r.Name, r.Namespace)
+	})
 }
 
-// ResumeResource resumes a FluxCD resource
-func (m *Manager) ResumeResource(resourceType k8s.ResourceType, name string) error {
+// ReconcileResource triggers reconciliation of a FluxCD resource on the
+// cluster it was listed from
+func (m *Manager) ReconcileResource(resource k8s.Resource) error {
+	return m.runOn(k8s.ActionReconcile, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.ReconcileResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// sourceReconcileTimeout bounds how long ReconcileWithSource waits for the
+// source to fetch
+const sourceReconcileTimeout = 2 * time.Minute
+
+// ReconcileWithSource reconciles a Kustomization or HelmRelease after its
+// source has fetched, returning the source's artifact revision
+func (m *Manager) ReconcileWithSource(resource k8s.Resource) (string, error) {
+	var revision string
+	err := m.runOn(k8s.ActionReconcileWithSource, resource, sourceReconcileTimeout, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) (err error) {
+		revision, err = client.ReconcileWithSource(ctx, r.Type, r.Name, r.Namespace)
+		return err
+	})
+	return revision, err
+}
+
+// ResetHelmRelease resets the remediation retries of a HelmRelease
+func (m *Manager) ResetHelmRelease(resource k8s.Resource) error {
+	return m.runOn(k8s.ActionReset, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.ResetHelmRelease(ctx, r.Name, r.Namespace)
+	})
+}
+
+// DeleteResource deletes a FluxCD resource on the cluster it was listed from
+func (m *Manager) DeleteResource(resource k8s.Resource) error {
+	return m.runOn(k8s.ActionDelete, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.DeleteResource(ctx, r.Type, r.Name, r.Namespace)
+	})
+}
+
+// SnoozeResource mutes a resource in triage views until the given time, a
+// zero time lifts the snooze
+func (m *Manager) SnoozeResource(resource k8s.Resource, until time.Time) error {
+	action := k8s.ActionSnooze
+	if until.IsZero() {
+		action = k8s.ActionUnsnooze
+	}
+	return m.runOn(action, resource, 10*time.Second, func(ctx context.Context, client k8s.FluxClient, r k8s.Resource) error {
+		return client.SnoozeResource(ctx, r.Type, r.Name, r.Namespace, until)
+	})
+}
+
+// resourceClient returns the client of the cluster a resource was listed
+// from, or of the current cluster for resources that don't carry one
+func (m *Manager) resourceClient(resource k8s.Resource) (k8s.FluxClient, string, error) {
 	m.mu.RLock()
-	client, exists := m.clusters[m.currentCluster]
-	m.mu.RUnlock()
-	
+	defer m.mu.RUnlock()
+
+	cluster := resource.Cluster
+	if cluster == "" {
+		cluster = m.currentCluster
+	}
+	client, exists := m.clusters[cluster]
 	if !exists {
-		return fmt.Errorf("cluster %s not connected", m.currentCluster)
+		return nil, cluster, fmt.Errorf("cluster %s not connected", cluster)
 	}
+	return client, cluster, nil
+}
 
-	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+// GetInventoryHealth computes the workload status of a Kustomization's
+// inventory on its cluster
+func (m *Manager) GetInventoryHealth(resource k8s.Resource) (*k8s.InventoryHealth, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 30*time.Second)
 	defer cancel()
 
-	return client.ResumeResource(ctx, resourceType, name, m.currentNamespace)
+	return client.GetInventoryHealth(ctx, resource.Name, resource.Namespace)
 }
 
-// ReconcileResource triggers reconciliation of a FluxCD resource
-func (m *Manager) ReconcileResource(resourceType k8s.ResourceType, name string) error {
+// ListNamespaces returns the namespaces of the current cluster
+func (m *Manager) ListNamespaces() ([]string, error) {
 	m.mu.RLock()
 	client, exists := m.clusters[m.currentCluster]
 	m.mu.RUnlock()
-	
+
 	if !exists {
-		return fmt.Errorf("cluster %s not connected", m.currentCluster)
+		return nil, fmt.Errorf("cluster %s not connected", m.currentCluster)
 	}
 
 	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
 	defer cancel()
 
-	return client.ReconcileResource(ctx, resourceType, name, m.currentNamespace)
+	return client.ListNamespaces(ctx)
+}
+
+// GetInventory returns the objects applied by a Kustomization on its cluster
+func (m *Manager) GetInventory(resource k8s.Resource) ([]k8s.ObjectRef, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 10*time.Second)
+	defer cancel()
+
+	return client.GetInventory(ctx, resource.Name, resource.Namespace)
+}
+
+// GetResource re-fetches a single resource from its cluster
+func (m *Manager) GetResource(resource k8s.Resource) (*k8s.Resource, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 10*time.Second)
+	defer cancel()
+
+	fresh, err := client.GetResource(ctx, resource.Type, resource.Name, resource.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	fresh.Cluster = cluster
+	return fresh, nil
+}
+
+// ResolveSource fetches the source a Kustomization or HelmRelease references,
+// from the cluster the resource lives on
+func (m *Manager) ResolveSource(resource k8s.Resource) (*k8s.Resource, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 10*time.Second)
+	defer cancel()
+
+	source, err := client.ResolveSource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	source.Cluster = cluster
+	return source, nil
+}
+
+// GetResourceYAML returns the full manifest of a resource from its cluster
+func (m *Manager) GetResourceYAML(resource k8s.Resource) (string, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 10*time.Second)
+	defer cancel()
+
+	return client.GetResourceYAML(ctx, resource.Type, resource.Name, resource.Namespace)
+}
+
+// GetResourceEvents returns the events of a resource on its cluster, newest
+// first
+func (m *Manager) GetResourceEvents(resource k8s.Resource) ([]corev1.Event, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 10*time.Second)
+	defer cancel()
+
+	return client.GetResourceEvents(ctx, resource)
+}
+
+// GetControllerLogs returns the recent logs of the controller reconciling a
+// resource, on the resource's cluster
+func (m *Manager) GetControllerLogs(resource k8s.Resource, sinceSeconds int64) (string, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 30*time.Second)
+	defer cancel()
+
+	return client.GetControllerLogs(ctx, resource.Type, sinceSeconds)
+}
+
+// BuildDependencyTree returns the tree of objects a Kustomization or
+// HelmRelease manages on its cluster
+func (m *Manager) BuildDependencyTree(resource k8s.Resource) (*k8s.TreeNode, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), 30*time.Second)
+	defer cancel()
+
+	return client.BuildDependencyTree(ctx, resource.Type, resource.Name, resource.Namespace)
+}
+
+// kustomizationDiffTimeout bounds fetching, building and dry-running a
+// Kustomization for DiffKustomization
+const kustomizationDiffTimeout = time.Minute
+
+// DiffKustomization diffs a Kustomization, built from its source artifact,
+// against the objects in its cluster
+func (m *Manager) DiffKustomization(resource k8s.Resource) (string, error) {
+	client, cluster, err := m.resourceClient(resource)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(m.clusterContext(cluster), kustomizationDiffTimeout)
+	defer cancel()
+
+	return client.DiffKustomization(ctx, resource.Name, resource.Namespace)
+}
+
+// CompareResource diffs the same resource between two clusters and returns a
+// unified diff. A resource missing from one cluster diffs against an empty manifest.
+func (m *Manager) CompareResource(resourceType k8s.ResourceType, name, clusterA, clusterB string) (string, error) {
+	m.mu.RLock()
+	clientA, existsA := m.clusters[clusterA]
+	clientB, existsB := m.clusters[clusterB]
+	m.mu.RUnlock()
+
+	if !existsA {
+		return "", fmt.Errorf("cluster %s not connected", clusterA)
+	}
+	if !existsB {
+		return "", fmt.Errorf("cluster %s not connected", clusterB)
+	}
+
+	namespace := m.GetCurrentNamespace()
+
+	// Each cluster's GET is cancelled with that cluster, e.g. on a context switch
+	ctxA, cancelA := context.WithTimeout(m.clusterContext(clusterA), 10*time.Second)
+	defer cancelA()
+	yamlA, errA := clientA.GetComparableYAML(ctxA, resourceType, name, namespace)
+	if errA != nil && !apierrors.IsNotFound(errA) {
+		return "", fmt.Errorf("[%s] %w", clusterA, errA)
+	}
+	ctxB, cancelB := context.WithTimeout(m.clusterContext(clusterB), 10*time.Second)
+	defer cancelB()
+	yamlB, errB := clientB.GetComparableYAML(ctxB, resourceType, name, namespace)
+	if errB != nil && !apierrors.IsNotFound(errB) {
+		return "", fmt.Errorf("[%s] %w", clusterB, errB)
+	}
+
+	if errA != nil && errB != nil {
+		return "", fmt.Errorf("%s %s/%s not found in %s or %s", resourceType, namespace, name, clusterA, clusterB)
+	}
+
+	fromFile := fmt.Sprintf("%s/%s/%s", clusterA, namespace, name)
+	toFile := fmt.Sprintf("%s/%s/%s", clusterB, namespace, name)
+	if errA != nil {
+		fromFile += " (not found)"
+	}
+	if errB != nil {
+		toFile += " (not found)"
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(yamlA),
+		B:        difflib.SplitLines(yamlB),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s/%s: %w", resourceType, name, err)
+	}
+
+	return diff, nil
 }
 
 // startResourceRefresh starts the background resource refresh process
@@ -252,12 +852,10 @@ func (m *Manager) startResourceRefresh() {
 	ticker := time.NewTicker(m.config.Defaults.RefreshInterval)
 	defer ticker.Stop()
 
-	resourceTypes := []k8s.ResourceType{
-		k8s.ResourceTypeGitRepository,
-		k8s.ResourceTypeHelmRepository,
-		k8s.ResourceTypeKustomization,
-		k8s.ResourceTypeHelmRelease,
-	}
+	resourceTypes := k8s.ResourceTypes()
+
+	// List right away rather than leaving the UI empty for a whole interval
+	m.refreshResources(resourceTypes)
 
 	for {
 		select {
@@ -265,6 +863,9 @@ func (m *Manager) startResourceRefresh() {
 			return
 		case <-ticker.C:
 			m.refreshResources(resourceTypes)
+		case <-m.refreshRequests:
+			m.refreshResources(resourceTypes)
+			ticker.Reset(m.config.Defaults.RefreshInterval)
 		}
 	}
 }
@@ -272,67 +873,317 @@ func (m *Manager) startResourceRefresh() {
 // refreshResources refreshes all resources for all clusters
 func (m *Manager) refreshResources(resourceTypes []k8s.ResourceType) {
 	m.mu.RLock()
-	clusters := make(map[string]*k8s.Client)
+	clusters := make(map[string]k8s.FluxClient)
 	for name, client := range m.clusters {
 		clusters[name] = client
 	}
+	unreachable := make(map[string]error, len(m.unreachable))
+	for name, err := range m.unreachable {
+		unreachable[name] = err
+	}
 	m.mu.RUnlock()
 
+	for name, err := range unreachable {
+		for _, resourceType := range resourceTypes {
+			m.sendResourceUpdate(ResourceUpdate{Cluster: name, Type: resourceType, Err: err})
+		}
+	}
+
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, m.config.Defaults.MaxConcurrentClusters)
 
 	for clusterName, client := range clusters {
 		wg.Add(1)
-		go func(name string, c *k8s.Client) {
+		go func(name string, c k8s.FluxClient) {
 			defer wg.Done()
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			for _, resourceType := range resourceTypes {
-				resources, err := m.listResourcesForCluster(c, resourceType)
-				if err != nil {
-					m.errorUpdates <- ErrorUpdate{
-						Cluster: name,
-						Error:   fmt.Errorf("failed to list %s: %w", resourceType, err),
-					}
+			ctx := m.clusterContext(name)
+			m.checkControllers(ctx, name, c)
+			if !m.refreshClusterTypes(ctx, name, c, resourceTypes) {
+				return
+			}
+
+			m.publishWarnings(name, c)
+		}(clusterName, client)
+	}
+
+	wg.Wait()
+}
+
+// refreshClusterTypes lists several types on one cluster in parallel, so a
+// slow API server isn't waited on once per type. It returns false once the
+// results would be stale or the manager is stopped.
+func (m *Manager) refreshClusterTypes(ctx context.Context, name string, c k8s.FluxClient, resourceTypes []k8s.ResourceType) bool {
+	var stale atomic.Bool
+	var g errgroup.Group
+	g.SetLimit(k8s.ListConcurrency)
+	for _, resourceType := range resourceTypes {
+		g.Go(func() error {
+			if !stale.Load() && !m.refreshClusterType(ctx, name, c, resourceType) {
+				stale.Store(true)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+	return !stale.Load()
+}
+
+// refreshClusterType lists one type on one cluster and publishes the update,
+// returning false once the results would be stale or the manager is stopped
+func (m *Manager) refreshClusterType(ctx context.Context, name string, c k8s.FluxClient, resourceType k8s.ResourceType) bool {
+	resources, err := m.listResourcesForCluster(ctx, c, resourceType)
+	if ctx.Err() != nil {
+		// Switched away from this context, its results are stale
+		return false
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to list %s: %w", resourceType, err)
+		if m.config.Fleet {
+			// Annotate the cluster's rows rather than failing the whole table
+			m.sendResourceUpdate(ResourceUpdate{Cluster: name, Type: resourceType, Err: err})
+			return true
+		}
+		m.sendError(ErrorUpdate{Cluster: name, Error: err})
+		return true
+	}
+	resources = tagCluster(name, resources)
+	notInstalled := len(resources) == 0 && !m.isInstalled(name, c, resourceType)
+	truncated := false
+	if pager, ok := c.(k8s.Pager); ok {
+		truncated = pager.Truncated(resourceType)
+	}
+	var controller *k8s.ControllerStatus
+	if !notInstalled {
+		controller = m.controllerStatus(name, resourceType)
+	}
+	m.recordSnapshot(name, resourceType, resources)
+
+	if m.recorder != nil {
+		if err := m.recorder.RecordResources(name, resourceType, resources); err != nil {
+			m.sendError(ErrorUpdate{Cluster: name, Error: err})
+		}
+	}
+
+	return m.sendResourceUpdate(ResourceUpdate{
+		Cluster:   name,
+		Resources: resources,
+		Type:      resourceType,
+		NotInstalled: notInstalled,
+		Truncated:    truncated,
+		Controller:   controller,
+	})
+}
+
+// watchCluster serves a cluster's lists from a watch cache when its client
+// supports one, and lists a type again as soon as one of its resources
+// changes. Periodic refreshes carry on, reading watched types from the cache.
+func (m *Manager) watchCluster(ctx context.Context, name string, client k8s.FluxClient) {
+	watcher, ok := client.(k8s.Watcher)
+	if !ok {
+		return
+	}
+
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+
+		changes, err := watcher.StartWatching(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				m.sendWarning(WarningUpdate{Cluster: name, Message: fmt.Sprintf("Watching resources failed, polling instead: %v", err)})
+			}
+			return
+		}
+		m.consumeChanges(ctx, name, client, changes)
+	}()
+}
+
+// watchDebounce batches a burst of changes into one list per type
+const watchDebounce = 200 * time.Millisecond
+
+// consumeChanges lists the types reported changed until ctx is done
+func (m *Manager) consumeChanges(ctx context.Context, name string, c k8s.FluxClient, changes <-chan k8s.ResourceType) {
+	pending := make(map[k8s.ResourceType]bool)
+	var flush <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case resourceType := <-changes:
+			pending[resourceType] = true
+			if flush == nil {
+				flush = time.After(watchDebounce)
+			}
+		case <-flush:
+			flush = nil
+			for _, resourceType := range k8s.ResourceTypes() {
+				if !pending[resourceType] {
 					continue
 				}
-
-				select {
-				case m.resourceUpdates <- ResourceUpdate{
-					Cluster:   name,
-					Resources: resources,
-					Type:      resourceType,
-				}:
-				case <-m.ctx.Done():
+				delete(pending, resourceType)
+				if !m.refreshClusterType(ctx, name, c, resourceType) {
 					return
 				}
 			}
-		}(clusterName, client)
+			m.publishWarnings(name, c)
+		}
 	}
+}
 
-	wg.Wait()
+// sendResourceUpdate publishes a resource update, returning false once the
+// manager is stopped
+func (m *Manager) sendResourceUpdate(update ResourceUpdate) bool {
+	select {
+	case m.resourceUpdates <- update:
+		return true
+	case <-m.ctx.Done():
+		return false
+	}
 }
 
-// listResourcesForCluster lists resources for a specific cluster and type
-func (m *Manager) listResourcesForCluster(client *k8s.Client, resourceType k8s.ResourceType) ([]k8s.Resource, error) {
-	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+// isInstalled reports whether a cluster serves a resource type. Positive
+// answers are cached; missing types are checked again so installing Flux
+// while the UI runs is picked up. Check failures count as installed.
+func (m *Manager) isInstalled(cluster string, c k8s.FluxClient, resourceType k8s.ResourceType) bool {
+	key := cluster + "/" + string(resourceType)
+
+	m.mu.RLock()
+	known := m.installed[key]
+	m.mu.RUnlock()
+	if known {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, 5*time.Second)
 	defer cancel()
 
-	switch resourceType {
-	case k8s.ResourceTypeGitRepository:
-		return client.ListGitRepositories(ctx, "")
-	case k8s.ResourceTypeHelmRepository:
-		return client.ListHelmRepositories(ctx, "")
-	case k8s.ResourceTypeKustomization:
-		return client.ListKustomizations(ctx, "")
-	case k8s.ResourceTypeHelmRelease:
-		return client.ListHelmReleases(ctx, "")
+	installed, err := c.IsInstalled(ctx, resourceType)
+	if err != nil {
+		return true
+	}
+	if installed {
+		m.mu.Lock()
+		m.installed[key] = true
+		m.mu.Unlock()
+	}
+	return installed
+}
+
+// sendError publishes an error update unless the manager is stopping. Errors
+// are dropped rather than waited on when the buffer is full, so a UI that
+// isn't draining them can't stall startup or the refresh loops.
+func (m *Manager) sendError(update ErrorUpdate) {
+	select {
+	case m.errorUpdates <- update:
+	case <-m.ctx.Done():
 	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
 	}
 }
 
+// tagCluster returns a copy of resources with their Cluster set
+func tagCluster(name string, resources []k8s.Resource) []k8s.Resource {
+	tagged := make([]k8s.Resource, len(resources))
+	for i, resource := range resources {
+		resource.Cluster = name
+		tagged[i] = resource
+	}
+	return tagged
+}
+
+// controllerCheckInterval is how often the readiness of a cluster's Flux
+// controllers is checked, far less often than resources are listed
+const controllerCheckInterval = 30 * time.Second
+
+// controllerCheck is the outcome of a cluster's latest controller check
+type controllerCheck struct {
+	checked  time.Time
+	statuses map[string]k8s.ControllerStatus // By controller name
+}
+
+// checkControllers refreshes the readiness of a cluster's Flux controllers
+// when its client can tell and the last check is older than
+// controllerCheckInterval. A failed check, e.g. for lack of RBAC on
+// deployments, reports nothing rather than raising an error.
+func (m *Manager) checkControllers(ctx context.Context, name string, c k8s.FluxClient) {
+	checker, ok := c.(k8s.ControllerChecker)
+	if !ok {
+		return
+	}
+	m.mu.RLock()
+	last := m.controllers[name].checked
+	m.mu.RUnlock()
+	if time.Since(last) < controllerCheckInterval {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	check := controllerCheck{checked: time.Now(), statuses: make(map[string]k8s.ControllerStatus)}
+	if statuses, err := checker.ControllerReadiness(ctx); err == nil {
+		for _, status := range statuses {
+			check.statuses[status.Name] = status
+		}
+	}
+
+	m.mu.Lock()
+	m.controllers[name] = check
+	m.mu.Unlock()
+}
+
+// controllerStatus returns the latest known status of the controller
+// reconciling a resource type on a cluster, nil when unknown
+func (m *Manager) controllerStatus(cluster string, resourceType k8s.ResourceType) *k8s.ControllerStatus {
+	info, exists := k8s.LookupResource(resourceType)
+	if !exists {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, known := m.controllers[cluster].statuses[info.Controller]
+	if !known {
+		return nil
+	}
+	return &status
+}
+
+// publishWarnings forwards API server warnings collected by a cluster client
+func (m *Manager) publishWarnings(name string, c k8s.FluxClient) {
+	for _, message := range c.DrainWarnings() {
+		if !m.sendWarning(WarningUpdate{Cluster: name, Message: message}) {
+			return
+		}
+	}
+}
+
+// sendWarning publishes a warning update, returning false once the manager is
+// stopped
+func (m *Manager) sendWarning(update WarningUpdate) bool {
+	select {
+	case m.warningUpdates <- update:
+		return true
+	case <-m.ctx.Done():
+		return false
+	}
+}
+
+// listResourcesForCluster lists resources for a specific cluster and type
+// in the current namespace ("" lists all namespaces)
+func (m *Manager) listResourcesForCluster(ctx context.Context, client k8s.FluxClient, resourceType k8s.ResourceType) ([]k8s.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// A configured namespace set narrows all namespaces to those it names
+	namespace := m.GetCurrentNamespace()
+	if namespace == "" && len(m.config.Defaults.Namespaces) > 0 {
+		return k8s.ListInNamespaces(ctx, client, resourceType, m.config.Defaults.Namespaces, m.listOptions()...)
+	}
+	return k8s.ListResources(ctx, client, resourceType, namespace, m.listOptions()...)
+}
+
 // startEventRefresh starts the background event refresh process
 func (m *Manager) startEventRefresh() {
 	if !m.config.Defaults.EventsEnabled {
@@ -355,26 +1206,38 @@ func (m *Manager) startEventRefresh() {
 // refreshEvents refreshes events for all clusters
 func (m *Manager) refreshEvents() {
 	m.mu.RLock()
-	clusters := make(map[string]*k8s.Client)
+	clusters := make(map[string]k8s.FluxClient)
 	for name, client := range m.clusters {
 		clusters[name] = client
 	}
 	m.mu.RUnlock()
 
 	for clusterName, client := range clusters {
-		go func(name string, c *k8s.Client) {
-			ctx, cancel := context.WithTimeout(m.ctx, 5*time.Second)
+		m.workers.Add(1)
+		go func(name string, c k8s.FluxClient) {
+			defer m.workers.Done()
+			clusterCtx := m.clusterContext(name)
+			ctx, cancel := context.WithTimeout(clusterCtx, 5*time.Second)
 			defer cancel()
 
 			events, err := c.GetEvents(ctx, "")
+			if clusterCtx.Err() != nil {
+				return
+			}
 			if err != nil {
-				m.errorUpdates <- ErrorUpdate{
+				m.sendError(ErrorUpdate{
 					Cluster: name,
 					Error:   fmt.Errorf("failed to get events: %w", err),
-				}
+				})
 				return
 			}
 
+			if m.recorder != nil {
+				if err := m.recorder.RecordEvents(name, events); err != nil {
+					m.sendError(ErrorUpdate{Cluster: name, Error: err})
+				}
+			}
+
 			select {
 			case m.eventUpdates <- EventUpdate{
 				Cluster: name,
@@ -0,0 +1,489 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/k8s/fake"
+)
+
+// newTestManager creates a started manager whose clusters are backed by fake
+// clients keyed by context name
+func newTestManager(t *testing.T, clients map[string]*fake.Client) *Manager {
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+
+	for name := range clients {
+		if name != "default" {
+			cfg.Clusters = append(cfg.Clusters, config.ClusterConfig{Name: name, Context: name})
+		}
+	}
+
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return clients[context], nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	return manager
+}
+
+func TestManager_ListResources(t *testing.T) {
+	client := fake.NewClient(
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "tenant", Namespace: "team-a"},
+	)
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	resources, err := manager.ListResources(k8s.ResourceTypeKustomization)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "apps", resources[0].Name)
+
+	manager.SetCurrentNamespace("")
+	resources, err = manager.ListResources(k8s.ResourceTypeKustomization)
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+}
+
+func TestManager_LabelSelector(t *testing.T) {
+	client := fake.NewClient(
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "payments", Namespace: "flux-system", Labels: map[string]string{"team": "payments"}},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "search", Namespace: "flux-system", Labels: map[string]string{"team": "search"}},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	)
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	require.NoError(t, manager.SetLabelSelector("team=payments"))
+	assert.Equal(t, "team=payments", manager.GetLabelSelector())
+	resources, err := manager.ListResources(k8s.ResourceTypeKustomization)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "payments", resources[0].Name)
+
+	// A bad selector leaves the current one in place
+	assert.ErrorContains(t, manager.SetLabelSelector("team in payments"), "invalid label selector")
+	assert.Equal(t, "team=payments", manager.GetLabelSelector())
+
+	require.NoError(t, manager.SetLabelSelector(""))
+	assert.Empty(t, manager.GetLabelSelector())
+	resources, err = manager.ListResources(k8s.ResourceTypeKustomization)
+	require.NoError(t, err)
+	assert.Len(t, resources, 3)
+}
+
+func TestManager_ResourceActions(t *testing.T) {
+	client := fake.NewClient()
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	require.NoError(t, manager.SuspendResource(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo"}))
+	require.NoError(t, manager.ResumeResource(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo"}))
+	require.NoError(t, manager.ReconcileResource(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo"}))
+
+	require.Len(t, client.Actions, 3)
+	assert.Equal(t, fake.Action{Verb: "suspend", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "flux-system"}, client.Actions[0])
+	assert.Equal(t, "resume", client.Actions[1].Verb)
+	assert.Equal(t, "reconcile", client.Actions[2].Verb)
+
+	assert.Error(t, manager.SetCurrentCluster("missing"))
+}
+
+func TestManager_ResourceActionsFollowTheRow(t *testing.T) {
+	staging, production := fake.NewClient(), fake.NewClient()
+	manager := newTestManager(t, map[string]*fake.Client{"default": staging, "production": production})
+
+	// Resources listed from another cluster and namespace are acted on there
+	require.NoError(t, manager.SuspendResource(k8s.Resource{Cluster: "production", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps"}))
+	assert.Empty(t, staging.Actions)
+	require.Len(t, production.Actions, 1)
+	assert.Equal(t, fake.Action{Verb: "suspend", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps"}, production.Actions[0])
+
+	_, err := manager.GetResourceYAML(k8s.Resource{Cluster: "missing", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo"})
+	assert.ErrorContains(t, err, "cluster missing not connected")
+}
+
+func TestManager_CompareResource(t *testing.T) {
+	staging := fake.NewClient()
+	prod := fake.NewClient()
+	key := fake.ManifestKey(k8s.ResourceTypeHelmRelease, "podinfo", "flux-system")
+	staging.Manifests[key] = "spec:\n  chart: podinfo\n  version: 6.5.0\n"
+	prod.Manifests[key] = "spec:\n  chart: podinfo\n  version: 6.4.0\n"
+
+	manager := newTestManager(t, map[string]*fake.Client{"default": staging, "prod": prod})
+
+	diff, err := manager.CompareResource(k8s.ResourceTypeHelmRelease, "podinfo", "default", "prod")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-  version: 6.5.0")
+	assert.Contains(t, diff, "+  version: 6.4.0")
+
+	// Missing in one cluster diffs against an empty manifest
+	delete(prod.Manifests, key)
+	diff, err = manager.CompareResource(k8s.ResourceTypeHelmRelease, "podinfo", "default", "prod")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "prod/flux-system/podinfo (not found)")
+
+	// Missing in both is an error
+	_, err = manager.CompareResource(k8s.ResourceTypeHelmRelease, "other", "default", "prod")
+	assert.Error(t, err)
+}
+
+func TestManager_FleetRefresh(t *testing.T) {
+	cfg, err := config.Load("", "", "staging", "flux-system")
+	require.NoError(t, err)
+	cfg.Fleet = true
+	cfg.FleetContexts = []string{"staging", "production"}
+
+	staging := fake.NewClient(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"})
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		if context == "production" {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return staging, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	manager.refreshResources([]k8s.ResourceType{k8s.ResourceTypeKustomization})
+
+	// The background refresh lists every type on start, so skip its other updates
+	updates := make(map[string]ResourceUpdate)
+	for len(updates) < 2 {
+		update := <-manager.GetResourceUpdates()
+		if update.Type == k8s.ResourceTypeKustomization {
+			updates[update.Cluster] = update
+		}
+	}
+
+	// Reachable clusters tag their resources, unreachable ones report an error
+	require.Len(t, updates["staging"].Resources, 1)
+	assert.Equal(t, "staging", updates["staging"].Resources[0].Cluster)
+	assert.NoError(t, updates["staging"].Err)
+	assert.ErrorContains(t, updates["production"].Err, "connection refused")
+}
+
+func TestManager_NotInstalled(t *testing.T) {
+	client := fake.NewClient()
+	client.NotInstalled[k8s.ResourceTypeHelmRelease] = true
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	manager.refreshResources([]k8s.ResourceType{k8s.ResourceTypeKustomization, k8s.ResourceTypeHelmRelease})
+
+	updates := make(map[k8s.ResourceType]ResourceUpdate)
+	for len(updates) < 2 {
+		update := <-manager.GetResourceUpdates()
+		if update.Type == k8s.ResourceTypeKustomization || update.Type == k8s.ResourceTypeHelmRelease {
+			updates[update.Type] = update
+		}
+	}
+
+	// Empty lists are flagged when the type's CRD is missing
+	assert.False(t, updates[k8s.ResourceTypeKustomization].NotInstalled)
+	assert.True(t, updates[k8s.ResourceTypeHelmRelease].NotInstalled)
+}
+
+func TestManager_SetCurrentNamespaceRefreshes(t *testing.T) {
+	client := fake.NewClient(
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "tenant", Namespace: "team-a"},
+	)
+	client.Namespaces = []string{"flux-system", "team-a"}
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	namespaces, err := manager.ListNamespaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"flux-system", "team-a"}, namespaces)
+
+	// The default interval is far off, the switch lists right away
+	manager.SetCurrentNamespace("team-a")
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case update := <-manager.GetResourceUpdates():
+				if update.Type == k8s.ResourceTypeKustomization && len(update.Resources) == 1 && update.Resources[0].Name == "tenant" {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_NamespaceSet(t *testing.T) {
+	client := fake.NewClient(
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "tenant-a", Namespace: "team-a"},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "tenant-b", Namespace: "team-b"},
+	)
+	t.Setenv("HOME", t.TempDir())
+	cfg, err := config.Load("", "", "default", "")
+	require.NoError(t, err)
+	cfg.Defaults.Namespaces = []string{"team-a", "team-b"}
+	cfg.CurrentNamespace = ""
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	// All namespaces lists only the configured ones
+	var names []string
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case update := <-manager.GetResourceUpdates():
+				if update.Type == k8s.ResourceTypeKustomization {
+					names = names[:0]
+					for _, resource := range update.Resources {
+						names = append(names, resource.Name)
+					}
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"tenant-a", "tenant-b"}, names)
+}
+
+func TestManager_SwitchContext(t *testing.T) {
+	clients := map[string]*fake.Client{
+		"default": fake.NewClient(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}),
+		"staging": fake.NewClient(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "staging-apps", Namespace: "flux-system"}),
+	}
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		client, exists := clients[context]
+		if !exists {
+			return nil, fmt.Errorf("context %s not found", context)
+		}
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	old := manager.clusterContext("default")
+	require.NoError(t, manager.SwitchContext("staging"))
+
+	// Calls still running against the old context are cancelled
+	assert.ErrorIs(t, old.Err(), context.Canceled)
+	assert.NoError(t, manager.clusterContext("staging").Err())
+	assert.Equal(t, "staging", manager.GetCurrentCluster())
+	assert.Equal(t, "staging", manager.GetCurrentContext())
+	assert.Equal(t, []string{"staging"}, manager.GetClusters())
+
+	resources, err := manager.ListResources(k8s.ResourceTypeKustomization)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "staging-apps", resources[0].Name)
+
+	// A context that fails to connect leaves the current one in place
+	assert.ErrorContains(t, manager.SwitchContext("missing"), "failed to connect to context missing")
+	assert.Equal(t, "staging", manager.GetCurrentCluster())
+}
+
+// watchingClient is a fake client reporting changes through a channel
+type watchingClient struct {
+	*fake.Client
+	changes chan k8s.ResourceType
+}
+
+func (c *watchingClient) StartWatching(ctx context.Context) (<-chan k8s.ResourceType, error) {
+	return c.changes, nil
+}
+
+func TestManager_WatchRefreshesChangedType(t *testing.T) {
+	client := &watchingClient{
+		Client:  fake.NewClient(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}),
+		changes: make(chan k8s.ResourceType, 1),
+	}
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	kustomizationUpdate := func(count int) func() bool {
+		return func() bool {
+			for {
+				select {
+				case update := <-manager.GetResourceUpdates():
+					if update.Type == k8s.ResourceTypeKustomization && len(update.Resources) == count {
+						return true
+					}
+				default:
+					return false
+				}
+			}
+		}
+	}
+	require.Eventually(t, kustomizationUpdate(1), 2*time.Second, 10*time.Millisecond)
+
+	// The default interval is far off, the change alone triggers the list
+	client.SetResources(k8s.ResourceTypeKustomization,
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	)
+	client.changes <- k8s.ResourceTypeKustomization
+	require.Eventually(t, kustomizationUpdate(2), 2*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_SuspendResources(t *testing.T) {
+	client := fake.NewClient()
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	resources := []k8s.Resource{
+		{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Cluster: "default"},
+		{Type: k8s.ResourceTypeKustomization, Name: "tenant", Namespace: "team-a", Cluster: "default"},
+		{Type: k8s.ResourceTypeKustomization, Name: "remote", Namespace: "flux-system", Cluster: "gone"},
+	}
+	results := manager.SuspendResources(resources)
+	require.Len(t, results, 3)
+
+	// A failure does not stop the remaining resources
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.ErrorContains(t, results[2].Err, "cluster gone not connected")
+	assert.Equal(t, "remote", results[2].Resource.Name)
+
+	assert.ElementsMatch(t, []fake.Action{
+		{Verb: "suspend", Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Verb: "suspend", Type: k8s.ResourceTypeKustomization, Name: "tenant", Namespace: "team-a"},
+	}, client.Actions)
+}
+
+func TestManager_MetricsServer(t *testing.T) {
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	cfg.MetricsAddr = "127.0.0.1:0"
+
+	client := fake.NewClient(
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Ready: true},
+		k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system", Suspended: true},
+	)
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	// Served from the lists the UI receives
+	for update := range manager.GetResourceUpdates() {
+		if update.Type == k8s.ResourceTypeKustomization {
+			break
+		}
+	}
+	require.NoError(t, manager.Healthy())
+	assert.Len(t, manager.Snapshot(), 2)
+
+	body := httpGet(t, "http://"+manager.MetricsAddr()+"/metrics")
+	assert.Contains(t, body, `fluxcli_resources{cluster="default",type="Kustomization",ready="true"} 1`)
+	assert.Contains(t, body, `fluxcli_resources_suspended{cluster="default",type="Kustomization"} 1`)
+	assert.Equal(t, "ok\n", httpGet(t, "http://"+manager.MetricsAddr()+"/healthz"))
+}
+
+// httpGet returns the body of a successful GET
+func httpGet(t *testing.T, url string) string {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestManager_ControllerReadiness(t *testing.T) {
+	client := fake.NewClient()
+	client.Controllers = []k8s.ControllerStatus{{Name: "source-controller", Detail: "0/1 replicas ready"}}
+	manager := newTestManager(t, map[string]*fake.Client{"default": client})
+
+	manager.refreshResources([]k8s.ResourceType{k8s.ResourceTypeKustomization, k8s.ResourceTypeGitRepository})
+
+	updates := make(map[k8s.ResourceType]ResourceUpdate)
+	for len(updates) < 2 {
+		update := <-manager.GetResourceUpdates()
+		if update.Type == k8s.ResourceTypeKustomization || update.Type == k8s.ResourceTypeGitRepository {
+			updates[update.Type] = update
+		}
+	}
+
+	// Types report the controller reconciling them, when it's known
+	require.NotNil(t, updates[k8s.ResourceTypeGitRepository].Controller)
+	assert.False(t, updates[k8s.ResourceTypeGitRepository].Controller.Ready)
+	assert.Nil(t, updates[k8s.ResourceTypeKustomization].Controller)
+}
+
+func TestManager_StartErrorsDontBlock(t *testing.T) {
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+	for i := range 150 {
+		cfg.Clusters = append(cfg.Clusters, config.ClusterConfig{Name: fmt.Sprintf("cluster-%d", i), Context: fmt.Sprintf("cluster-%d", i)})
+	}
+
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		if context == "default" {
+			return fake.NewClient(), nil
+		}
+		return nil, fmt.Errorf("connection refused")
+	})
+	t.Cleanup(manager.Stop)
+
+	// More connection errors than the channel buffers must not stall Start
+	// while nothing drains them
+	started := make(chan error, 1)
+	go func() { started <- manager.Start() }()
+	select {
+	case err := <-started:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start blocked on the error channel")
+	}
+	assert.Len(t, manager.GetErrorUpdates(), 100)
+}
+
+func TestManager_StopDuringStart(t *testing.T) {
+	cfg, err := config.Load("", "", "default", "flux-system")
+	require.NoError(t, err)
+
+	connecting, release := make(chan struct{}), make(chan struct{})
+	manager := NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		close(connecting)
+		<-release
+		return fake.NewClient(), nil
+	})
+
+	started := make(chan error, 1)
+	go func() { started <- manager.Start() }()
+	<-connecting
+
+	// Quitting while Start connects waits for it instead of closing the
+	// channels under it
+	stopped := make(chan struct{})
+	go func() {
+		manager.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while Start was still connecting")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.ErrorIs(t, <-started, context.Canceled, "no background loops start once stopped")
+	<-stopped
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/metrics"
+)
+
+// listenMetrics opens the metrics server's listener when an address is
+// configured, so a port in use fails Start instead of the background loop
+func (m *Manager) listenMetrics() error {
+	if m.config.MetricsAddr == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", m.config.MetricsAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", m.config.MetricsAddr, err)
+	}
+	m.metricsListener = listener
+	return nil
+}
+
+// MetricsAddr returns the address the metrics server listens on, "" when it
+// is not running
+func (m *Manager) MetricsAddr() string {
+	if m.metricsListener == nil {
+		return ""
+	}
+	return m.metricsListener.Addr().String()
+}
+
+// serveMetrics serves the metrics of the latest refresh until the manager
+// stops
+func (m *Manager) serveMetrics() {
+	if m.metricsListener == nil {
+		return
+	}
+
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		if err := metrics.Serve(m.ctx, m.metricsListener, m); err != nil {
+			m.sendError(ErrorUpdate{Error: err})
+		}
+	}()
+}
+
+// snapshotKey identifies the list of a type on a cluster
+type snapshotKey struct {
+	cluster      string
+	resourceType k8s.ResourceType
+}
+
+// recordSnapshot keeps the latest list of a type on a cluster for metrics
+func (m *Manager) recordSnapshot(cluster string, resourceType k8s.ResourceType, resources []k8s.Resource) {
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+	m.snapshot[snapshotKey{cluster, resourceType}] = resources
+}
+
+// Snapshot returns the resources of the latest refresh of every connected
+// cluster, by cluster and type
+func (m *Manager) Snapshot() []k8s.Resource {
+	m.mu.RLock()
+	connected := make(map[string]bool, len(m.clusters))
+	for name := range m.clusters {
+		connected[name] = true
+	}
+	m.mu.RUnlock()
+
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+	keys := make([]snapshotKey, 0, len(m.snapshot))
+	for key := range m.snapshot {
+		// Contexts switched away from keep their last list, leave them out
+		if connected[key.cluster] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].cluster != keys[j].cluster {
+			return keys[i].cluster < keys[j].cluster
+		}
+		return keys[i].resourceType < keys[j].resourceType
+	})
+
+	var resources []k8s.Resource
+	for _, key := range keys {
+		resources = append(resources, m.snapshot[key]...)
+	}
+	return resources
+}
+
+// Healthy reports whether a cluster is connected and has been listed
+func (m *Manager) Healthy() error {
+	m.mu.RLock()
+	clusters := len(m.clusters)
+	m.mu.RUnlock()
+	if clusters == 0 {
+		return errors.New("no cluster connected")
+	}
+
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+	if len(m.snapshot) == 0 {
+		return errors.New("resources not listed yet")
+	}
+	return nil
+}
@@ -3,6 +3,11 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
@@ -26,6 +31,31 @@ type Client struct {
 	Context   string
 	Cluster   string
 	Namespace string
+	Warnings  *WarningCollector
+	// ReconcileDedupWindow suppresses reconcile requests while a younger
+	// unhandled one is pending; zero disables the check
+	ReconcileDedupWindow time.Duration
+	// OverdueMargin is how long the controller may leave a reconcile request
+	// or spec change unhandled before listers flag the resource overdue
+	OverdueMargin time.Duration
+	// EventsLookback is how far back GetEvents reaches; zero or negative
+	// returns events of any age
+	EventsLookback time.Duration
+	// APIRetries is how often Get, List, Update and Patch calls failing with
+	// a transient API error are retried; zero disables retries
+	APIRetries int
+	// RequestTimeout bounds each API request attempt; zero leaves requests
+	// to the caller's context
+	RequestTimeout time.Duration
+	// ListPageSize is how many objects a list reads from the API server per
+	// request; zero lists everything in one request
+	ListPageSize int64
+	// ListLimit caps the objects a list reads across pages; zero reads all
+	ListLimit int
+
+	watch      atomic.Pointer[watchState] // Set once StartWatching runs
+	truncated  sync.Map                   // ResourceType to whether its latest list hit ListLimit
+	unobserved sync.Map                   // Object key to the unobservedGeneration it was first listed with
 }
 
 // NewClient creates a new Kubernetes client
@@ -34,6 +64,31 @@ func NewClient(kubeconfig, context, namespace string) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
+	return newClient(config, context, namespace)
+}
+
+// NewInClusterClient creates a client that connects with the service account
+// of the pod fluxcli runs in, named InClusterContext
+func NewInClusterClient(namespace string) (*Client, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+	}
+	return newClient(config, InClusterContext, namespace)
+}
+
+// newClient creates a client for the cluster config points at
+func newClient(config *rest.Config, context, namespace string) (*Client, error) {
+	// Collect API server warnings (e.g. deprecated API versions) instead of
+	// letting client-go print them to stderr over the TUI
+	warnings := NewWarningCollector()
+	config.WarningHandler = warnings
+
+	// Share one rate limiter between both clients and watch it for throttling;
+	// a negative QPS disables rate limiting altogether
+	if config.RateLimiter == nil && config.QPS >= 0 {
+		config.RateLimiter = newThrottleMonitor(config, warnings)
+	}
 
 	// Create controller-runtime client for CRDs
 	scheme := runtime.NewScheme()
@@ -64,13 +119,31 @@ func NewClient(kubeconfig, context, namespace string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
-	return &Client{
-		Client:    ctrlClient,
+	c := &Client{
 		Interface: k8sClient,
 		Config:    config,
 		Context:   context,
 		Namespace: namespace,
-	}, nil
+		Warnings:  warnings,
+		ReconcileDedupWindow: DefaultReconcileDedupWindow,
+		OverdueMargin:        DefaultOverdueMargin,
+		EventsLookback:       DefaultEventsLookback,
+		APIRetries:           DefaultAPIRetries,
+		RequestTimeout:       DefaultRequestTimeout,
+		ListPageSize:         DefaultListPageSize,
+	}
+	c.Client = retryClient{Client: ctrlClient, retries: &c.APIRetries, timeout: &c.RequestTimeout}
+	return c, nil
+}
+
+// InClusterContext is the name an in-cluster connection goes by. It only
+// labels the connection, a kubeconfig context of the same name is unaffected.
+const InClusterContext = "in-cluster"
+
+// InCluster reports whether fluxcli runs in a pod it can connect from
+func InCluster() bool {
+	_, err := rest.InClusterConfig()
+	return err == nil
 }
 
 // buildConfig builds a Kubernetes client configuration
@@ -93,6 +166,59 @@ func buildConfig(kubeconfig, context string) (*rest.Config, error) {
 	return clientConfig.ClientConfig()
 }
 
+// ListContexts returns the context names defined in a kubeconfig, sorted
+func ListContexts(kubeconfig string) ([]string, error) {
+	configLoader := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		configLoader.ExplicitPath = kubeconfig
+	}
+
+	rawConfig, err := configLoader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	contexts := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+// CheckContexts returns an error naming the available contexts when a
+// kubeconfig doesn't define one of contexts
+func CheckContexts(kubeconfig string, contexts ...string) error {
+	available, err := ListContexts(kubeconfig)
+	if err != nil {
+		return err
+	}
+	for _, context := range contexts {
+		if slices.Contains(available, context) {
+			continue
+		}
+		if len(available) == 0 {
+			return fmt.Errorf("context %q not found, the kubeconfig defines no contexts", context)
+		}
+		return fmt.Errorf("context %q not found in kubeconfig, available contexts: %s", context, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+// CurrentContext returns the current context of a kubeconfig
+func CurrentContext(kubeconfig string) (string, error) {
+	configLoader := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		configLoader.ExplicitPath = kubeconfig
+	}
+
+	rawConfig, err := configLoader.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return rawConfig.CurrentContext, nil
+}
+
 // TestConnection tests the connection to the Kubernetes cluster
 func (c *Client) TestConnection(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -102,6 +228,29 @@ func (c *Client) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// ListNamespaces returns the names of the cluster's namespaces, sorted
+func (c *Client) ListNamespaces(ctx context.Context) ([]string, error) {
+	list, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// DrainWarnings returns API server warnings received since the last call
+func (c *Client) DrainWarnings() []string {
+	if c.Warnings == nil {
+		return nil
+	}
+	return c.Warnings.Drain()
+}
+
 // GetCurrentContext returns the current Kubernetes context
 func (c *Client) GetCurrentContext() string {
 	return c.Context
@@ -0,0 +1,56 @@
+package k8s
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestBuildConfig_InCluster(t *testing.T) {
+	// Outside a pod the service account environment is missing
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+	assert.False(t, InCluster())
+
+	_, err := NewInClusterClient("flux-system")
+	assert.ErrorIs(t, err, rest.ErrNotInCluster)
+	assert.Contains(t, err.Error(), "failed to load in-cluster config")
+
+	// A kubeconfig context named like the in-cluster connection is its own
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	raw := clientcmdapi.NewConfig()
+	raw.Clusters["lab"] = &clientcmdapi.Cluster{Server: "https://lab.example.com:6443"}
+	raw.AuthInfos["lab"] = clientcmdapi.NewAuthInfo()
+	raw.Contexts[InClusterContext] = &clientcmdapi.Context{Cluster: "lab", AuthInfo: "lab"}
+	require.NoError(t, clientcmd.WriteToFile(*raw, kubeconfig))
+	config, err := buildConfig(kubeconfig, InClusterContext)
+	require.NoError(t, err)
+	assert.Equal(t, "https://lab.example.com:6443", config.Host)
+}
+
+func TestCheckContexts(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	raw := clientcmdapi.NewConfig()
+	raw.Contexts["staging"] = clientcmdapi.NewContext()
+	raw.Contexts["prod"] = clientcmdapi.NewContext()
+	require.NoError(t, clientcmd.WriteToFile(*raw, kubeconfig))
+
+	assert.NoError(t, CheckContexts(kubeconfig, "prod"))
+	assert.NoError(t, CheckContexts(kubeconfig, "staging", "prod"))
+
+	err := CheckContexts(kubeconfig, "prod", "dev")
+	require.Error(t, err)
+	assert.Equal(t, `context "dev" not found in kubeconfig, available contexts: prod, staging`, err.Error())
+
+	require.NoError(t, clientcmd.WriteToFile(*clientcmdapi.NewConfig(), kubeconfig))
+	assert.EqualError(t, CheckContexts(kubeconfig, "prod"), `context "prod" not found, the kubeconfig defines no contexts`)
+
+	// An unreadable kubeconfig fails as such
+	err = CheckContexts(filepath.Join(t.TempDir(), "missing"), "prod")
+	assert.ErrorContains(t, err, "failed to load kubeconfig")
+}
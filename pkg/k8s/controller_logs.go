@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controllerNamespace is where flux install deploys the controllers
+const controllerNamespace = "flux-system"
+
+// controllerLogLimit caps the log lines fetched from a controller, which can
+// be chatty at debug level
+const controllerLogLimit int64 = 5000
+
+// GetControllerLogs returns the logs of the controller that reconciles a
+// resource type, going back sinceSeconds. Zero or negative fetches the latest
+// controllerLogLimit lines regardless of age.
+func (c *Client) GetControllerLogs(ctx context.Context, resourceType ResourceType, sinceSeconds int64) (string, error) {
+	info, exists := LookupResource(resourceType)
+	if !exists || info.Controller == "" {
+		return "", fmt.Errorf("no controller known for %s", resourceType)
+	}
+
+	pod, err := c.controllerPod(ctx, info.Controller)
+	if err != nil {
+		return "", err
+	}
+
+	tail := controllerLogLimit
+	opts := &corev1.PodLogOptions{TailLines: &tail}
+	if sinceSeconds > 0 {
+		opts.SinceSeconds = &sinceSeconds
+	}
+	for _, container := range pod.Spec.Containers {
+		// flux install names the controller container manager
+		if container.Name == "manager" {
+			opts.Container = container.Name
+		}
+	}
+
+	stream, err := c.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to stream logs of %s: %w", pod.Name, err)
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs of %s: %w", pod.Name, err)
+	}
+	return string(logs), nil
+}
+
+// controllerPod finds a pod of a Flux controller, preferring running ones
+func (c *Client) controllerPod(ctx context.Context, controller string) (*corev1.Pod, error) {
+	pods, err := c.CoreV1().Pods(controllerNamespace).List(ctx, metav1.ListOptions{LabelSelector: "app=" + controller})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s pods: %w", controller, err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no %s pod found in %s", controller, controllerNamespace)
+	}
+
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return &pods.Items[0], nil
+}
+
+// GrepLogs keeps the log lines that mention a resource name. Controllers log
+// the name in the structured name field, so a substring match finds them.
+func GrepLogs(logs, name string) string {
+	if name == "" {
+		return logs
+	}
+
+	var matched []string
+	for _, line := range strings.Split(logs, "\n") {
+		if strings.Contains(line, name) {
+			matched = append(matched, line)
+		}
+	}
+	return strings.Join(matched, "\n")
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_GetControllerLogs(t *testing.T) {
+	pod := func(name, app string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	c := &Client{Interface: k8sfake.NewSimpleClientset(
+		pod("kustomize-controller-old", "kustomize-controller", corev1.PodFailed),
+		pod("kustomize-controller-new", "kustomize-controller", corev1.PodRunning),
+		pod("helm-controller-abc", "helm-controller", corev1.PodRunning),
+	)}
+
+	selected, err := c.controllerPod(t.Context(), "kustomize-controller")
+	require.NoError(t, err)
+	assert.Equal(t, "kustomize-controller-new", selected.Name, "running pods are preferred")
+
+	logs, err := c.GetControllerLogs(t.Context(), ResourceTypeKustomization, 600)
+	require.NoError(t, err)
+	assert.NotEmpty(t, logs)
+
+	_, err = c.GetControllerLogs(t.Context(), ResourceTypeGitRepository, 600)
+	assert.ErrorContains(t, err, "no source-controller pod found in flux-system")
+}
+
+func TestGrepLogs(t *testing.T) {
+	logs := `{"level":"info","msg":"server-side apply completed","name":"apps","namespace":"flux-system"}
+{"level":"error","msg":"Reconciler error","name":"infra","namespace":"flux-system"}
+{"level":"info","msg":"dependencies do not meet ready condition","name":"apps","namespace":"flux-system"}`
+
+	assert.Equal(t, `{"level":"error","msg":"Reconciler error","name":"infra","namespace":"flux-system"}`, GrepLogs(logs, "infra"))
+	assert.Len(t, strings.Split(GrepLogs(logs, "apps"), "\n"), 2)
+	assert.Empty(t, GrepLogs(logs, "podinfo"))
+	assert.Equal(t, logs, GrepLogs(logs, ""))
+}
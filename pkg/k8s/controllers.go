@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerStatus is the readiness of a Flux controller's deployment
+type ControllerStatus struct {
+	Name   string // e.g. source-controller
+	Ready  bool   // At least one replica is ready
+	Detail string // Why it isn't ready, e.g. "0/1 replicas ready"
+}
+
+// ControllerChecker is implemented by clients that can tell whether the Flux
+// controllers are running. Without them resources sit not ready with no
+// condition explaining why.
+type ControllerChecker interface {
+	// ControllerReadiness returns the status of every Flux controller
+	// deployed to flux-system; controllers not deployed there are left out
+	ControllerReadiness(ctx context.Context) ([]ControllerStatus, error)
+}
+
+var _ ControllerChecker = (*Client)(nil)
+
+// Controllers returns the Flux controllers reconciling the registered types,
+// in the order their types are listed
+func Controllers() []string {
+	var controllers []string
+	seen := make(map[string]bool)
+	for _, resourceType := range resourceOrder {
+		controller := registry[resourceType].Controller
+		if controller != "" && !seen[controller] {
+			seen[controller] = true
+			controllers = append(controllers, controller)
+		}
+	}
+	return controllers
+}
+
+// ControllerReadiness implements ControllerChecker. flux install names each
+// deployment after its controller.
+func (c *Client) ControllerReadiness(ctx context.Context) ([]ControllerStatus, error) {
+	var deployments *appsv1.DeploymentList
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		deployments, err = c.AppsV1().Deployments(controllerNamespace).List(ctx, metav1.ListOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list controller deployments: %w", err)
+	}
+
+	byName := make(map[string]*appsv1.Deployment, len(deployments.Items))
+	for i := range deployments.Items {
+		byName[deployments.Items[i].Name] = &deployments.Items[i]
+	}
+
+	var statuses []ControllerStatus
+	for _, controller := range Controllers() {
+		if deployment, exists := byName[controller]; exists {
+			statuses = append(statuses, controllerStatus(deployment))
+		}
+	}
+	return statuses, nil
+}
+
+// controllerStatus derives a controller's readiness from its deployment
+func controllerStatus(deployment *appsv1.Deployment) ControllerStatus {
+	status := ControllerStatus{Name: deployment.Name}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case desired == 0:
+		status.Detail = "scaled to zero"
+	case deployment.Status.ReadyReplicas == 0:
+		status.Detail = fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired)
+	default:
+		status.Ready = true
+	}
+	return status
+}
@@ -0,0 +1,37 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_ControllerReadiness(t *testing.T) {
+	deployment := func(name string, replicas, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	c := &Client{Interface: k8sfake.NewSimpleClientset(
+		deployment("source-controller", 1, 0),
+		deployment("kustomize-controller", 1, 1),
+		deployment("helm-controller", 0, 0),
+		deployment("podinfo", 1, 0),
+	)}
+
+	statuses, err := c.ControllerReadiness(t.Context())
+	require.NoError(t, err)
+
+	// Controllers that aren't deployed and unrelated deployments are left out
+	assert.Equal(t, []ControllerStatus{
+		{Name: "source-controller", Detail: "0/1 replicas ready"},
+		{Name: "kustomize-controller", Ready: true},
+		{Name: "helm-controller", Detail: "scaled to zero"},
+	}, statuses)
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import "k8s.io/apimachinery/pkg/types"
+
+// MarkDrift sets Drift on the Kustomizations among resources whose last
+// applied revision differs from the artifact revision of their source in
+// sources. Kustomizations that haven't applied anything yet, or whose source
+// is not in sources or has no artifact, are not marked.
+func MarkDrift(resources, sources []Resource) {
+	type sourceKey struct {
+		resourceType ResourceType
+		key          types.NamespacedName
+	}
+	revisions := make(map[sourceKey]string)
+	for _, source := range sources {
+		if source.Revision != "" {
+			revisions[sourceKey{source.Type, types.NamespacedName{Namespace: source.Namespace, Name: source.Name}}] = source.Revision
+		}
+	}
+
+	for i := range resources {
+		resource := &resources[i]
+		if resource.Type != ResourceTypeKustomization {
+			continue
+		}
+		resource.Drift = false
+		sourceType, key, err := SourceRef(*resource)
+		if err != nil || resource.Revision == "" {
+			continue
+		}
+		if revision, ok := revisions[sourceKey{sourceType, key}]; ok && revision != resource.Revision {
+			resource.Drift = true
+		}
+	}
+}
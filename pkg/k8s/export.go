@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ExportFormat is how an export is serialized
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatYAML ExportFormat = "yaml"
+)
+
+// Export is a snapshot of listed resources, for runbooks and bug reports
+type Export struct {
+	Context   string     `json:"context"`
+	Timestamp time.Time  `json:"timestamp"`
+	Resources []Resource `json:"resources"`
+}
+
+// NewExport creates an export of resources taken now
+func NewExport(context string, resources []Resource) Export {
+	if resources == nil {
+		resources = []Resource{}
+	}
+	return Export{Context: context, Timestamp: time.Now().UTC(), Resources: resources}
+}
+
+// ParseExportFormat parses a format name. An empty name picks YAML for paths
+// ending in .yaml or .yml and JSON otherwise.
+func ParseExportFormat(name, path string) (ExportFormat, error) {
+	switch strings.ToLower(name) {
+	case "":
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			return ExportFormatYAML, nil
+		}
+		return ExportFormatJSON, nil
+	case string(ExportFormatJSON):
+		return ExportFormatJSON, nil
+	case string(ExportFormatYAML), "yml":
+		return ExportFormatYAML, nil
+	}
+	return "", fmt.Errorf("unknown export format %q, use json or yaml", name)
+}
+
+// WriteExport writes an export to w
+func WriteExport(w io.Writer, export Export, format ExportFormat) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export: %w", err)
+	}
+	if format == ExportFormatYAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("failed to marshal export: %w", err)
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// WriteExportFile writes an export to path, - for stdout
+func WriteExportFile(path string, export Export, format ExportFormat) error {
+	if path == "-" {
+		return WriteExport(os.Stdout, export, format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := WriteExport(file, export, format); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteTable writes resources to w as aligned columns like flux get
+func WriteTable(w io.Writer, resources []Resource) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tKIND\tNAME\tREVISION\tSUSPENDED\tREADY\tMESSAGE")
+	for _, resource := range resources {
+		ready := "False"
+		if resource.Ready {
+			ready = "True"
+		}
+		message := strings.Join(strings.Fields(resource.DisplayMessage()), " ")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+			resource.Namespace, resource.Type, resource.Name, resource.Revision, resource.Suspended, ready, message)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	for _, tt := range []struct {
+		name, path string
+		want       ExportFormat
+	}{
+		{"", "flux.json", ExportFormatJSON},
+		{"", "flux.yaml", ExportFormatYAML},
+		{"", "FLUX.YML", ExportFormatYAML},
+		{"", "-", ExportFormatJSON},
+		{"yaml", "-", ExportFormatYAML},
+		{"JSON", "flux.yaml", ExportFormatJSON},
+	} {
+		format, err := ParseExportFormat(tt.name, tt.path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, format, "%q %q", tt.name, tt.path)
+	}
+
+	_, err := ParseExportFormat("toml", "")
+	assert.Error(t, err)
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, []Resource{
+		{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Ready: true, Revision: "main@sha1:abc", Message: "Applied revision: main@sha1:abc"},
+		{Type: ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system", Suspended: true, Message: "failed to checkout\nauthentication required"},
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^NAMESPACE\s+KIND\s+NAME\s+REVISION\s+SUSPENDED\s+READY\s+MESSAGE$`, lines[0])
+	assert.Regexp(t, `^flux-system\s+Kustomization\s+apps\s+main@sha1:abc\s+false\s+True\s+Applied revision: main@sha1:abc$`, lines[1])
+	// Multi-line messages stay on their row
+	assert.Regexp(t, `^flux-system\s+GitRepository\s+fleet\s+true\s+False\s+failed to checkout authentication required$`, lines[2])
+}
+
+func TestWriteExport(t *testing.T) {
+	export := NewExport("prod", []Resource{
+		{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Ready: true, Status: "Ready"},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExport(&buf, export, ExportFormatJSON))
+	var decoded Export
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "prod", decoded.Context)
+	assert.True(t, decoded.Timestamp.Equal(export.Timestamp))
+	require.Len(t, decoded.Resources, 1)
+	assert.Equal(t, "apps", decoded.Resources[0].Name)
+
+	path := filepath.Join(t.TempDir(), "flux.yaml")
+	require.NoError(t, WriteExportFile(path, export, ExportFormatYAML))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "context: prod\n")
+	decoded = Export{}
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	require.Len(t, decoded.Resources, 1)
+	assert.Equal(t, ResourceTypeKustomization, decoded.Resources[0].Type)
+
+	// An empty export still lists resources, so consumers can iterate it
+	buf.Reset()
+	require.NoError(t, WriteExport(&buf, NewExport("prod", nil), ExportFormatJSON))
+	assert.Contains(t, buf.String(), `"resources": []`)
+}
@@ -0,0 +1,418 @@
+// Package fake provides an in-memory k8s.FluxClient for tests
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Action records a mutating call made against the fake client
+type Action struct {
+	Verb      string
+	Type      k8s.ResourceType
+	Name      string
+	Namespace string
+}
+
+// Client is an in-memory k8s.FluxClient. Resources and manifests are served
+// from its fields and mutating calls are recorded in Actions.
+type Client struct {
+	mu sync.Mutex
+
+	Resources map[k8s.ResourceType][]k8s.Resource
+	Events    []corev1.Event
+	// Manifests maps "<type>/<namespace>/<name>" to a YAML manifest
+	Manifests map[string]string
+	Warnings  []string
+	// Health maps "<namespace>/<name>" of a Kustomization to its inventory health
+	Health map[string]*k8s.InventoryHealth
+	// NotInstalled marks resource types whose CRD is missing
+	NotInstalled map[k8s.ResourceType]bool
+	// Inventories maps "<namespace>/<name>" of a Kustomization to its inventory
+	Inventories map[string][]k8s.ObjectRef
+	Namespaces  []string
+	// Trees maps "<type>/<namespace>/<name>" to a dependency tree
+	Trees map[string]*k8s.TreeNode
+	// Logs maps a controller name, e.g. kustomize-controller, to its logs
+	Logs map[string]string
+	// Diffs maps "<namespace>/<name>" of a Kustomization to its diff against
+	// the cluster
+	Diffs map[string]string
+	// Controllers are the controller statuses reported by ControllerReadiness
+	Controllers []k8s.ControllerStatus
+
+	// Err, when set, is returned by every call
+	Err error
+
+	Actions []Action
+}
+
+// NewClient creates a fake client serving the given resources
+func NewClient(resources ...k8s.Resource) *Client {
+	c := &Client{
+		Resources: make(map[k8s.ResourceType][]k8s.Resource),
+		Manifests: make(map[string]string),
+		Health:    make(map[string]*k8s.InventoryHealth),
+		Inventories: make(map[string][]k8s.ObjectRef),
+		NotInstalled: make(map[k8s.ResourceType]bool),
+		Logs:         make(map[string]string),
+		Trees:        make(map[string]*k8s.TreeNode),
+		Diffs:        make(map[string]string),
+	}
+	for _, resource := range resources {
+		c.Resources[resource.Type] = append(c.Resources[resource.Type], resource)
+	}
+	return c
+}
+
+// ManifestKey returns the Manifests key for a resource
+func ManifestKey(resourceType k8s.ResourceType, name, namespace string) string {
+	return fmt.Sprintf("%s/%s/%s", resourceType, namespace, name)
+}
+
+var _ k8s.FluxClient = (*Client)(nil)
+
+// TestConnection implements k8s.FluxClient
+func (c *Client) TestConnection(ctx context.Context) error {
+	return c.Err
+}
+
+// ListGitRepositories implements k8s.FluxClient
+func (c *Client) ListGitRepositories(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeGitRepository, namespace, opts...)
+}
+
+// ListHelmRepositories implements k8s.FluxClient
+func (c *Client) ListHelmRepositories(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeHelmRepository, namespace, opts...)
+}
+
+// ListKustomizations implements k8s.FluxClient
+func (c *Client) ListKustomizations(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeKustomization, namespace, opts...)
+}
+
+// ListHelmReleases implements k8s.FluxClient
+func (c *Client) ListHelmReleases(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeHelmRelease, namespace, opts...)
+}
+
+// ListOCIRepositories implements k8s.FluxClient
+func (c *Client) ListOCIRepositories(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeOCIRepository, namespace, opts...)
+}
+
+// ListBuckets implements k8s.FluxClient
+func (c *Client) ListBuckets(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeBucket, namespace, opts...)
+}
+
+// ListImageRepositories implements k8s.FluxClient
+func (c *Client) ListImageRepositories(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeImageRepository, namespace, opts...)
+}
+
+// ListImagePolicies implements k8s.FluxClient
+func (c *Client) ListImagePolicies(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeImagePolicy, namespace, opts...)
+}
+
+// ListImageUpdateAutomations implements k8s.FluxClient
+func (c *Client) ListImageUpdateAutomations(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeImageUpdateAutomation, namespace, opts...)
+}
+
+// ListAlerts implements k8s.FluxClient
+func (c *Client) ListAlerts(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeAlert, namespace, opts...)
+}
+
+// ListProviders implements k8s.FluxClient
+func (c *Client) ListProviders(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeProvider, namespace, opts...)
+}
+
+// ListReceivers implements k8s.FluxClient
+func (c *Client) ListReceivers(ctx context.Context, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	return c.list(k8s.ResourceTypeReceiver, namespace, opts...)
+}
+
+// CountResources implements k8s.FluxClient
+func (c *Client) CountResources(ctx context.Context, resourceType k8s.ResourceType, namespace string) (int64, error) {
+	resources, err := c.list(resourceType, namespace)
+	return int64(len(resources)), err
+}
+
+// IsInstalled implements k8s.FluxClient
+func (c *Client) IsInstalled(ctx context.Context, resourceType k8s.ResourceType) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return false, c.Err
+	}
+	return !c.NotInstalled[resourceType], nil
+}
+
+// GetEvents implements k8s.FluxClient
+func (c *Client) GetEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.Events, nil
+}
+
+// GetResourceEvents implements k8s.FluxClient
+func (c *Client) GetResourceEvents(ctx context.Context, resource k8s.Resource) ([]corev1.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return k8s.FilterResourceEvents(c.Events, resource), nil
+}
+
+// GetControllerLogs implements k8s.FluxClient
+func (c *Client) GetControllerLogs(ctx context.Context, resourceType k8s.ResourceType, sinceSeconds int64) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return "", c.Err
+	}
+	info, _ := k8s.LookupResource(resourceType)
+	logs, exists := c.Logs[info.Controller]
+	if !exists {
+		return "", fmt.Errorf("no %s pod found", info.Controller)
+	}
+	return logs, nil
+}
+
+var _ k8s.ControllerChecker = (*Client)(nil)
+
+// ControllerReadiness implements k8s.ControllerChecker
+func (c *Client) ControllerReadiness(ctx context.Context) ([]k8s.ControllerStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return append([]k8s.ControllerStatus(nil), c.Controllers...), nil
+}
+
+// ListNamespaces implements k8s.FluxClient
+func (c *Client) ListNamespaces(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.Namespaces, nil
+}
+
+// SuspendResource implements k8s.FluxClient
+func (c *Client) SuspendResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) error {
+	return c.record("suspend", resourceType, name, namespace)
+}
+
+// ResumeResource implements k8s.FluxClient
+func (c *Client) ResumeResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) error {
+	return c.record("resume", resourceType, name, namespace)
+}
+
+// ReconcileResource implements k8s.FluxClient
+func (c *Client) ReconcileResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) error {
+	return c.record("reconcile", resourceType, name, namespace)
+}
+
+// ReconcileWithSource implements k8s.FluxClient
+func (c *Client) ReconcileWithSource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) (string, error) {
+	return "", c.record("reconcile-source", resourceType, name, namespace)
+}
+
+// ResetHelmRelease implements k8s.FluxClient
+func (c *Client) ResetHelmRelease(ctx context.Context, name, namespace string) error {
+	return c.record("reset", k8s.ResourceTypeHelmRelease, name, namespace)
+}
+
+// SnoozeResource implements k8s.FluxClient, recording "unsnooze" for a zero time
+func (c *Client) SnoozeResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string, until time.Time) error {
+	if until.IsZero() {
+		return c.record("unsnooze", resourceType, name, namespace)
+	}
+	return c.record("snooze", resourceType, name, namespace)
+}
+
+// DeleteResource implements k8s.FluxClient
+func (c *Client) DeleteResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) error {
+	return c.record("delete", resourceType, name, namespace)
+}
+
+// GetResource implements k8s.FluxClient
+func (c *Client) GetResource(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) (*k8s.Resource, error) {
+	resources, err := c.list(resourceType, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		if resource.Name == name {
+			return &resource, nil
+		}
+	}
+	gr := schema.GroupResource{Resource: string(resourceType)}
+	return nil, fmt.Errorf("%s %s/%s not found: %w", resourceType, namespace, name, apierrors.NewNotFound(gr, name))
+}
+
+// ResolveSource implements k8s.FluxClient
+func (c *Client) ResolveSource(ctx context.Context, resource k8s.Resource) (*k8s.Resource, error) {
+	sourceType, source, err := k8s.SourceRef(resource)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetResource(ctx, sourceType, source.Name, source.Namespace)
+}
+
+// DiffKustomization implements k8s.FluxClient
+func (c *Client) DiffKustomization(ctx context.Context, name, namespace string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return "", c.Err
+	}
+	diff, exists := c.Diffs[namespace+"/"+name]
+	if !exists {
+		return "", fmt.Errorf("kustomization %s/%s: %w", namespace, name, k8s.ErrNoArtifact)
+	}
+	return diff, nil
+}
+
+// BuildDependencyTree implements k8s.FluxClient
+func (c *Client) BuildDependencyTree(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) (*k8s.TreeNode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	tree, exists := c.Trees[ManifestKey(resourceType, name, namespace)]
+	if !exists {
+		gr := schema.GroupResource{Resource: string(resourceType)}
+		return nil, fmt.Errorf("%s %s/%s not found: %w", resourceType, namespace, name, apierrors.NewNotFound(gr, name))
+	}
+	return tree, nil
+}
+
+// GetResourceYAML implements k8s.FluxClient
+func (c *Client) GetResourceYAML(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return "", c.Err
+	}
+	manifest, exists := c.Manifests[ManifestKey(resourceType, name, namespace)]
+	if !exists {
+		gr := schema.GroupResource{Resource: string(resourceType)}
+		return "", fmt.Errorf("failed to get %s/%s: %w", resourceType, name, apierrors.NewNotFound(gr, name))
+	}
+	return manifest, nil
+}
+
+// GetComparableYAML implements k8s.FluxClient
+func (c *Client) GetComparableYAML(ctx context.Context, resourceType k8s.ResourceType, name, namespace string) (string, error) {
+	return c.GetResourceYAML(ctx, resourceType, name, namespace)
+}
+
+// GetInventory implements k8s.FluxClient
+func (c *Client) GetInventory(ctx context.Context, name, namespace string) ([]k8s.ObjectRef, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.Inventories[namespace+"/"+name], nil
+}
+
+// GetInventoryHealth implements k8s.FluxClient
+func (c *Client) GetInventoryHealth(ctx context.Context, name, namespace string) (*k8s.InventoryHealth, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	health, exists := c.Health[namespace+"/"+name]
+	if !exists {
+		return &k8s.InventoryHealth{}, nil
+	}
+	return health, nil
+}
+
+// DrainWarnings implements k8s.FluxClient
+func (c *Client) DrainWarnings() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	warnings := c.Warnings
+	c.Warnings = nil
+	return warnings
+}
+
+// SetResources replaces the resources of a type, which is safe while a
+// manager lists them
+func (c *Client) SetResources(resourceType k8s.ResourceType, resources ...k8s.Resource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Resources[resourceType] = resources
+}
+
+// list returns the resources of a type in namespace ("" for all)
+func (c *Client) list(resourceType k8s.ResourceType, namespace string, opts ...k8s.ListOption) ([]k8s.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	options := k8s.NewListOptions(opts...)
+	resources := make([]k8s.Resource, 0, len(c.Resources[resourceType]))
+	for _, resource := range c.Resources[resourceType] {
+		if (namespace == "" || resource.Namespace == namespace) && options.Matches(resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// record stores a mutating action
+func (c *Client) record(verb string, resourceType k8s.ResourceType, name, namespace string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+	c.Actions = append(c.Actions, Action{
+		Verb:      verb,
+		Type:      resourceType,
+		Name:      name,
+		Namespace: namespace,
+	})
+	return nil
+}
@@ -0,0 +1,318 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Workload status values, modelled after kstatus
+const (
+	WorkloadCurrent    = "Current"
+	WorkloadInProgress = "InProgress"
+	WorkloadFailed     = "Failed"
+	WorkloadNotFound   = "NotFound"
+)
+
+// maxInventoryHealthObjects caps how many inventory objects are polled at once
+const maxInventoryHealthObjects = 500
+
+// ObjectRef identifies an object in a Kustomization inventory
+type ObjectRef struct {
+	Namespace string
+	Name      string
+	Group     string
+	Version   string
+	Kind      string
+}
+
+// String renders the reference as kind/namespace/name
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// WorkloadStatus is the computed status of a single inventory object
+type WorkloadStatus struct {
+	Object  ObjectRef `json:"object"`
+	Status  string    `json:"status"`
+	Message string    `json:"message,omitempty"`
+}
+
+// InventoryHealth aggregates the status of every object in a Kustomization
+// inventory, answering whether the applied workloads are actually up
+type InventoryHealth struct {
+	Objects    []WorkloadStatus `json:"objects"`
+	Current    int              `json:"current"`
+	InProgress int              `json:"inProgress"`
+	Failed     int              `json:"failed"`
+	Truncated  bool             `json:"truncated,omitempty"`
+}
+
+// Healthy reports whether every polled object is current
+func (h *InventoryHealth) Healthy() bool {
+	return h.Current == len(h.Objects)
+}
+
+// ParseInventoryID parses a Kustomization inventory entry ID in the format
+// '<namespace>_<name>_<group>_<kind>'
+func ParseInventoryID(id, version string) (ObjectRef, error) {
+	parts := strings.Split(id, "_")
+	if len(parts) != 4 {
+		return ObjectRef{}, fmt.Errorf("invalid inventory id %q", id)
+	}
+	return ObjectRef{
+		Namespace: parts[0],
+		Name:      parts[1],
+		Group:     parts[2],
+		Version:   version,
+		Kind:      parts[3],
+	}, nil
+}
+
+// GetInventory returns the parsed inventory of a Kustomization, empty when
+// nothing has been applied yet. Entries that don't parse are skipped.
+func (c *Client) GetInventory(ctx context.Context, name, namespace string) ([]ObjectRef, error) {
+	var ks kustomizev1.Kustomization
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &ks); err != nil {
+		return nil, fmt.Errorf("failed to get kustomization %s/%s: %w", namespace, name, err)
+	}
+
+	if ks.Status.Inventory == nil {
+		return nil, nil
+	}
+
+	refs := make([]ObjectRef, 0, len(ks.Status.Inventory.Entries))
+	for _, entry := range ks.Status.Inventory.Entries {
+		// One malformed entry shouldn't hide the rest of the inventory
+		ref, err := ParseInventoryID(entry.ID, entry.Version)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// GetInventoryHealth polls every object in a Kustomization's inventory and
+// computes its rollout status
+func (c *Client) GetInventoryHealth(ctx context.Context, name, namespace string) (*InventoryHealth, error) {
+	refs, err := c.GetInventory(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &InventoryHealth{}
+	if len(refs) > maxInventoryHealthObjects {
+		refs = refs[:maxInventoryHealthObjects]
+		health.Truncated = true
+	}
+
+	for _, ref := range refs {
+		health.add(c.getWorkloadStatus(ctx, ref))
+	}
+
+	return health, nil
+}
+
+// add appends an object status and updates the totals
+func (h *InventoryHealth) add(status WorkloadStatus) {
+	h.Objects = append(h.Objects, status)
+	switch status.Status {
+	case WorkloadCurrent:
+		h.Current++
+	case WorkloadInProgress:
+		h.InProgress++
+	default:
+		h.Failed++
+	}
+}
+
+// getWorkloadStatus fetches an inventory object and computes its status
+func (c *Client) getWorkloadStatus(ctx context.Context, ref ObjectRef) WorkloadStatus {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.Version, Kind: ref.Kind})
+
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return WorkloadStatus{Object: ref, Status: WorkloadNotFound, Message: "object not found"}
+		}
+		return WorkloadStatus{Object: ref, Status: WorkloadFailed, Message: err.Error()}
+	}
+
+	status, message := computeWorkloadStatus(obj)
+	return WorkloadStatus{Object: ref, Status: status, Message: message}
+}
+
+// computeWorkloadStatus derives a kstatus-like status from an object. Built-in
+// workloads are checked for a complete rollout; other objects fall back to
+// their Ready condition and are considered current without one.
+func computeWorkloadStatus(obj *unstructured.Unstructured) (string, string) {
+	if obj.GetDeletionTimestamp() != nil {
+		return WorkloadInProgress, "object is being deleted"
+	}
+
+	observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observed < obj.GetGeneration() {
+		return WorkloadInProgress, fmt.Sprintf("generation %d not yet observed", obj.GetGeneration())
+	}
+
+	switch obj.GetObjectKind().GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		return deploymentStatus(obj)
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		return statefulSetStatus(obj)
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		return daemonSetStatus(obj)
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		return jobStatus(obj)
+	case schema.GroupKind{Kind: "Pod"}:
+		return podStatus(obj)
+	}
+
+	return conditionStatus(obj)
+}
+
+// deploymentStatus checks that all replicas are updated and available
+func deploymentStatus(obj *unstructured.Unstructured) (string, string) {
+	if cond := findCondition(obj, "Progressing"); cond != nil && cond["reason"] == "ProgressDeadlineExceeded" {
+		return WorkloadFailed, conditionMessage(cond)
+	}
+
+	desired := specReplicas(obj)
+	updated := statusInt(obj, "updatedReplicas")
+	available := statusInt(obj, "availableReplicas")
+	total := statusInt(obj, "replicas")
+
+	switch {
+	case updated < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d replicas updated", updated, desired)
+	case total > updated:
+		return WorkloadInProgress, fmt.Sprintf("%d old replicas pending termination", total-updated)
+	case available < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d replicas available", available, desired)
+	}
+	return WorkloadCurrent, fmt.Sprintf("%d/%d replicas available", available, desired)
+}
+
+// statefulSetStatus checks that all replicas are ready on the update revision
+func statefulSetStatus(obj *unstructured.Unstructured) (string, string) {
+	desired := specReplicas(obj)
+	ready := statusInt(obj, "readyReplicas")
+	updated := statusInt(obj, "updatedReplicas")
+	current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+
+	switch {
+	case ready < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d replicas ready", ready, desired)
+	case updated < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d replicas updated", updated, desired)
+	case update != "" && current != update:
+		return WorkloadInProgress, fmt.Sprintf("rolling out revision %s", update)
+	}
+	return WorkloadCurrent, fmt.Sprintf("%d/%d replicas ready", ready, desired)
+}
+
+// daemonSetStatus checks that every scheduled pod is updated and available
+func daemonSetStatus(obj *unstructured.Unstructured) (string, string) {
+	desired := statusInt(obj, "desiredNumberScheduled")
+	updated := statusInt(obj, "updatedNumberScheduled")
+	available := statusInt(obj, "numberAvailable")
+
+	switch {
+	case updated < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d pods updated", updated, desired)
+	case available < desired:
+		return WorkloadInProgress, fmt.Sprintf("%d/%d pods available", available, desired)
+	}
+	return WorkloadCurrent, fmt.Sprintf("%d/%d pods available", available, desired)
+}
+
+// jobStatus maps the Complete and Failed job conditions
+func jobStatus(obj *unstructured.Unstructured) (string, string) {
+	if cond := findCondition(obj, "Failed"); cond != nil && cond["status"] == "True" {
+		return WorkloadFailed, conditionMessage(cond)
+	}
+	if cond := findCondition(obj, "Complete"); cond != nil && cond["status"] == "True" {
+		return WorkloadCurrent, "job completed"
+	}
+	return WorkloadInProgress, "job running"
+}
+
+// podStatus maps the pod phase
+func podStatus(obj *unstructured.Unstructured) (string, string) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return WorkloadCurrent, "pod succeeded"
+	case "Failed":
+		return WorkloadFailed, "pod failed"
+	case "Running":
+		if cond := findCondition(obj, "Ready"); cond != nil && cond["status"] == "True" {
+			return WorkloadCurrent, "pod ready"
+		}
+	}
+	return WorkloadInProgress, fmt.Sprintf("pod %s", strings.ToLower(phase))
+}
+
+// conditionStatus uses the Stalled and Ready conditions when present
+func conditionStatus(obj *unstructured.Unstructured) (string, string) {
+	if cond := findCondition(obj, "Stalled"); cond != nil && cond["status"] == "True" {
+		return WorkloadFailed, conditionMessage(cond)
+	}
+	if cond := findCondition(obj, "Ready"); cond != nil {
+		switch cond["status"] {
+		case "True":
+			return WorkloadCurrent, conditionMessage(cond)
+		case "False":
+			if cond["reason"] == "Progressing" {
+				return WorkloadInProgress, conditionMessage(cond)
+			}
+			return WorkloadFailed, conditionMessage(cond)
+		}
+		return WorkloadInProgress, conditionMessage(cond)
+	}
+	return WorkloadCurrent, ""
+}
+
+// findCondition returns the status condition of the given type, if any
+func findCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == conditionType {
+			return cond
+		}
+	}
+	return nil
+}
+
+// specReplicas returns spec.replicas, defaulting to 1
+func specReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// statusInt returns an integer status field, defaulting to 0
+func statusInt(obj *unstructured.Unstructured, field string) int64 {
+	value, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+	return value
+}
+
+// conditionMessage returns a condition's message, or "" without one
+func conditionMessage(cond map[string]interface{}) string {
+	message, _ := cond["message"].(string)
+	return message
+}
@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseInventoryID(t *testing.T) {
+	ref, err := ParseInventoryID("apps_podinfo_apps_Deployment", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, ObjectRef{Namespace: "apps", Name: "podinfo", Group: "apps", Version: "v1", Kind: "Deployment"}, ref)
+
+	ref, err = ParseInventoryID("_apps__Namespace", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "Namespace/apps", ref.String())
+
+	_, err = ParseInventoryID("invalid", "v1")
+	assert.Error(t, err)
+}
+
+func TestClient_GetInventorySkipsInvalidIDs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{Inventory: &kustomizev1.ResourceInventory{Entries: []kustomizev1.ResourceRef{
+				{ID: "podinfo_podinfo_apps_Deployment", Version: "v1"},
+				{ID: "garbled", Version: "v1"},
+				{ID: "podinfo_podinfo__Service", Version: "v1"},
+			}}},
+		},
+	).Build()}
+
+	refs, err := c.GetInventory(t.Context(), "apps", "flux-system")
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	assert.Equal(t, "Deployment/podinfo/podinfo", refs[0].String())
+	assert.Equal(t, "Service/podinfo/podinfo", refs[1].String())
+}
+
+func TestComputeWorkloadStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    map[string]interface{}
+		status string
+	}{
+		{
+			name: "deployment rolled out",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": map[string]interface{}{"generation": int64(2)},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{
+					"observedGeneration": int64(2), "replicas": int64(2),
+					"updatedReplicas": int64(2), "availableReplicas": int64(2),
+				},
+			},
+			status: WorkloadCurrent,
+		},
+		{
+			name: "deployment rolling out",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": map[string]interface{}{"generation": int64(2)},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{
+					"observedGeneration": int64(2), "replicas": int64(3),
+					"updatedReplicas": int64(2), "availableReplicas": int64(2),
+				},
+			},
+			status: WorkloadInProgress,
+		},
+		{
+			name: "generation not observed",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "StatefulSet",
+				"metadata": map[string]interface{}{"generation": int64(3)},
+				"status":   map[string]interface{}{"observedGeneration": int64(2)},
+			},
+			status: WorkloadInProgress,
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+					},
+				},
+			},
+			status: WorkloadFailed,
+		},
+		{
+			name: "job failed",
+			obj: map[string]interface{}{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Failed", "status": "True"},
+					},
+				},
+			},
+			status: WorkloadFailed,
+		},
+		{
+			name: "custom resource not ready",
+			obj: map[string]interface{}{
+				"apiVersion": "example.com/v1", "kind": "Widget",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "False", "reason": "Progressing"},
+					},
+				},
+			},
+			status: WorkloadInProgress,
+		},
+		{
+			name:   "object without status",
+			obj:    map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"},
+			status: WorkloadCurrent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := computeWorkloadStatus(&unstructured.Unstructured{Object: tt.obj})
+			assert.Equal(t, tt.status, status)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// imageGroupVersion is the API version served by the image reflector and
+// automation controllers
+var imageGroupVersion = schema.GroupVersion{Group: "image.toolkit.fluxcd.io", Version: "v1beta2"}
+
+// ScanResult is the outcome of an ImageRepository's latest registry scan
+type ScanResult struct {
+	TagCount   int64     `json:"tag_count"`
+	ScanTime   time.Time `json:"scan_time"`
+	LatestTags []string  `json:"latest_tags,omitempty"`
+}
+
+// ListImageRepositories lists all ImageRepository resources
+func (c *Client) ListImageRepositories(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeImageRepository, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.imageRepositoryResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// imageRepositoryResource converts an ImageRepository into a Resource
+func (c *Client) imageRepositoryResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeImageRepository, obj)
+	resource.URL, _, _ = unstructured.NestedString(obj.Object, "spec", "image")
+
+	if scan, found, _ := unstructured.NestedMap(obj.Object, "status", "lastScanResult"); found {
+		result := &ScanResult{}
+		result.TagCount, _, _ = unstructured.NestedInt64(scan, "tagCount")
+		result.LatestTags, _, _ = unstructured.NestedStringSlice(scan, "latestTags")
+		if scanTime, _, _ := unstructured.NestedString(scan, "scanTime"); scanTime != "" {
+			result.ScanTime, _ = time.Parse(time.RFC3339, scanTime)
+		}
+		resource.LastScan = result
+	}
+
+	return resource
+}
+
+// ListImagePolicies lists all ImagePolicy resources
+func (c *Client) ListImagePolicies(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeImagePolicy, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.imagePolicyResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// imagePolicyResource converts an ImagePolicy into a Resource
+func (c *Client) imagePolicyResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeImagePolicy, obj)
+	resource.Source, _, _ = unstructured.NestedString(obj.Object, "spec", "imageRepositoryRef", "name")
+
+	latestImage, _, _ := unstructured.NestedString(obj.Object, "status", "latestImage")
+	resource.Revision = latestImage
+	if tag, _, _ := unstructured.NestedString(obj.Object, "status", "latestRef", "tag"); tag != "" {
+		resource.Ref = tag
+	} else {
+		resource.Ref = imageTag(latestImage)
+	}
+
+	return resource
+}
+
+// ListImageUpdateAutomations lists all ImageUpdateAutomation resources
+func (c *Client) ListImageUpdateAutomations(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeImageUpdateAutomation, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.imageUpdateAutomationResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// imageUpdateAutomationResource converts an ImageUpdateAutomation into a Resource
+func (c *Client) imageUpdateAutomationResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeImageUpdateAutomation, obj)
+	resource.Source, _, _ = unstructured.NestedString(obj.Object, "spec", "sourceRef", "name")
+	resource.Path, _, _ = unstructured.NestedString(obj.Object, "spec", "update", "path")
+	resource.Ref = pushRef(obj)
+	resource.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "lastPushCommit")
+
+	return resource
+}
+
+// imageTag returns the tag of an image reference, or "" when it has none
+func imageTag(image string) string {
+	name := image
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	// A colon before the last slash belongs to the registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// pushRef returns the git ref an ImageUpdateAutomation pushes to, which
+// defaults to the branch it checks out
+func pushRef(obj *unstructured.Unstructured) string {
+	if refspec, _, _ := unstructured.NestedString(obj.Object, "spec", "git", "push", "refspec"); refspec != "" {
+		return refspec
+	}
+	if branch, _, _ := unstructured.NestedString(obj.Object, "spec", "git", "push", "branch"); branch != "" {
+		return branch
+	}
+	branch, _, _ := unstructured.NestedString(obj.Object, "spec", "git", "checkout", "ref", "branch")
+	return branch
+}
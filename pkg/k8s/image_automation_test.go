@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newUnstructuredClient returns a client backed by a fake API server that
+// serves the unstructured kinds
+func newUnstructuredClient(t *testing.T, objects ...map[string]interface{}) *Client {
+	scheme := runtime.NewScheme()
+	for _, gvk := range unstructuredKinds {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+
+	builder := ctrlfake.NewClientBuilder().WithScheme(scheme)
+	for _, object := range objects {
+		builder = builder.WithObjects(&unstructured.Unstructured{Object: object})
+	}
+	return &Client{Client: builder.Build()}
+}
+
+func TestClient_ImageAutomation(t *testing.T) {
+	c := newUnstructuredClient(t,
+		map[string]interface{}{
+			"apiVersion": "image.toolkit.fluxcd.io/v1beta2",
+			"kind":       "ImageRepository",
+			"metadata":   map[string]interface{}{"name": "podinfo", "namespace": "flux-system"},
+			"spec":       map[string]interface{}{"image": "ghcr.io/stefanprodan/podinfo", "interval": "5m"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{
+					"type": "Ready", "status": "True", "reason": "Succeeded", "message": "successful scan: found 42 tags",
+					"lastTransitionTime": "2026-10-14T10:00:00Z",
+				}},
+				"lastScanResult": map[string]interface{}{"tagCount": int64(42), "scanTime": "2026-10-14T10:00:00Z", "latestTags": []interface{}{"6.5.0", "6.4.1"}},
+			},
+		},
+		map[string]interface{}{
+			"apiVersion": "image.toolkit.fluxcd.io/v1beta2",
+			"kind":       "ImagePolicy",
+			"metadata":   map[string]interface{}{"name": "podinfo", "namespace": "flux-system"},
+			"spec":       map[string]interface{}{"imageRepositoryRef": map[string]interface{}{"name": "podinfo"}},
+			"status":     map[string]interface{}{"latestImage": "ghcr.io/stefanprodan/podinfo:6.5.0"},
+		},
+		map[string]interface{}{
+			"apiVersion": "image.toolkit.fluxcd.io/v1beta2",
+			"kind":       "ImageUpdateAutomation",
+			"metadata":   map[string]interface{}{"name": "apps", "namespace": "flux-system"},
+			"spec": map[string]interface{}{
+				"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": "fleet"},
+				"git": map[string]interface{}{
+					"checkout": map[string]interface{}{"ref": map[string]interface{}{"branch": "main"}},
+					"push":     map[string]interface{}{"branch": "image-updates"},
+				},
+			},
+			"status": map[string]interface{}{"lastPushCommit": "abc123"},
+		},
+	)
+
+	repos, err := c.ListImageRepositories(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "ghcr.io/stefanprodan/podinfo", repos[0].URL)
+	assert.True(t, repos[0].Ready)
+	require.NotNil(t, repos[0].LastScan)
+	assert.EqualValues(t, 42, repos[0].LastScan.TagCount)
+	assert.Equal(t, []string{"6.5.0", "6.4.1"}, repos[0].LastScan.LatestTags)
+	assert.Equal(t, "2026-10-14T10:00:00Z", repos[0].LastReconcile.UTC().Format("2006-01-02T15:04:05Z"))
+
+	policies, err := c.ListImagePolicies(t.Context(), "")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "podinfo", policies[0].Source)
+	assert.Equal(t, "6.5.0", policies[0].Ref)
+
+	automations, err := c.ListImageUpdateAutomations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, automations, 1)
+	assert.Equal(t, "fleet", automations[0].Source)
+	assert.Equal(t, "image-updates", automations[0].Ref)
+	assert.Equal(t, "abc123", automations[0].Revision)
+
+	require.NoError(t, c.SuspendResource(t.Context(), ResourceTypeImageUpdateAutomation, "apps", "flux-system"))
+	updated := newUnstructuredObject(ResourceTypeImageUpdateAutomation)
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "apps", Namespace: "flux-system"}, updated))
+	suspended, _, _ := unstructured.NestedBool(updated.Object, "spec", "suspend")
+	assert.True(t, suspended)
+
+	assert.ErrorIs(t, c.SuspendResource(t.Context(), ResourceTypeImagePolicy, "podinfo", "flux-system"), ErrUnsupportedAction)
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"ghcr.io/stefanprodan/podinfo:6.5.0":              "6.5.0",
+		"registry.local:5000/app":                         "",
+		"registry.local:5000/app:1.2.3":                   "1.2.3",
+		"ghcr.io/app:1.0@sha256:0123456789abcdef":         "1.0",
+		"":                                                "",
+	}
+	for image, want := range tests {
+		assert.Equal(t, want, imageTag(image), image)
+	}
+}
@@ -0,0 +1,30 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// IsInstalled reports whether the cluster serves the CRD of a resource type in
+// any version, i.e. whether the controller owning it has been installed
+func (c *Client) IsInstalled(ctx context.Context, resourceType ResourceType) (bool, error) {
+	obj, err := newObject(resourceType)
+	if err != nil {
+		return false, err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return false, fmt.Errorf("failed to determine kind of %s: %w", resourceType, err)
+	}
+
+	if _, err := c.RESTMapper().RESTMappings(gvk.GroupKind()); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check whether %s is installed: %w", resourceType, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FluxClient is the set of cluster operations the application depends on.
+// It is implemented by Client for live clusters and by the replay client for
+// recorded sessions.
+type FluxClient interface {
+	TestConnection(ctx context.Context) error
+
+	ListGitRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListHelmRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListKustomizations(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListHelmReleases(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListOCIRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListBuckets(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListImageRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListImagePolicies(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListImageUpdateAutomations(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListAlerts(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListProviders(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	ListReceivers(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error)
+	CountResources(ctx context.Context, resourceType ResourceType, namespace string) (int64, error)
+	IsInstalled(ctx context.Context, resourceType ResourceType) (bool, error)
+	GetEvents(ctx context.Context, namespace string) ([]corev1.Event, error)
+	GetResourceEvents(ctx context.Context, resource Resource) ([]corev1.Event, error)
+	GetControllerLogs(ctx context.Context, resourceType ResourceType, sinceSeconds int64) (string, error)
+	ListNamespaces(ctx context.Context) ([]string, error)
+
+	SuspendResource(ctx context.Context, resourceType ResourceType, name, namespace string) error
+	ResumeResource(ctx context.Context, resourceType ResourceType, name, namespace string) error
+	ReconcileResource(ctx context.Context, resourceType ResourceType, name, namespace string) error
+	ReconcileWithSource(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error)
+	ResetHelmRelease(ctx context.Context, name, namespace string) error
+	SnoozeResource(ctx context.Context, resourceType ResourceType, name, namespace string, until time.Time) error
+	DeleteResource(ctx context.Context, resourceType ResourceType, name, namespace string) error
+
+	GetResource(ctx context.Context, resourceType ResourceType, name, namespace string) (*Resource, error)
+	ResolveSource(ctx context.Context, resource Resource) (*Resource, error)
+	BuildDependencyTree(ctx context.Context, resourceType ResourceType, name, namespace string) (*TreeNode, error)
+	DiffKustomization(ctx context.Context, name, namespace string) (string, error)
+	GetResourceYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error)
+	GetComparableYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error)
+	GetInventory(ctx context.Context, name, namespace string) ([]ObjectRef, error)
+	GetInventoryHealth(ctx context.Context, name, namespace string) (*InventoryHealth, error)
+
+	DrainWarnings() []string
+}
+
+var _ FluxClient = (*Client)(nil)
+
+// Watcher is implemented by clients that can watch their resources instead of
+// relying on periodic lists alone. StartWatching returns a channel receiving
+// the type of every resource that changes.
+type Watcher interface {
+	StartWatching(ctx context.Context) (<-chan ResourceType, error)
+}
+
+var _ Watcher = (*Client)(nil)
+
+// ListResources lists the resources of a type through a FluxClient, narrowed
+// by opts
+func ListResources(ctx context.Context, c FluxClient, resourceType ResourceType, namespace string, opts ...ListOption) ([]Resource, error) {
+	switch resourceType {
+	case ResourceTypeGitRepository:
+		return c.ListGitRepositories(ctx, namespace, opts...)
+	case ResourceTypeHelmRepository:
+		return c.ListHelmRepositories(ctx, namespace, opts...)
+	case ResourceTypeKustomization:
+		return c.ListKustomizations(ctx, namespace, opts...)
+	case ResourceTypeHelmRelease:
+		return c.ListHelmReleases(ctx, namespace, opts...)
+	case ResourceTypeOCIRepository:
+		return c.ListOCIRepositories(ctx, namespace, opts...)
+	case ResourceTypeBucket:
+		return c.ListBuckets(ctx, namespace, opts...)
+	case ResourceTypeImageRepository:
+		return c.ListImageRepositories(ctx, namespace, opts...)
+	case ResourceTypeImagePolicy:
+		return c.ListImagePolicies(ctx, namespace, opts...)
+	case ResourceTypeImageUpdateAutomation:
+		return c.ListImageUpdateAutomations(ctx, namespace, opts...)
+	case ResourceTypeAlert:
+		return c.ListAlerts(ctx, namespace, opts...)
+	case ResourceTypeProvider:
+		return c.ListProviders(ctx, namespace, opts...)
+	case ResourceTypeReceiver:
+		return c.ListReceivers(ctx, namespace, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
+
+// ListConcurrency bounds how many types are listed at once per cluster
+const ListConcurrency = 4
+
+// ListAll lists every resource type in parallel and returns them in the
+// order of ResourceTypes, with Kustomizations marked when they drift behind
+// their source. Types whose CRDs are missing list as empty, so the error is
+// the first real failure.
+func ListAll(ctx context.Context, c FluxClient, namespace string, opts ...ListOption) ([]Resource, error) {
+	resourceTypes := ResourceTypes()
+	lists := make([][]Resource, len(resourceTypes))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(ListConcurrency)
+	for i, resourceType := range resourceTypes {
+		g.Go(func() error {
+			list, err := ListResources(ctx, c, resourceType, namespace, opts...)
+			lists[i] = list
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	for _, list := range lists {
+		resources = append(resources, list...)
+	}
+	MarkDrift(resources, resources)
+	return resources, nil
+}
+
+// ListInNamespaces lists the resources of a type in each of the namespaces
+// and merges them in the order the namespaces are given. Unlike listing all
+// namespaces, it only needs RBAC in the namespaces asked for. No namespaces
+// lists all of them.
+func ListInNamespaces(ctx context.Context, c FluxClient, resourceType ResourceType, namespaces []string, opts ...ListOption) ([]Resource, error) {
+	if len(namespaces) == 0 {
+		return ListResources(ctx, c, resourceType, "", opts...)
+	}
+
+	seen := make(map[string]bool, len(namespaces))
+	unique := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace must not be empty")
+		}
+		if !seen[namespace] {
+			seen[namespace] = true
+			unique = append(unique, namespace)
+		}
+	}
+	lists := make([][]Resource, len(unique))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(ListConcurrency)
+	for i, namespace := range unique {
+		g.Go(func() error {
+			list, err := ListResources(ctx, c, resourceType, namespace, opts...)
+			if err != nil {
+				return fmt.Errorf("failed to list namespace %s: %w", namespace, err)
+			}
+			lists[i] = list
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	for _, list := range lists {
+		resources = append(resources, list...)
+	}
+	return resources, nil
+}
@@ -0,0 +1,602 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kustypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrNoArtifact is returned when a diff is asked for before the source of a
+// Kustomization has fetched an artifact
+var ErrNoArtifact = errors.New("source has no artifact yet")
+
+// kustomizeFieldManager is the field manager kustomize-controller applies
+// with. Dry-runs use it so fields the controller owns diff as it would apply.
+const kustomizeFieldManager = "kustomize-controller"
+
+// Directories the artifact and the overlay carrying the Kustomization's own
+// settings are unpacked to when building
+const (
+	diffSourceDir  = "/source"
+	diffOverlayDir = "/overlay"
+)
+
+// substituteDisabledAnnotation opts an object out of post-build substitution
+const substituteDisabledAnnotation = "kustomize.toolkit.fluxcd.io/substitute"
+
+// substituteVar matches ${var}, ${var:=default} and ${var:-default}
+var substituteVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::?[=-]([^}]*))?\}`)
+
+// DiffKustomization builds a Kustomization from its source artifact, like
+// flux diff kustomization, and server-side applies the objects in dry-run
+// mode to diff them against the cluster. It returns a unified diff of the
+// objects that would change, with Secret values masked; objects pruned from
+// the inventory show as removed. SOPS decryption and impersonation of
+// spec.serviceAccountName are not applied.
+func (c *Client) DiffKustomization(ctx context.Context, name, namespace string) (string, error) {
+	ks := &kustomizev1.Kustomization{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, ks); err != nil {
+		return "", fmt.Errorf("failed to get kustomization %s/%s: %w", namespace, name, err)
+	}
+
+	artifact, err := c.sourceArtifact(ctx, ks)
+	if err != nil {
+		return "", err
+	}
+	data, err := c.fetchArtifact(ctx, artifact)
+	if err != nil {
+		return "", err
+	}
+	objects, err := c.buildKustomization(ctx, ks, data)
+	if err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	built := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		ref := unstructuredRef(obj)
+		built[ref.String()] = true
+
+		objDiff, err := c.diffObject(ctx, obj)
+		if err != nil {
+			// Keep going, one object failing the dry-run shouldn't hide the rest
+			fmt.Fprintf(&diff, "# %s: %v\n", ref, err)
+			continue
+		}
+		diff.WriteString(objDiff)
+	}
+
+	if ks.Spec.Prune {
+		pruned, err := c.diffPruned(ctx, ks, built)
+		if err != nil {
+			return "", err
+		}
+		diff.WriteString(pruned)
+	}
+	return diff.String(), nil
+}
+
+// sourceArtifact returns the artifact of the source a Kustomization builds from
+func (c *Client) sourceArtifact(ctx context.Context, ks *kustomizev1.Kustomization) (*sourcev1.Artifact, error) {
+	source := types.NamespacedName{Name: ks.Spec.SourceRef.Name, Namespace: ks.Namespace}
+	if ks.Spec.SourceRef.Namespace != "" {
+		source.Namespace = ks.Spec.SourceRef.Namespace
+	}
+
+	obj, err := newObject(ResourceType(ks.Spec.SourceRef.Kind))
+	if err != nil {
+		return nil, err
+	}
+	artifacts, ok := obj.(interface{ GetArtifact() *sourcev1.Artifact })
+	if !ok {
+		return nil, fmt.Errorf("%s sources carry no artifact: %w", ks.Spec.SourceRef.Kind, ErrUnsupportedAction)
+	}
+	if err := c.Get(ctx, source, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %w", ks.Spec.SourceRef.Kind, source.Name, err)
+	}
+
+	artifact := artifacts.GetArtifact()
+	if artifact == nil || artifact.URL == "" {
+		return nil, fmt.Errorf("%s %s/%s has not fetched yet, reconcile it and retry: %w", ks.Spec.SourceRef.Kind, source.Namespace, source.Name, ErrNoArtifact)
+	}
+	return artifact, nil
+}
+
+// fetchArtifact downloads an artifact from source-controller through the API
+// server's service proxy, since the artifact URL only resolves in-cluster
+func (c *Client) fetchArtifact(ctx context.Context, artifact *sourcev1.Artifact) ([]byte, error) {
+	u, err := url.Parse(artifact.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse artifact url: %w", err)
+	}
+	// The host is <service>.<namespace>.svc.<cluster domain>
+	labels := strings.Split(u.Hostname(), ".")
+	if len(labels) < 2 {
+		return nil, fmt.Errorf("failed to fetch artifact: unexpected host %q", u.Host)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+	}
+
+	data, err := c.CoreV1().Services(labels[1]).ProxyGet(u.Scheme, labels[0], port, u.Path, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifact %s: %w", artifact.Revision, err)
+	}
+
+	if algorithm, digest, found := strings.Cut(artifact.Digest, ":"); found && algorithm == "sha256" {
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != digest {
+			return nil, fmt.Errorf("failed to fetch artifact %s: digest mismatch", artifact.Revision)
+		}
+	}
+	return data, nil
+}
+
+// buildKustomization unpacks an artifact and builds the Kustomization's path
+// with its target namespace, name affixes, patches, images and components,
+// then applies its post-build substitutions and common metadata
+func (c *Client) buildKustomization(ctx context.Context, ks *kustomizev1.Kustomization, artifact []byte) ([]*unstructured.Unstructured, error) {
+	fs := filesys.MakeFsInMemory()
+	if err := untar(fs, diffSourceDir, artifact); err != nil {
+		return nil, fmt.Errorf("failed to unpack artifact: %w", err)
+	}
+
+	root := path.Join(diffSourceDir, path.Clean("/"+ks.Spec.Path))
+	if !fs.IsDir(root) {
+		return nil, fmt.Errorf("path %q not found in the artifact", ks.Spec.Path)
+	}
+	if err := generateKustomization(fs, root); err != nil {
+		return nil, err
+	}
+	if err := writeOverlay(fs, ks, root); err != nil {
+		return nil, err
+	}
+
+	options := krusty.MakeDefaultOptions()
+	options.LoadRestrictions = kustypes.LoadRestrictionsNone
+	resources, err := krusty.MakeKustomizer(options).Run(fs, diffOverlayDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s/%s: %w", ks.Namespace, ks.Name, err)
+	}
+
+	vars, err := c.substitutions(ctx, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, resources.Size())
+	for _, resource := range resources.Resources() {
+		// Substitute in the rendered manifest, as kustomize-controller does
+		data, err := resource.AsYAML()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build kustomization %s/%s: %w", ks.Namespace, ks.Name, err)
+		}
+		if vars != nil && resource.GetAnnotations()[substituteDisabledAnnotation] != "disabled" {
+			data = substitute(data, vars)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in %s/%s/%s: %w", resource.GetKind(), resource.GetNamespace(), resource.GetName(), err)
+		}
+		labelObject(obj, ks)
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// untar extracts a gzip'd tarball below dir, skipping links and entries that
+// would escape it
+func untar(fs filesys.FileSystem, dir string, data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean("/" + header.Name)
+		target := path.Join(dir, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			if err := fs.MkdirAll(path.Dir(target)); err != nil {
+				return err
+			}
+			if err := fs.WriteFile(target, content); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// hasKustomization reports whether a directory holds a kustomization file
+func hasKustomization(fs filesys.FileSystem, dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if fs.Exists(path.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateKustomization writes a kustomization listing the manifests below a
+// directory without one, the way kustomize-controller does. Directories with
+// their own kustomization are included as a whole.
+func generateKustomization(fs filesys.FileSystem, root string) error {
+	if hasKustomization(fs, root) {
+		return nil
+	}
+
+	var resources []string
+	err := fs.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if file != root && hasKustomization(fs, file) {
+				resources = append(resources, strings.TrimPrefix(file, root+"/"))
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := path.Ext(file); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// Skip YAML that isn't a manifest, such as Helm values files
+		if bytes.Contains(content, []byte("apiVersion:")) && bytes.Contains(content, []byte("kind:")) {
+			resources = append(resources, strings.TrimPrefix(file, root+"/"))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate kustomization: %w", err)
+	}
+
+	return writeYAML(fs, path.Join(root, konfig.DefaultKustomizationFileName()), map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	})
+}
+
+// writeOverlay writes a kustomization on top of the Kustomization's path that
+// carries the settings of its spec
+func writeOverlay(fs filesys.FileSystem, ks *kustomizev1.Kustomization, root string) error {
+	base, err := filepath.Rel(diffOverlayDir, root)
+	if err != nil {
+		return err
+	}
+
+	overlay := map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  []string{base},
+	}
+	if ks.Spec.TargetNamespace != "" {
+		overlay["namespace"] = ks.Spec.TargetNamespace
+	}
+	if ks.Spec.NamePrefix != "" {
+		overlay["namePrefix"] = ks.Spec.NamePrefix
+	}
+	if ks.Spec.NameSuffix != "" {
+		overlay["nameSuffix"] = ks.Spec.NameSuffix
+	}
+	if len(ks.Spec.Patches) > 0 {
+		overlay["patches"] = ks.Spec.Patches
+	}
+	if len(ks.Spec.Images) > 0 {
+		overlay["images"] = ks.Spec.Images
+	}
+	if len(ks.Spec.Components) > 0 {
+		components := make([]string, 0, len(ks.Spec.Components))
+		for _, component := range ks.Spec.Components {
+			components = append(components, path.Join(base, component))
+		}
+		overlay["components"] = components
+	}
+
+	if err := fs.MkdirAll(diffOverlayDir); err != nil {
+		return err
+	}
+	return writeYAML(fs, path.Join(diffOverlayDir, konfig.DefaultKustomizationFileName()), overlay)
+}
+
+// writeYAML writes a value as YAML to a file
+func writeYAML(fs filesys.FileSystem, file string, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return fs.WriteFile(file, data)
+}
+
+// substitutions collects the post-build variables of a Kustomization, inline
+// ones overriding those from ConfigMaps and Secrets. Returns nil without
+// post-build settings.
+func (c *Client) substitutions(ctx context.Context, ks *kustomizev1.Kustomization) (map[string]string, error) {
+	if ks.Spec.PostBuild == nil {
+		return nil, nil
+	}
+
+	vars := make(map[string]string)
+	for _, ref := range ks.Spec.PostBuild.SubstituteFrom {
+		key := types.NamespacedName{Name: ref.Name, Namespace: ks.Namespace}
+		var data map[string]string
+		switch ref.Kind {
+		case "ConfigMap":
+			cm := &corev1.ConfigMap{}
+			if err := c.Get(ctx, key, cm); err != nil {
+				if apierrors.IsNotFound(err) && ref.Optional {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get substitutions from ConfigMap %s: %w", ref.Name, err)
+			}
+			data = cm.Data
+		case "Secret":
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, key, secret); err != nil {
+				if apierrors.IsNotFound(err) && ref.Optional {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get substitutions from Secret %s: %w", ref.Name, err)
+			}
+			data = make(map[string]string, len(secret.Data))
+			for k, v := range secret.Data {
+				data[k] = string(v)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported substitution source kind %q", ref.Kind)
+		}
+		for k, v := range data {
+			vars[k] = v
+		}
+	}
+	for k, v := range ks.Spec.PostBuild.Substitute {
+		vars[k] = v
+	}
+	return vars, nil
+}
+
+// substitute replaces the variables in a manifest. Unset variables without a
+// default become empty, as with kustomize-controller.
+func substitute(manifest []byte, vars map[string]string) []byte {
+	return substituteVar.ReplaceAllFunc(manifest, func(match []byte) []byte {
+		groups := substituteVar.FindSubmatch(match)
+		if value, ok := vars[string(groups[1])]; ok {
+			return []byte(value)
+		}
+		return groups[2]
+	})
+}
+
+// labelObject adds the common metadata of a Kustomization and the labels
+// kustomize-controller marks the objects it applies with
+func labelObject(obj *unstructured.Unstructured, ks *kustomizev1.Kustomization) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if ks.Spec.CommonMetadata != nil {
+		for k, v := range ks.Spec.CommonMetadata.Labels {
+			labels[k] = v
+		}
+		if len(ks.Spec.CommonMetadata.Annotations) > 0 {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			for k, v := range ks.Spec.CommonMetadata.Annotations {
+				annotations[k] = v
+			}
+			obj.SetAnnotations(annotations)
+		}
+	}
+	labels[kustomizev1.GroupVersion.Group+"/name"] = ks.Name
+	labels[kustomizev1.GroupVersion.Group+"/namespace"] = ks.Namespace
+	obj.SetLabels(labels)
+}
+
+// diffObject dry-runs the apply of an object and diffs the result against
+// the live object
+func (c *Client) diffObject(ctx context.Context, obj *unstructured.Unstructured) (string, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get live object: %w", err)
+	}
+
+	merged := obj.DeepCopy()
+	if err := c.Patch(ctx, merged, client.Apply, client.DryRunAll, client.FieldOwner(kustomizeFieldManager), client.ForceOwnership); err != nil {
+		return "", fmt.Errorf("dry-run failed: %w", err)
+	}
+
+	if merged.GetKind() == "Secret" {
+		maskSecret(merged, live)
+	}
+	return objectDiff(unstructuredRef(obj), live, merged)
+}
+
+// diffPruned diffs the objects the controller would prune: those in the
+// inventory the build no longer produces
+func (c *Client) diffPruned(ctx context.Context, ks *kustomizev1.Kustomization, built map[string]bool) (string, error) {
+	refs, err := c.GetInventory(ctx, ks.Name, ks.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	for _, ref := range refs {
+		if built[ref.String()] {
+			continue
+		}
+		live := &unstructured.Unstructured{}
+		live.SetAPIVersion(schema.GroupVersion{Group: ref.Group, Version: ref.Version}.String())
+		live.SetKind(ref.Kind)
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, live); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			fmt.Fprintf(&diff, "# %s: failed to get live object: %v\n", ref, err)
+			continue
+		}
+		if live.GetKind() == "Secret" {
+			maskSecret(live, nil)
+		}
+		objDiff, err := objectDiff(ref, live, nil)
+		if err != nil {
+			return "", err
+		}
+		diff.WriteString(objDiff)
+	}
+	return diff.String(), nil
+}
+
+// objectDiff renders the unified diff between a live object and the object
+// after apply, either of which may be nil
+func objectDiff(ref ObjectRef, live, merged *unstructured.Unstructured) (string, error) {
+	liveYAML, err := comparableYAML(live)
+	if err != nil {
+		return "", err
+	}
+	mergedYAML, err := comparableYAML(merged)
+	if err != nil {
+		return "", err
+	}
+
+	fromFile, toFile := "live/"+ref.String(), "merged/"+ref.String()
+	if live == nil {
+		fromFile += " (created)"
+	}
+	if merged == nil {
+		toFile += " (pruned)"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(mergedYAML),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", ref, err)
+	}
+	return diff, nil
+}
+
+// comparableYAML renders an object without its status and server-generated
+// metadata
+func comparableYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	obj = obj.DeepCopy()
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", unstructuredRef(obj), err)
+	}
+	return string(data), nil
+}
+
+// maskSecret replaces the values of a Secret, telling changed values apart by
+// comparing them with the live Secret, which is masked as well
+func maskSecret(secret, live *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		values, _, _ := unstructured.NestedMap(secret.Object, field)
+		var liveValues map[string]interface{}
+		if live != nil {
+			liveValues, _, _ = unstructured.NestedMap(live.Object, field)
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if live != nil && liveValues[key] != values[key] {
+				values[key] = redactedValue + " (changed)"
+			} else {
+				values[key] = redactedValue
+			}
+		}
+		for key := range liveValues {
+			liveValues[key] = redactedValue
+		}
+		if len(values) > 0 {
+			_ = unstructured.SetNestedMap(secret.Object, values, field)
+		}
+		if len(liveValues) > 0 {
+			_ = unstructured.SetNestedMap(live.Object, liveValues, field)
+		}
+	}
+}
+
+// unstructuredRef identifies an unstructured object
+func unstructuredRef(obj *unstructured.Unstructured) ObjectRef {
+	gvk := obj.GroupVersionKind()
+	return ObjectRef{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+	}
+}
@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// artifactResponse serves an artifact through the fake service proxy
+type artifactResponse []byte
+
+func (r artifactResponse) DoRaw(context.Context) ([]byte, error) {
+	return r, nil
+}
+
+func (r artifactResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r)), nil
+}
+
+// tarball packs files into a gzip'd tarball like a source artifact
+func tarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// diffTestClient serves a Kustomization whose source artifact holds files.
+// Dry-run applies return the built object unchanged.
+func diffTestClient(t *testing.T, artifact *sourcev1.Artifact, files map[string]string, objects ...client.Object) *Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	data := tarball(t, files)
+	if artifact != nil {
+		sum := sha256.Sum256(data)
+		artifact.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef:       kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "flux-system"},
+			Path:            "./apps",
+			Prune:           true,
+			TargetNamespace: "podinfo",
+			PostBuild:       &kustomizev1.PostBuild{Substitute: map[string]string{"color": "green"}},
+		},
+		Status: kustomizev1.KustomizationStatus{
+			Inventory: &kustomizev1.ResourceInventory{Entries: []kustomizev1.ResourceRef{
+				{ID: "podinfo_podinfo__ConfigMap", Version: "v1"},
+				{ID: "podinfo_old__ConfigMap", Version: "v1"},
+			}},
+		},
+	}
+	repo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+		Status:     sourcev1.GitRepositoryStatus{Artifact: artifact},
+	}
+
+	ctrl := ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, ks, repo)...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch == client.Apply {
+					return nil
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	clientset := k8sfake.NewSimpleClientset()
+	clientset.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+		proxy := action.(k8stesting.ProxyGetAction)
+		assert.Equal(t, "flux-system", proxy.GetNamespace())
+		assert.Equal(t, "source-controller", proxy.GetName())
+		assert.Equal(t, "/gitrepository/flux-system/flux-system/abc.tar.gz", proxy.GetPath())
+		return true, artifactResponse(data), nil
+	})
+
+	return &Client{Client: ctrl, Interface: clientset}
+}
+
+func TestClient_DiffKustomization(t *testing.T) {
+	labels := map[string]string{
+		"kustomize.toolkit.fluxcd.io/name":      "apps",
+		"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+	}
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "podinfo", Labels: labels},
+		Data:       map[string]string{"color": "blue", "size": "large"},
+	}
+	old := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "podinfo", Labels: labels},
+		Data:       map[string]string{"stale": "true"},
+	}
+	artifact := &sourcev1.Artifact{
+		URL:      "http://source-controller.flux-system.svc.cluster.local./gitrepository/flux-system/flux-system/abc.tar.gz",
+		Revision: "main@sha1:abc",
+	}
+	c := diffTestClient(t, artifact, map[string]string{
+		"apps/podinfo.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: podinfo\ndata:\n  color: ${color}\n  size: ${size:=large}\n",
+		"apps/new.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: new\ndata:\n  fresh: \"true\"\n",
+		// Not a manifest, left out of the generated kustomization
+		"apps/values.yaml": "replicaCount: 1\n",
+	}, live, old)
+
+	diff, err := c.DiffKustomization(context.Background(), "apps", "flux-system")
+	require.NoError(t, err)
+
+	// Changed values diff, substituted values that match do not
+	assert.Contains(t, diff, "--- live/ConfigMap/podinfo/podinfo\n+++ merged/ConfigMap/podinfo/podinfo\n")
+	assert.Contains(t, diff, "-  color: blue\n+  color: green\n")
+	assert.NotContains(t, diff, "-  size")
+	assert.NotContains(t, diff, "+  size")
+
+	// New objects land in the target namespace, pruned ones are removed
+	assert.Contains(t, diff, "--- live/ConfigMap/podinfo/new (created)\n")
+	assert.Contains(t, diff, "+  fresh: \"true\"\n")
+	assert.Contains(t, diff, "+++ merged/ConfigMap/podinfo/old (pruned)\n")
+	assert.Contains(t, diff, "-  stale: \"true\"\n")
+}
+
+func TestClient_DiffKustomizationNoArtifact(t *testing.T) {
+	c := diffTestClient(t, nil, map[string]string{})
+
+	_, err := c.DiffKustomization(context.Background(), "apps", "flux-system")
+	assert.ErrorIs(t, err, ErrNoArtifact)
+	assert.Contains(t, err.Error(), "GitRepository flux-system/flux-system has not fetched yet")
+}
+
+func TestMaskSecret(t *testing.T) {
+	live := &corev1.Secret{Data: map[string][]byte{"user": []byte("admin"), "password": []byte("old")}}
+	merged := &corev1.Secret{Data: map[string][]byte{"user": []byte("admin"), "password": []byte("new")}}
+	liveObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	require.NoError(t, err)
+	mergedObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(merged)
+	require.NoError(t, err)
+
+	liveSecret, mergedSecret := &unstructured.Unstructured{Object: liveObj}, &unstructured.Unstructured{Object: mergedObj}
+	maskSecret(mergedSecret, liveSecret)
+	assert.Equal(t, map[string]interface{}{"user": "REDACTED", "password": "REDACTED (changed)"}, mergedSecret.Object["data"])
+	assert.Equal(t, map[string]interface{}{"user": "REDACTED", "password": "REDACTED"}, liveSecret.Object["data"])
+}
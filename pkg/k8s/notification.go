@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// notificationGroupVersion is the API version of Alerts and Providers
+var notificationGroupVersion = schema.GroupVersion{Group: "notification.toolkit.fluxcd.io", Version: "v1beta3"}
+
+// receiverGroupVersion is the API version of Receivers, which graduated to v1
+// ahead of Alerts and Providers
+var receiverGroupVersion = schema.GroupVersion{Group: "notification.toolkit.fluxcd.io", Version: "v1"}
+
+// ListAlerts lists all Alert resources
+func (c *Client) ListAlerts(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeAlert, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.alertResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// alertResource converts an Alert into a Resource
+func (c *Client) alertResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeAlert, obj)
+	resource.Source, _, _ = unstructured.NestedString(obj.Object, "spec", "providerRef", "name")
+	resource.EventSources = objectRefs(obj, "spec", "eventSources")
+	markStatic(&resource)
+
+	return resource
+}
+
+// ListProviders lists all notification Provider resources
+func (c *Client) ListProviders(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeProvider, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.providerResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// providerResource converts a Provider into a Resource
+func (c *Client) providerResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeProvider, obj)
+	resource.Provider, _, _ = unstructured.NestedString(obj.Object, "spec", "type")
+	resource.Path, _, _ = unstructured.NestedString(obj.Object, "spec", "channel")
+	markStatic(&resource)
+
+	return resource
+}
+
+// ListReceivers lists all Receiver resources
+func (c *Client) ListReceivers(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	items, err := c.listUnstructured(ctx, ResourceTypeReceiver, namespace, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(items))
+	for i := range items {
+		resources = append(resources, c.receiverResource(&items[i]))
+	}
+
+	return resources, nil
+}
+
+// receiverResource converts a Receiver into a Resource
+func (c *Client) receiverResource(obj *unstructured.Unstructured) Resource {
+	resource := c.unstructuredResource(ResourceTypeReceiver, obj)
+	resource.Provider, _, _ = unstructured.NestedString(obj.Object, "spec", "type")
+	resource.URL, _, _ = unstructured.NestedString(obj.Object, "status", "webhookPath")
+	resource.EventSources = objectRefs(obj, "spec", "resources")
+
+	return resource
+}
+
+// objectRefs renders a list of cross-namespace object references as
+// Kind/name, or Kind/namespace/name when the namespace is set. A name of "*"
+// matches every object of the kind.
+func objectRefs(obj *unstructured.Unstructured, fields ...string) []string {
+	raw, _, _ := unstructured.NestedSlice(obj.Object, fields...)
+	refs := make([]string, 0, len(raw))
+	for _, item := range raw {
+		ref, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, name, namespace := stringField(ref, "kind"), stringField(ref, "name"), stringField(ref, "namespace")
+		if namespace != "" {
+			refs = append(refs, fmt.Sprintf("%s/%s/%s", kind, namespace, name))
+		} else {
+			refs = append(refs, fmt.Sprintf("%s/%s", kind, name))
+		}
+	}
+	return refs
+}
+
+// markStatic reports Alerts and Providers as ready when they carry no Ready
+// condition. Since v1beta3 they are static objects the controller no longer
+// reconciles, so there is no status to wait for.
+func markStatic(resource *Resource) {
+	for _, cond := range resource.Conditions {
+		if cond.Type == "Ready" {
+			return
+		}
+	}
+	resource.Ready = true
+	resource.Status = "Ready"
+}
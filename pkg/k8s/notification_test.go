@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClient_Notifications(t *testing.T) {
+	c := newUnstructuredClient(t,
+		map[string]interface{}{
+			"apiVersion": "notification.toolkit.fluxcd.io/v1beta3",
+			"kind":       "Alert",
+			"metadata":   map[string]interface{}{"name": "on-call", "namespace": "flux-system"},
+			"spec": map[string]interface{}{
+				"providerRef": map[string]interface{}{"name": "slack"},
+				"eventSources": []interface{}{
+					map[string]interface{}{"kind": "Kustomization", "name": "*"},
+					map[string]interface{}{"kind": "HelmRelease", "name": "podinfo", "namespace": "apps"},
+				},
+			},
+		},
+		map[string]interface{}{
+			"apiVersion": "notification.toolkit.fluxcd.io/v1beta3",
+			"kind":       "Provider",
+			"metadata":   map[string]interface{}{"name": "slack", "namespace": "flux-system"},
+			"spec":       map[string]interface{}{"type": "slack", "channel": "#deploys"},
+		},
+		map[string]interface{}{
+			"apiVersion": "notification.toolkit.fluxcd.io/v1",
+			"kind":       "Receiver",
+			"metadata":   map[string]interface{}{"name": "github", "namespace": "flux-system"},
+			"spec": map[string]interface{}{
+				"type":      "github",
+				"resources": []interface{}{map[string]interface{}{"kind": "GitRepository", "name": "fleet"}},
+			},
+			"status": map[string]interface{}{
+				"webhookPath": "/hook/bed6d00b5555b1603e1f59b94d7fdbca58089cb5663633fb83f2815dc626d92b",
+				"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False", "reason": "TokenNotFound", "message": "secret not found"}},
+			},
+		},
+	)
+
+	alerts, err := c.ListAlerts(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "slack", alerts[0].Source)
+	assert.Equal(t, []string{"Kustomization/*", "HelmRelease/apps/podinfo"}, alerts[0].EventSources)
+	assert.True(t, alerts[0].Ready, "static Alerts without conditions are ready")
+
+	providers, err := c.ListProviders(t.Context(), "")
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "slack", providers[0].Provider)
+	assert.Equal(t, "#deploys", providers[0].Path)
+
+	receivers, err := c.ListReceivers(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, receivers, 1)
+	assert.Equal(t, "github", receivers[0].Provider)
+	assert.Equal(t, "/hook/bed6d00b5555b1603e1f59b94d7fdbca58089cb5663633fb83f2815dc626d92b", receivers[0].URL)
+	assert.Equal(t, []string{"GitRepository/fleet"}, receivers[0].EventSources)
+	assert.False(t, receivers[0].Ready)
+	assert.Equal(t, "TokenNotFound", receivers[0].Status)
+
+	for resourceType, name := range map[ResourceType]string{ResourceTypeAlert: "on-call", ResourceTypeReceiver: "github"} {
+		require.NoError(t, c.SuspendResource(t.Context(), resourceType, name, "flux-system"))
+		updated := newUnstructuredObject(resourceType)
+		require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: name, Namespace: "flux-system"}, updated))
+		suspended, _, _ := unstructured.NestedBool(updated.Object, "spec", "suspend")
+		assert.True(t, suspended, resourceType)
+
+		require.NoError(t, c.ResumeResource(t.Context(), resourceType, name, "flux-system"))
+	}
+
+	assert.ErrorIs(t, c.SuspendResource(t.Context(), ResourceTypeProvider, "slack", "flux-system"), ErrUnsupportedAction)
+}
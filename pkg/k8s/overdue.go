@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultOverdueMargin is how long the controller may leave a reconcile
+// request or spec change unhandled before the resource is reported overdue
+const DefaultOverdueMargin = 5 * time.Minute
+
+// unobservedGeneration is a generation the controller had not observed yet
+// when a list first saw it
+type unobservedGeneration struct {
+	generation int64
+	since      time.Time
+}
+
+// markOverdue sets the reconcile schedule of a resource converted from obj
+func (c *Client) markOverdue(resource *Resource, obj client.Object, interval time.Duration, lastHandledReconcileAt string, observedGeneration int64) {
+	now := time.Now()
+	behind := c.behindSince(obj, lastHandledReconcileAt, observedGeneration, now)
+	resource.setOverdue(interval, lastHandledReconcileAt, behind, c.OverdueMargin, now)
+}
+
+// behindSince returns since when the controller has had work on obj that it
+// hasn't picked up: a reconcile request it hasn't handled, or a generation it
+// hasn't observed. It returns the zero time when the controller is caught up.
+// Steady interval reconciles leave no trace in status, so this is the only
+// reliable sign of a controller falling behind.
+func (c *Client) behindSince(obj client.Object, lastHandledReconcileAt string, observedGeneration int64, now time.Time) time.Time {
+	var since time.Time
+	if requested := obj.GetAnnotations()[reconcileRequestAnnotation]; requested != "" && requested != lastHandledReconcileAt {
+		since, _ = time.Parse(time.RFC3339, requested)
+	}
+
+	// Generations carry no timestamp, so age them from the first list that
+	// saw them unobserved. Objects without status yet have nothing to compare.
+	key := string(obj.GetUID())
+	if key == "" {
+		key = obj.GetNamespace() + "/" + obj.GetName()
+	}
+	if observedGeneration <= 0 || obj.GetGeneration() <= observedGeneration {
+		c.unobserved.Delete(key)
+		return since
+	}
+	seen, _ := c.unobserved.LoadOrStore(key, unobservedGeneration{generation: obj.GetGeneration(), since: now})
+	unobserved := seen.(unobservedGeneration)
+	if unobserved.generation != obj.GetGeneration() {
+		unobserved = unobservedGeneration{generation: obj.GetGeneration(), since: now}
+		c.unobserved.Store(key, unobserved)
+	}
+	if since.IsZero() || unobserved.since.Before(since) {
+		since = unobserved.since
+	}
+	return since
+}
+
+// setOverdue records the reconcile interval and last known reconcile of a
+// resource and flags it overdue when the controller has been behind since
+// longer than margin. The last reconcile is the latest of the handled
+// reconcile request and the condition transitions, which is a lower bound
+// since steady reconciles don't move them.
+func (r *Resource) setOverdue(interval time.Duration, lastHandledReconcileAt string, behindSince time.Time, margin time.Duration, now time.Time) {
+	r.Interval = interval
+	r.LastReconcile = lastReconcile(lastHandledReconcileAt, r.Conditions)
+	r.Overdue = isOverdue(r.Interval, behindSince, r.Suspended, margin, now)
+	r.NextReconcile = nextReconcile(r.Interval, r.LastReconcile, r.Suspended, now)
+}
+
+// nextReconcile estimates when the controller reconciles again: the first
+// whole interval after the last known reconcile that is still ahead of now.
+// The last reconcile is a lower bound, so steady reconciles since then are
+// assumed to have kept the schedule. It returns the zero time when the
+// controller won't reconcile or the last reconcile is unknown.
+func nextReconcile(interval time.Duration, last time.Time, suspended bool, now time.Time) time.Time {
+	if suspended || interval <= 0 || last.IsZero() {
+		return time.Time{}
+	}
+	next := last.Add(interval)
+	if next.After(now) {
+		return next
+	}
+	return last.Add((now.Sub(last)/interval + 1) * interval)
+}
+
+// SinceReconcile returns how long ago the resource last reconciled, or 0
+// when that is unknown
+func (r Resource) SinceReconcile(now time.Time) time.Duration {
+	if r.LastReconcile.IsZero() {
+		return 0
+	}
+	return now.Sub(r.LastReconcile)
+}
+
+// UntilReconcile returns how long until the next scheduled reconcile. It is
+// negative once the reconcile is due and 0 when none is scheduled.
+func (r Resource) UntilReconcile(now time.Time) time.Duration {
+	if r.NextReconcile.IsZero() {
+		return 0
+	}
+	return r.NextReconcile.Sub(now)
+}
+
+// lastReconcile returns the most recent reconcile time known from status
+func lastReconcile(lastHandledReconcileAt string, conditions []Condition) time.Time {
+	var last time.Time
+	if handled, err := time.Parse(time.RFC3339, lastHandledReconcileAt); err == nil {
+		last = handled
+	}
+	for _, cond := range conditions {
+		if cond.LastTransitionTime.After(last) {
+			last = cond.LastTransitionTime
+		}
+	}
+	return last
+}
+
+// isOverdue reports whether the controller of a resource reconciled on an
+// interval has been behind for more than margin. Suspended resources are
+// never overdue.
+func isOverdue(interval time.Duration, behindSince time.Time, suspended bool, margin time.Duration, now time.Time) bool {
+	if suspended || interval <= 0 || behindSince.IsZero() {
+		return false
+	}
+	return now.Sub(behindSince) > margin
+}
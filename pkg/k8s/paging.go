@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DefaultListPageSize is how many objects a list requests from the API
+// server at a time
+const DefaultListPageSize = 500
+
+// Pager is implemented by clients that page through large lists and may
+// stop before the last page
+type Pager interface {
+	// Truncated reports whether the latest list of a resource type stopped
+	// at the list limit with more objects left on the server
+	Truncated(resourceType ResourceType) bool
+}
+
+var _ Pager = (*Client)(nil)
+
+// Truncated implements Pager
+func (c *Client) Truncated(resourceType ResourceType) bool {
+	truncated, _ := c.truncated.Load(resourceType)
+	return truncated == true
+}
+
+// listPages lists through reader page by page until the server has no more
+// or ListLimit objects are read. Lists that bring their own limit or
+// continue token are passed through as a single request. A continue token
+// that expires part way restarts the list once from the first page, since
+// the pages read so far belong to a snapshot the server no longer serves.
+func (c *Client) listPages(ctx context.Context, reader client.Reader, list client.ObjectList, opts ...client.ListOption) error {
+	var listOpts client.ListOptions
+	listOpts.ApplyOptions(opts)
+	if c.ListPageSize <= 0 || listOpts.Limit > 0 || listOpts.Continue != "" {
+		return reader.List(ctx, list, opts...)
+	}
+
+	var items []runtime.Object
+	var resourceVersion, continueToken string
+	truncated, restarted := false, false
+	for {
+		page := list.DeepCopyObject().(client.ObjectList)
+		pageOpts := append(slices.Clip(opts), client.Limit(c.ListPageSize), client.Continue(continueToken))
+		if err := reader.List(ctx, page, pageOpts...); err != nil {
+			if continueToken == "" || !apierrors.IsResourceExpired(err) {
+				return err
+			}
+			if restarted {
+				return fmt.Errorf("failed to list, the list expired while paging, also after restarting it: %w", err)
+			}
+			items, continueToken, restarted = nil, "", true
+			continue
+		}
+		pageItems, err := meta.ExtractList(page)
+		if err != nil {
+			return fmt.Errorf("failed to read list page: %w", err)
+		}
+		items = append(items, pageItems...)
+		resourceVersion, continueToken = page.GetResourceVersion(), page.GetContinue()
+
+		if c.ListLimit > 0 && len(items) > c.ListLimit {
+			items, truncated = items[:c.ListLimit], true
+			break
+		}
+		if continueToken == "" {
+			break
+		}
+		if c.ListLimit > 0 && len(items) == c.ListLimit {
+			truncated = true
+			break
+		}
+	}
+
+	if err := meta.SetList(list, items); err != nil {
+		return fmt.Errorf("failed to assemble list pages: %w", err)
+	}
+	list.SetResourceVersion(resourceVersion)
+	c.setTruncated(list, truncated)
+	return nil
+}
+
+// setTruncated records whether the latest list of the list's resource type
+// was cut short
+func (c *Client) setTruncated(list client.ObjectList, truncated bool) {
+	if c.Client == nil {
+		return
+	}
+	gvk, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return
+	}
+	c.truncated.Store(ResourceType(strings.TrimSuffix(gvk.Kind, "List")), truncated)
+}
+
@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// pagingClient serves count Kustomizations in pages like the API server,
+// using the offset of the next page as the continue token. It returns the
+// limits of the requests that reached the API.
+func pagingClient(t *testing.T, count int) (*Client, *[]int64) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	builder := ctrlfake.NewClientBuilder().WithScheme(scheme)
+	for i := range count {
+		builder = builder.WithObjects(&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("app-%02d", i), Namespace: "flux-system"}})
+	}
+
+	var limits []int64
+	ctrl := builder.WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			var listOpts client.ListOptions
+			listOpts.ApplyOptions(opts)
+			limits = append(limits, listOpts.Limit)
+
+			// The fake client ignores limits, so cut the page from the full list
+			if err := c.List(ctx, list, &client.ListOptions{Namespace: listOpts.Namespace}); err != nil {
+				return err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return err
+			}
+			start := 0
+			if listOpts.Continue != "" {
+				start, _ = strconv.Atoi(listOpts.Continue)
+			}
+			end := len(items)
+			if listOpts.Limit > 0 {
+				end = min(end, start+int(listOpts.Limit))
+			}
+			if end < len(items) {
+				list.SetContinue(strconv.Itoa(end))
+			}
+			return meta.SetList(list, items[start:end])
+		},
+	}).Build()
+
+	return &Client{Client: ctrl}, &limits
+}
+
+func TestClient_ListPages(t *testing.T) {
+	c, limits := pagingClient(t, 7)
+	c.ListPageSize = 3
+
+	resources, err := c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 7)
+	assert.Equal(t, "app-00", resources[0].Name)
+	assert.Equal(t, "app-06", resources[6].Name)
+	assert.Equal(t, []int64{3, 3, 3}, *limits)
+	assert.False(t, c.Truncated(ResourceTypeKustomization))
+
+	// Without a page size everything comes in one request
+	c, limits = pagingClient(t, 7)
+	resources, err = c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 7)
+	assert.Equal(t, []int64{0}, *limits)
+
+	// Counting keeps its own limit
+	c, limits = pagingClient(t, 7)
+	c.ListPageSize = 3
+	_, err = c.CountResources(context.Background(), ResourceTypeKustomization, "flux-system")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, *limits)
+}
+
+func TestClient_ListPagesLimit(t *testing.T) {
+	// The cap stops paging part way through a page
+	c, limits := pagingClient(t, 7)
+	c.ListPageSize, c.ListLimit = 3, 4
+	resources, err := c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 4)
+	assert.Equal(t, []int64{3, 3}, *limits)
+	assert.True(t, c.Truncated(ResourceTypeKustomization))
+
+	// Reaching the cap at the end of a page stops before the next one
+	c, limits = pagingClient(t, 7)
+	c.ListPageSize, c.ListLimit = 3, 6
+	resources, err = c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 6)
+	assert.Equal(t, []int64{3, 3}, *limits)
+	assert.True(t, c.Truncated(ResourceTypeKustomization))
+
+	// A cap the list fits in is not reported
+	c, _ = pagingClient(t, 7)
+	c.ListPageSize, c.ListLimit = 3, 7
+	resources, err = c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 7)
+	assert.False(t, c.Truncated(ResourceTypeKustomization))
+	assert.False(t, c.Truncated(ResourceTypeHelmRelease))
+}
+
+// expiringClient fails the next expire continued lists as expired, like the
+// API server once a continue token outlives its snapshot
+type expiringClient struct {
+	client.Client
+	expire int
+}
+
+func (c *expiringClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	var listOpts client.ListOptions
+	listOpts.ApplyOptions(opts)
+	if listOpts.Continue != "" && c.expire > 0 {
+		c.expire--
+		return apierrors.NewResourceExpired("continue token expired")
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestClient_ListPagesExpired(t *testing.T) {
+	// An expired token restarts the list, without the pages read before
+	c, limits := pagingClient(t, 7)
+	c.ListPageSize = 3
+	c.Client = &expiringClient{Client: c.Client, expire: 1}
+	resources, err := c.ListKustomizations(context.Background(), "flux-system")
+	require.NoError(t, err)
+	assert.Len(t, resources, 7)
+	assert.Equal(t, []int64{3, 3, 3, 3}, *limits)
+
+	// A list that expires again gives up and says why
+	c, _ = pagingClient(t, 7)
+	c.ListPageSize = 3
+	c.Client = &expiringClient{Client: c.Client, expire: 2}
+	_, err = c.ListKustomizations(context.Background(), "flux-system")
+	assert.ErrorContains(t, err, "the list expired while paging")
+	assert.True(t, apierrors.IsResourceExpired(err))
+}
@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ReconcileWithSource requests a reconcile of the source a Kustomization or
+// HelmRelease references, or of the HelmChart generated from a HelmRelease's
+// spec.chart template, waits until source-controller has handled the
+// request and the source is Ready, then reconciles the resource itself, like
+// flux reconcile --with-source. It returns the source's artifact revision.
+// The wait is bounded by ctx.
+func (c *Client) ReconcileWithSource(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	if err := checkAction(resourceType, ActionReconcileWithSource); err != nil {
+		return "", err
+	}
+
+	sourceType, source, err := c.sourceOf(ctx, resourceType, name, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	// A request the controller has yet to pick up is as good as a new one
+	if err := c.ReconcileResource(ctx, sourceType, source.Name, source.Namespace); err != nil && !errors.Is(err, ErrReconcilePending) {
+		return "", fmt.Errorf("failed to reconcile source: %w", err)
+	}
+
+	revision, err := c.waitForHandled(ctx, sourceType, source)
+	if err != nil {
+		return "", err
+	}
+
+	return revision, c.ReconcileResource(ctx, resourceType, name, namespace)
+}
+
+// ResolveSource fetches the source a Kustomization or HelmRelease references
+func (c *Client) ResolveSource(ctx context.Context, resource Resource) (*Resource, error) {
+	kind, source, err := c.sourceRef(ctx, resource.Type, resource.Name, resource.Namespace, false)
+	if err != nil {
+		return nil, err
+	}
+	sourceType, err := sourceResourceType(resource, kind)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetResource(ctx, sourceType, source.Name, source.Namespace)
+}
+
+// SourceRef returns the type and key of the source a listed Kustomization or
+// HelmRelease references, as recorded in its SourceRef and ChartSource fields
+func SourceRef(resource Resource) (ResourceType, types.NamespacedName, error) {
+	source := types.NamespacedName{Namespace: resource.Namespace}
+	var kind string
+
+	switch resource.Type {
+	case ResourceTypeKustomization:
+		if resource.SourceRef != nil {
+			kind, source.Name = resource.SourceRef.Kind, resource.SourceRef.Name
+			if resource.SourceRef.Namespace != "" {
+				source.Namespace = resource.SourceRef.Namespace
+			}
+		}
+	case ResourceTypeHelmRelease:
+		if resource.ChartSource != nil {
+			kind, source.Name = resource.ChartSource.Kind, resource.ChartSource.Name
+			if resource.ChartSource.Namespace != "" {
+				source.Namespace = resource.ChartSource.Namespace
+			}
+		}
+	default:
+		return "", source, fmt.Errorf("%s has no source: %w", resource.Type, ErrUnsupportedAction)
+	}
+
+	if source.Name == "" {
+		return "", source, fmt.Errorf("%s/%s has no source reference", resource.Type, resource.Name)
+	}
+	sourceType, err := sourceResourceType(resource, kind)
+	return sourceType, source, err
+}
+
+// sourceResourceType checks that a referenced source kind is one fluxcli lists
+func sourceResourceType(resource Resource, kind string) (ResourceType, error) {
+	sourceType := ResourceType(kind)
+	if !isListed(sourceType) {
+		return "", fmt.Errorf("%s/%s references a %q source, which is not listed: %w", resource.Type, resource.Name, kind, ErrUnsupportedAction)
+	}
+	return sourceType, nil
+}
+
+// sourceOf returns the type and key of the source a Kustomization or
+// HelmRelease references, checking that it can be reconciled
+func (c *Client) sourceOf(ctx context.Context, resourceType ResourceType, name, namespace string) (ResourceType, types.NamespacedName, error) {
+	kind, source, err := c.sourceRef(ctx, resourceType, name, namespace, true)
+	if err != nil {
+		return "", source, err
+	}
+
+	sourceType := ResourceType(kind)
+	if !SupportsAction(sourceType, ActionReconcile) {
+		return "", source, fmt.Errorf("%s/%s references a %q source, which cannot be reconciled: %w", resourceType, name, kind, ErrUnsupportedAction)
+	}
+	return sourceType, source, nil
+}
+
+// sourceRef fetches a Kustomization or HelmRelease and returns the kind and
+// key of the source it references. Sources default to the resource's namespace.
+// With generated, a HelmRelease's spec.chart template resolves to the HelmChart
+// helm-controller generates from it rather than to the template's source.
+func (c *Client) sourceRef(ctx context.Context, resourceType ResourceType, name, namespace string, generated bool) (string, types.NamespacedName, error) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	var kind string
+	source := types.NamespacedName{Namespace: namespace}
+
+	switch resourceType {
+	case ResourceTypeKustomization:
+		ks := &kustomizev1.Kustomization{}
+		if err := c.Get(ctx, key, ks); err != nil {
+			return "", source, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		kind, source.Name = ks.Spec.SourceRef.Kind, ks.Spec.SourceRef.Name
+		if ks.Spec.SourceRef.Namespace != "" {
+			source.Namespace = ks.Spec.SourceRef.Namespace
+		}
+	case ResourceTypeHelmRelease:
+		hr := &helmv2.HelmRelease{}
+		if err := c.Get(ctx, key, hr); err != nil {
+			return "", source, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		switch {
+		case hr.Spec.ChartRef != nil:
+			kind, source.Name = hr.Spec.ChartRef.Kind, hr.Spec.ChartRef.Name
+			if hr.Spec.ChartRef.Namespace != "" {
+				source.Namespace = hr.Spec.ChartRef.Namespace
+			}
+		case hr.Spec.Chart != nil:
+			ref := hr.Spec.Chart.Spec.SourceRef
+			kind, source.Name = ref.Kind, ref.Name
+			if ref.Namespace != "" {
+				source.Namespace = ref.Namespace
+			}
+			if generated {
+				// The HelmChart is what fetches the chart, and lives next to
+				// its source as <namespace>-<name>, like flux reconcile uses
+				kind, source.Name = string(ResourceTypeHelmChart), fmt.Sprintf("%s-%s", hr.Namespace, hr.Name)
+			}
+		}
+	default:
+		return "", source, fmt.Errorf("%s has no source: %w", resourceType, ErrUnsupportedAction)
+	}
+	return kind, source, nil
+}
+
+// waitForHandled polls a source until its controller has handled the pending
+// reconcile request and it reports Ready, returning its artifact revision.
+// A source that stops progressing fails the wait right away.
+func (c *Client) waitForHandled(ctx context.Context, resourceType ResourceType, key types.NamespacedName) (string, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var last revisionStatus
+	for {
+		obj, err := newObject(resourceType)
+		if err != nil {
+			return "", err
+		}
+		if err := c.Get(ctx, key, obj); err != nil && ctx.Err() == nil {
+			return "", fmt.Errorf("failed to get %s/%s: %w", resourceType, key.Name, err)
+		}
+
+		requested := obj.GetAnnotations()[reconcileRequestAnnotation]
+		if requested != "" && requested == lastHandledReconcile(obj) {
+			status, err := c.getRevisionStatus(ctx, resourceType, key.Name, key.Namespace)
+			if err != nil && ctx.Err() == nil {
+				return "", err
+			}
+			last = status
+			if status.Ready {
+				return status.Revision, nil
+			}
+			if status.Reason != "" && !progressingReasons[status.Reason] {
+				return "", fmt.Errorf("source %s %s/%s failed: %s: %s", resourceType, key.Namespace, key.Name, status.Reason, status.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("timed out waiting for source %s %s/%s to fetch (last revision: %q, message: %q): %w",
+				resourceType, key.Namespace, key.Name, last.Revision, last.Message, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
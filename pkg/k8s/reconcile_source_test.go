@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestClient_ReconcileWithSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	repo := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "flux-system"}}
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec:       kustomizev1.KustomizationSpec{SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "fleet"}},
+	}
+
+	var order []string
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(repo, ks).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// Stand in for source-controller, which fetches as soon as it is asked to
+			Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				order = append(order, obj.GetName())
+				if repo, ok := obj.(*sourcev1.GitRepository); ok {
+					repo.Status.LastHandledReconcileAt = repo.Annotations[reconcileRequestAnnotation]
+					repo.Status.Artifact = &sourcev1.Artifact{Revision: "main@sha1:abc123"}
+					repo.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Succeeded", LastTransitionTime: metav1.Now()}}
+				}
+				return cl.Update(ctx, obj, opts...)
+			},
+		}).Build()}
+
+	revision, err := c.ReconcileWithSource(t.Context(), ResourceTypeKustomization, "apps", "flux-system")
+	require.NoError(t, err)
+	assert.Equal(t, "main@sha1:abc123", revision)
+	assert.Equal(t, []string{"fleet", "apps"}, order, "the source is reconciled first")
+
+	var updated kustomizev1.Kustomization
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "apps", Namespace: "flux-system"}, &updated))
+	assert.NotEmpty(t, updated.Annotations[reconcileRequestAnnotation])
+
+	_, err = c.ReconcileWithSource(t.Context(), ResourceTypeGitRepository, "fleet", "flux-system")
+	assert.ErrorIs(t, err, ErrUnsupportedAction)
+}
+
+func TestClient_ReconcileWithSourceHelmChart(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	// helm-controller generates the HelmChart next to the HelmRepository
+	chart := &sourcev1.HelmChart{ObjectMeta: metav1.ObjectMeta{Name: "apps-redis", Namespace: "flux-system"}}
+	hr := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "apps"},
+		Spec: helmv2.HelmReleaseSpec{
+			Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{
+				Chart:     "redis",
+				SourceRef: helmv2.CrossNamespaceObjectReference{Kind: "HelmRepository", Name: "bitnami", Namespace: "flux-system"},
+			}},
+		},
+	}
+
+	var order []string
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(chart, hr).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				order = append(order, obj.GetNamespace()+"/"+obj.GetName())
+				if chart, ok := obj.(*sourcev1.HelmChart); ok {
+					chart.Status.LastHandledReconcileAt = chart.Annotations[reconcileRequestAnnotation]
+					chart.Status.Artifact = &sourcev1.Artifact{Revision: "18.1.0"}
+					chart.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ChartPullSucceeded", LastTransitionTime: metav1.Now()}}
+				}
+				return cl.Update(ctx, obj, opts...)
+			},
+		}).Build()}
+
+	revision, err := c.ReconcileWithSource(t.Context(), ResourceTypeHelmRelease, "redis", "apps")
+	require.NoError(t, err)
+	assert.Equal(t, "18.1.0", revision)
+	assert.Equal(t, []string{"flux-system/apps-redis", "apps/redis"}, order, "the generated HelmChart is reconciled first")
+}
+
+func TestClient_ReconcileWithSourceFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	repo := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "infra"}}
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec:       kustomizev1.KustomizationSpec{SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "infra"}},
+	}
+
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(repo, ks).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if repo, ok := obj.(*sourcev1.GitRepository); ok {
+					repo.Status.LastHandledReconcileAt = repo.Annotations[reconcileRequestAnnotation]
+					repo.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "GitOperationFailed", Message: "authentication required", LastTransitionTime: metav1.Now()}}
+				}
+				return cl.Update(ctx, obj, opts...)
+			},
+		}).Build()}
+
+	// A failed fetch stops before the Kustomization is touched
+	_, err := c.ReconcileWithSource(t.Context(), ResourceTypeKustomization, "apps", "flux-system")
+	assert.ErrorContains(t, err, "source GitRepository infra/fleet failed: GitOperationFailed: authentication required")
+
+	var updated kustomizev1.Kustomization
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "apps", Namespace: "flux-system"}, &updated))
+	assert.Empty(t, updated.Annotations[reconcileRequestAnnotation])
+}
+
+func TestClient_ResolveSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	repo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "shared"},
+		Spec:       sourcev1.GitRepositorySpec{URL: "https://github.com/example/fleet"},
+	}
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec:       kustomizev1.KustomizationSpec{SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "shared"}},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(repo, ks).Build()}
+
+	source, err := c.ResolveSource(t.Context(), Resource{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"})
+	require.NoError(t, err)
+	assert.Equal(t, ResourceTypeGitRepository, source.Type)
+	assert.Equal(t, "shared", source.Namespace)
+	assert.Equal(t, "https://github.com/example/fleet", source.URL)
+
+	_, err = c.ResolveSource(t.Context(), Resource{Type: ResourceTypeGitRepository, Name: "fleet", Namespace: "shared"})
+	assert.ErrorIs(t, err, ErrUnsupportedAction)
+}
+
+func TestSourceRef(t *testing.T) {
+	sourceType, key, err := SourceRef(Resource{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Source: "OCIRepository/shared/manifests",
+		SourceRef: &SourceReference{Kind: "OCIRepository", Name: "manifests", Namespace: "shared"}})
+	require.NoError(t, err)
+	assert.Equal(t, ResourceTypeOCIRepository, sourceType)
+	assert.Equal(t, types.NamespacedName{Name: "manifests", Namespace: "shared"}, key)
+
+	sourceType, key, err = SourceRef(Resource{Type: ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps",
+		ChartSource: &ChartSource{Kind: "HelmRepository", Name: "podinfo", Namespace: "apps"}})
+	require.NoError(t, err)
+	assert.Equal(t, ResourceTypeHelmRepository, sourceType)
+	assert.Equal(t, types.NamespacedName{Name: "podinfo", Namespace: "apps"}, key)
+
+	// HelmCharts are not listed, so there is no row to jump to
+	_, _, err = SourceRef(Resource{Type: ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps",
+		ChartSource: &ChartSource{Kind: "HelmChart", Name: "apps-podinfo", ChartRef: true}})
+	assert.ErrorIs(t, err, ErrUnsupportedAction)
+
+	_, _, err = SourceRef(Resource{Type: ResourceTypeKustomization, Name: "apps"})
+	assert.Error(t, err)
+}
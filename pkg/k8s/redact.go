@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/yaml"
+)
+
+// redactedValue replaces sensitive values in redacted manifests
+const redactedValue = "REDACTED"
+
+// lastAppliedAnnotation holds a full copy of the manifest as applied by kubectl
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// RedactYAML masks values in a manifest that commonly carry secrets so it can
+// be shared safely: inline Helm values, post-build substitutions, credentials
+// embedded in URLs and the kubectl last-applied copy of the manifest. Keys are
+// kept so the structure stays readable.
+func RedactYAML(manifest string) (string, error) {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			if _, exists := annotations[lastAppliedAnnotation]; exists {
+				annotations[lastAppliedAnnotation] = redactedValue
+			}
+		}
+	}
+
+	if spec, ok := obj["spec"].(map[string]interface{}); ok {
+		if values, exists := spec["values"]; exists {
+			spec["values"] = redactLeaves(values)
+		}
+		if postBuild, ok := spec["postBuild"].(map[string]interface{}); ok {
+			if substitute, exists := postBuild["substitute"]; exists {
+				postBuild["substitute"] = redactLeaves(substitute)
+			}
+		}
+		redactURL(spec, "url")
+	}
+	if status, ok := obj["status"].(map[string]interface{}); ok {
+		redactURL(status, "url")
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal redacted manifest: %w", err)
+	}
+	return string(data), nil
+}
+
+// redactLeaves replaces every scalar in a nested value while keeping map keys
+// and list lengths
+func redactLeaves(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = redactLeaves(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactLeaves(child)
+		}
+		return v
+	case nil:
+		return nil
+	}
+	return redactedValue
+}
+
+// redactURL masks the password of a URL stored under key, if any
+func redactURL(fields map[string]interface{}, key string) {
+	raw, ok := fields[key].(string)
+	if !ok {
+		return
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	} else {
+		u.User = url.User(redactedValue)
+	}
+	fields[key] = u.String()
+}
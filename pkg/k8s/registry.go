@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Action is an operation the UI or CLI can perform on a resource
+type Action string
+
+const (
+	ActionSuspend   Action = "suspend"
+	ActionResume    Action = "resume"
+	ActionReconcile Action = "reconcile"
+	ActionReconcileWithSource Action = "reconcile-source"
+	ActionReset     Action = "reset"
+	ActionSnooze    Action = "snooze"
+	ActionUnsnooze  Action = "unsnooze"
+	ActionDelete    Action = "delete"
+)
+
+// allActions lists every action in the order menus present them
+var allActions = []Action{ActionReconcile, ActionReconcileWithSource, ActionSuspend, ActionResume, ActionReset, ActionSnooze, ActionUnsnooze, ActionDelete}
+
+// ErrUnsupportedAction is returned when a resource type does not support an action
+var ErrUnsupportedAction = errors.New("action not supported")
+
+// ResourceInfo carries the capability metadata of a resource type
+type ResourceInfo struct {
+	Type ResourceType
+	// Suspendable is true when the kind has a spec.suspend field
+	Suspendable bool
+	// Reconcilable is true when the kind honours the reconcile.fluxcd.io/requestedAt annotation
+	Reconcilable bool
+	// SourceReconcilable is true when the kind references a source that can be
+	// reconciled ahead of it
+	SourceReconcilable bool
+	// Resettable is true when the kind's remediation retries can be reset
+	Resettable bool
+	// Snoozable is true when the kind can be muted in triage views
+	Snoozable bool
+	// Controller is the Flux controller that reconciles the kind
+	Controller string
+}
+
+// registry holds the capabilities of every supported resource type
+var registry = map[ResourceType]ResourceInfo{
+	ResourceTypeGitRepository:  {Type: ResourceTypeGitRepository, Controller: "source-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeHelmRepository: {Type: ResourceTypeHelmRepository, Controller: "source-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeKustomization:  {Type: ResourceTypeKustomization, Controller: "kustomize-controller", Suspendable: true, Reconcilable: true, SourceReconcilable: true, Snoozable: true},
+	ResourceTypeHelmRelease:    {Type: ResourceTypeHelmRelease, Controller: "helm-controller", Suspendable: true, Reconcilable: true, SourceReconcilable: true, Resettable: true, Snoozable: true},
+	ResourceTypeOCIRepository:  {Type: ResourceTypeOCIRepository, Controller: "source-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeBucket:         {Type: ResourceTypeBucket, Controller: "source-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeImageRepository:       {Type: ResourceTypeImageRepository, Controller: "image-reflector-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeImagePolicy:           {Type: ResourceTypeImagePolicy, Controller: "image-reflector-controller", Snoozable: true}, // Re-evaluated whenever its ImageRepository scans
+	ResourceTypeImageUpdateAutomation: {Type: ResourceTypeImageUpdateAutomation, Controller: "image-automation-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeAlert:                 {Type: ResourceTypeAlert, Controller: "notification-controller", Suspendable: true, Snoozable: true}, // Static since v1beta3, nothing to reconcile
+	ResourceTypeProvider:              {Type: ResourceTypeProvider, Controller: "notification-controller", Snoozable: true},
+	ResourceTypeReceiver:              {Type: ResourceTypeReceiver, Controller: "notification-controller", Suspendable: true, Reconcilable: true, Snoozable: true},
+	ResourceTypeHelmChart:             {Type: ResourceTypeHelmChart, Controller: "source-controller", Reconcilable: true}, // Not listed, see ResourceTypeHelmChart
+}
+
+// resourceOrder lists the registered types in display order
+var resourceOrder = []ResourceType{ResourceTypeGitRepository, ResourceTypeHelmRepository, ResourceTypeKustomization, ResourceTypeHelmRelease, ResourceTypeOCIRepository, ResourceTypeBucket,
+	ResourceTypeImageRepository, ResourceTypeImagePolicy, ResourceTypeImageUpdateAutomation,
+	ResourceTypeAlert, ResourceTypeProvider, ResourceTypeReceiver}
+
+// ResourceTypes returns every listed resource type in display order
+func ResourceTypes() []ResourceType {
+	return append([]ResourceType(nil), resourceOrder...)
+}
+
+// isListed reports whether a resource type is one of ResourceTypes
+func isListed(resourceType ResourceType) bool {
+	return slices.Contains(resourceOrder, resourceType)
+}
+
+// LookupResource returns the registry entry of a resource type
+func LookupResource(resourceType ResourceType) (ResourceInfo, bool) {
+	info, exists := registry[resourceType]
+	return info, exists
+}
+
+// SupportsAction reports whether a resource type supports an action
+func SupportsAction(resourceType ResourceType, action Action) bool {
+	info, exists := registry[resourceType]
+	if !exists {
+		return false
+	}
+
+	switch action {
+	case ActionSuspend, ActionResume:
+		return info.Suspendable
+	case ActionReconcile:
+		return info.Reconcilable
+	case ActionReconcileWithSource:
+		return info.SourceReconcilable
+	case ActionReset:
+		return info.Resettable
+	case ActionSnooze, ActionUnsnooze:
+		return info.Snoozable
+	case ActionDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportedActions returns the actions a resource type supports, in menu order
+func SupportedActions(resourceType ResourceType) []Action {
+	var actions []Action
+	for _, action := range allActions {
+		if SupportsAction(resourceType, action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// checkAction returns an ErrUnsupportedAction error when a type lacks an action
+func checkAction(resourceType ResourceType, action Action) error {
+	if !SupportsAction(resourceType, action) {
+		return fmt.Errorf("%s does not support %s: %w", resourceType, action, ErrUnsupportedAction)
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RecordedFrame is a single snapshot in a recording. Recordings are stored as
+// JSON lines; a frame carries either the resources of one type or the events
+// of one cluster.
+type RecordedFrame struct {
+	Time      time.Time      `json:"time"`
+	Cluster   string         `json:"cluster"`
+	Type      ResourceType   `json:"type,omitempty"`
+	Resources []Resource     `json:"resources,omitempty"`
+	Events    []corev1.Event `json:"events,omitempty"`
+}
+
+// Recorder appends resource and event snapshots to a recording file
+type Recorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewRecorder creates a recorder writing to path, truncating any existing file
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording %s: %w", path, err)
+	}
+
+	return &Recorder{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// RecordResources records a resource snapshot for a cluster
+func (r *Recorder) RecordResources(cluster string, resourceType ResourceType, resources []Resource) error {
+	return r.write(RecordedFrame{
+		Time:      time.Now(),
+		Cluster:   cluster,
+		Type:      resourceType,
+		Resources: resources,
+	})
+}
+
+// RecordEvents records an event snapshot for a cluster
+func (r *Recorder) RecordEvents(cluster string, events []corev1.Event) error {
+	return r.write(RecordedFrame{
+		Time:    time.Now(),
+		Cluster: cluster,
+		Events:  events,
+	})
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// write appends a frame to the recording
+func (r *Recorder) write(frame RecordedFrame) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.encoder.Encode(frame); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// LoadRecording reads a recording file and returns a replay client per recorded cluster
+func LoadRecording(path string) (map[string]FluxClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s: %w", path, err)
+	}
+	defer file.Close()
+
+	clients := make(map[string]*fileClient)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var frame RecordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("invalid frame at %s:%d: %w", path, line, err)
+		}
+
+		client, exists := clients[frame.Cluster]
+		if !exists {
+			client = newFileClient()
+			clients[frame.Cluster] = client
+		}
+		client.addFrame(frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("recording %s contains no frames", path)
+	}
+
+	result := make(map[string]FluxClient, len(clients))
+	for name, client := range clients {
+		result[name] = client
+	}
+	return result, nil
+}
+
+// fileClient is a read-only FluxClient that replays recorded snapshots. Each
+// list call advances to the next recorded snapshot of that type and stays on
+// the last one once the recording is exhausted.
+type fileClient struct {
+	mu        sync.Mutex
+	frames    map[ResourceType][][]Resource
+	positions map[ResourceType]int
+	events    [][]corev1.Event
+	eventPos  int
+}
+
+// newFileClient creates an empty replay client
+func newFileClient() *fileClient {
+	return &fileClient{
+		frames:    make(map[ResourceType][][]Resource),
+		positions: make(map[ResourceType]int),
+	}
+}
+
+// addFrame appends a recorded frame to the client
+func (f *fileClient) addFrame(frame RecordedFrame) {
+	if frame.Type == "" {
+		f.events = append(f.events, frame.Events)
+		return
+	}
+	f.frames[frame.Type] = append(f.frames[frame.Type], frame.Resources)
+}
+
+// next returns the next snapshot for a resource type filtered by namespace
+func (f *fileClient) next(resourceType ResourceType, namespace string) []Resource {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	frames := f.frames[resourceType]
+	if len(frames) == 0 {
+		return []Resource{}
+	}
+
+	pos := f.positions[resourceType]
+	if pos < len(frames)-1 {
+		f.positions[resourceType] = pos + 1
+	}
+
+	return filterNamespace(frames[pos], namespace)
+}
+
+// current returns the current snapshot for a resource type without advancing
+func (f *fileClient) current(resourceType ResourceType, namespace string) []Resource {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	frames := f.frames[resourceType]
+	if len(frames) == 0 {
+		return []Resource{}
+	}
+	return filterNamespace(frames[f.positions[resourceType]], namespace)
+}
+
+// filterNamespace returns the resources in namespace ("" keeps all)
+func filterNamespace(resources []Resource, namespace string) []Resource {
+	if namespace == "" {
+		return resources
+	}
+
+	filtered := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		if resource.Namespace == namespace {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// errReplayReadOnly is returned for mutating operations during replay
+var errReplayReadOnly = fmt.Errorf("not supported while replaying a recording")
+
+// TestConnection always succeeds for recordings
+func (f *fileClient) TestConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListGitRepositories returns the next recorded GitRepository snapshot
+func (f *fileClient) ListGitRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeGitRepository, namespace)), nil
+}
+
+// ListHelmRepositories returns the next recorded HelmRepository snapshot
+func (f *fileClient) ListHelmRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeHelmRepository, namespace)), nil
+}
+
+// ListKustomizations returns the next recorded Kustomization snapshot
+func (f *fileClient) ListKustomizations(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeKustomization, namespace)), nil
+}
+
+// ListHelmReleases returns the next recorded HelmRelease snapshot
+func (f *fileClient) ListHelmReleases(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeHelmRelease, namespace)), nil
+}
+
+// ListOCIRepositories returns the next recorded OCIRepository snapshot
+func (f *fileClient) ListOCIRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeOCIRepository, namespace)), nil
+}
+
+// ListBuckets returns the next recorded Bucket snapshot
+func (f *fileClient) ListBuckets(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeBucket, namespace)), nil
+}
+
+// ListImageRepositories returns the next recorded ImageRepository snapshot
+func (f *fileClient) ListImageRepositories(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeImageRepository, namespace)), nil
+}
+
+// ListImagePolicies returns the next recorded ImagePolicy snapshot
+func (f *fileClient) ListImagePolicies(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeImagePolicy, namespace)), nil
+}
+
+// ListImageUpdateAutomations returns the next recorded ImageUpdateAutomation snapshot
+func (f *fileClient) ListImageUpdateAutomations(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeImageUpdateAutomation, namespace)), nil
+}
+
+// ListAlerts returns the next recorded Alert snapshot
+func (f *fileClient) ListAlerts(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeAlert, namespace)), nil
+}
+
+// ListProviders returns the next recorded Provider snapshot
+func (f *fileClient) ListProviders(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeProvider, namespace)), nil
+}
+
+// ListReceivers returns the next recorded Receiver snapshot
+func (f *fileClient) ListReceivers(ctx context.Context, namespace string, opts ...ListOption) ([]Resource, error) {
+	return NewListOptions(opts...).Filter(f.next(ResourceTypeReceiver, namespace)), nil
+}
+
+// CountResources counts the resources in the current recorded snapshot
+func (f *fileClient) CountResources(ctx context.Context, resourceType ResourceType, namespace string) (int64, error) {
+	return int64(len(f.current(resourceType, namespace))), nil
+}
+
+// IsInstalled assumes every type is installed since recordings hold no CRDs
+func (f *fileClient) IsInstalled(ctx context.Context, resourceType ResourceType) (bool, error) {
+	return true, nil
+}
+
+// GetEvents returns the next recorded event snapshot
+func (f *fileClient) GetEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.events) == 0 {
+		return []corev1.Event{}, nil
+	}
+
+	events := f.events[f.eventPos]
+	if f.eventPos < len(f.events)-1 {
+		f.eventPos++
+	}
+
+	if namespace == "" {
+		return events, nil
+	}
+	filtered := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		if event.Namespace == namespace {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered, nil
+}
+
+// GetResourceEvents returns the events of a resource in the current recorded
+// event snapshot, without advancing to the next one
+func (f *fileClient) GetResourceEvents(ctx context.Context, resource Resource) ([]corev1.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.events) == 0 {
+		return []corev1.Event{}, nil
+	}
+
+	return FilterResourceEvents(f.events[f.eventPos], resource), nil
+}
+
+// GetControllerLogs is not supported during replay since recordings hold no logs
+func (f *fileClient) GetControllerLogs(ctx context.Context, resourceType ResourceType, sinceSeconds int64) (string, error) {
+	return "", errReplayReadOnly
+}
+
+// ListNamespaces returns the namespaces of the recorded resources
+func (f *fileClient) ListNamespaces(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for resourceType, frames := range f.frames {
+		if len(frames) == 0 {
+			continue
+		}
+		for _, resource := range frames[f.positions[resourceType]] {
+			seen[resource.Namespace] = true
+		}
+	}
+
+	namespaces := make([]string, 0, len(seen))
+	for namespace := range seen {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// SuspendResource is not supported during replay
+func (f *fileClient) SuspendResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	return errReplayReadOnly
+}
+
+// ResumeResource is not supported during replay
+func (f *fileClient) ResumeResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	return errReplayReadOnly
+}
+
+// ReconcileResource is not supported during replay
+func (f *fileClient) ReconcileResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	return errReplayReadOnly
+}
+
+// ReconcileWithSource is not supported during replay
+func (f *fileClient) ReconcileWithSource(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	return "", errReplayReadOnly
+}
+
+// ResetHelmRelease is not supported during replay
+func (f *fileClient) ResetHelmRelease(ctx context.Context, name, namespace string) error {
+	return errReplayReadOnly
+}
+
+// SnoozeResource is not supported during replay
+func (f *fileClient) SnoozeResource(ctx context.Context, resourceType ResourceType, name, namespace string, until time.Time) error {
+	return errReplayReadOnly
+}
+
+// DeleteResource is not supported during replay
+func (f *fileClient) DeleteResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	return errReplayReadOnly
+}
+
+// GetResource finds a resource in the current recorded snapshot
+func (f *fileClient) GetResource(ctx context.Context, resourceType ResourceType, name, namespace string) (*Resource, error) {
+	for _, resource := range f.current(resourceType, namespace) {
+		if resource.Name == name {
+			return &resource, nil
+		}
+	}
+	gr := schema.GroupResource{Resource: string(resourceType)}
+	return nil, fmt.Errorf("%s %s/%s not found: %w", resourceType, namespace, name, apierrors.NewNotFound(gr, name))
+}
+
+// ResolveSource finds a resource's source in the current recorded snapshot
+func (f *fileClient) ResolveSource(ctx context.Context, resource Resource) (*Resource, error) {
+	sourceType, source, err := SourceRef(resource)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetResource(ctx, sourceType, source.Name, source.Namespace)
+}
+
+// BuildDependencyTree is not supported during replay since recordings hold no inventories
+func (f *fileClient) BuildDependencyTree(ctx context.Context, resourceType ResourceType, name, namespace string) (*TreeNode, error) {
+	return nil, errReplayReadOnly
+}
+
+// DiffKustomization is not supported during replay since recordings hold no artifacts
+func (f *fileClient) DiffKustomization(ctx context.Context, name, namespace string) (string, error) {
+	return "", errReplayReadOnly
+}
+
+// GetResourceYAML is not supported during replay since recordings hold no manifests
+func (f *fileClient) GetResourceYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	return "", errReplayReadOnly
+}
+
+// GetComparableYAML is not supported during replay since recordings hold no manifests
+func (f *fileClient) GetComparableYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	return "", errReplayReadOnly
+}
+
+// GetInventory is not supported during replay since recordings hold no inventories
+func (f *fileClient) GetInventory(ctx context.Context, name, namespace string) ([]ObjectRef, error) {
+	return nil, errReplayReadOnly
+}
+
+// GetInventoryHealth is not supported during replay since recordings hold no workloads
+func (f *fileClient) GetInventoryHealth(ctx context.Context, name, namespace string) (*InventoryHealth, error) {
+	return nil, errReplayReadOnly
+}
+
+// DrainWarnings returns nothing since recordings hold no API warnings
+func (f *fileClient) DrainWarnings() []string {
+	return nil
+}
@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	recorder, err := NewRecorder(path)
+	require.NoError(t, err)
+
+	first := []Resource{{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}}
+	second := []Resource{
+		{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Ready: true},
+		{Type: ResourceTypeKustomization, Name: "infra", Namespace: "infra"},
+	}
+	require.NoError(t, recorder.RecordResources("staging", ResourceTypeKustomization, first))
+	require.NoError(t, recorder.RecordResources("staging", ResourceTypeKustomization, second))
+	require.NoError(t, recorder.RecordEvents("staging", []corev1.Event{{Reason: "ReconciliationSucceeded"}}))
+	require.NoError(t, recorder.Close())
+
+	clients, err := LoadRecording(path)
+	require.NoError(t, err)
+	require.Contains(t, clients, "staging")
+	client := clients["staging"]
+	ctx := context.Background()
+
+	// Snapshots are replayed in order and the last one sticks
+	resources, err := client.ListKustomizations(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.False(t, resources[0].Ready)
+
+	resources, err = client.ListKustomizations(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	resources, err = client.ListKustomizations(ctx, "flux-system")
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.True(t, resources[0].Ready)
+
+	// Types without frames are empty
+	resources, err = client.ListHelmReleases(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	events, err := client.GetEvents(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "ReconciliationSucceeded", events[0].Reason)
+
+	// Replays are read-only
+	assert.Error(t, client.SuspendResource(ctx, ResourceTypeKustomization, "apps", "flux-system"))
+}
@@ -2,7 +2,10 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,7 +14,11 @@ import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -24,13 +31,59 @@ const (
 	ResourceTypeHelmRepository ResourceType = "HelmRepository"
 	ResourceTypeKustomization  ResourceType = "Kustomization"
 	ResourceTypeHelmRelease    ResourceType = "HelmRelease"
+	ResourceTypeOCIRepository  ResourceType = "OCIRepository"
+	ResourceTypeBucket         ResourceType = "Bucket"
+	ResourceTypeImageRepository       ResourceType = "ImageRepository"
+	ResourceTypeImagePolicy           ResourceType = "ImagePolicy"
+	ResourceTypeImageUpdateAutomation ResourceType = "ImageUpdateAutomation"
+	ResourceTypeAlert                 ResourceType = "Alert"
+	ResourceTypeProvider              ResourceType = "Provider"
+	ResourceTypeReceiver              ResourceType = "Receiver"
+
+	// ResourceTypeHelmChart is not listed, it is only reconciled on behalf of
+	// the HelmRelease whose spec.chart template it was generated from
+	ResourceTypeHelmChart ResourceType = "HelmChart"
 )
 
+// ParseResourceType resolves a user-supplied resource type name or alias
+func ParseResourceType(s string) (ResourceType, error) {
+	switch strings.ToLower(s) {
+	case "gitrepository", "gitrepositories", "gitrepo":
+		return ResourceTypeGitRepository, nil
+	case "helmrepository", "helmrepositories", "helmrepo":
+		return ResourceTypeHelmRepository, nil
+	case "kustomization", "kustomizations", "ks":
+		return ResourceTypeKustomization, nil
+	case "helmrelease", "helmreleases", "hr":
+		return ResourceTypeHelmRelease, nil
+	case "ocirepository", "ocirepositories", "ocirepo":
+		return ResourceTypeOCIRepository, nil
+	case "bucket", "buckets":
+		return ResourceTypeBucket, nil
+	case "imagerepository", "imagerepositories", "imagerepo":
+		return ResourceTypeImageRepository, nil
+	case "imagepolicy", "imagepolicies":
+		return ResourceTypeImagePolicy, nil
+	case "imageupdateautomation", "imageupdateautomations", "iua":
+		return ResourceTypeImageUpdateAutomation, nil
+	case "alert", "alerts":
+		return ResourceTypeAlert, nil
+	case "provider", "providers":
+		return ResourceTypeProvider, nil
+	case "receiver", "receivers":
+		return ResourceTypeReceiver, nil
+	default:
+		return "", fmt.Errorf("unknown resource type: %s", s)
+	}
+}
+
 // Resource represents a generic FluxCD resource
 type Resource struct {
+	Cluster     string        `json:"cluster,omitempty"`
 	Type        ResourceType  `json:"type"`
 	Name        string        `json:"name"`
 	Namespace   string        `json:"namespace"`
+	UID         string        `json:"uid,omitempty"`
 	Ready       bool          `json:"ready"`
 	Status      string        `json:"status"`
 	Message     string        `json:"message"`
@@ -40,10 +93,63 @@ type Resource struct {
 	Suspended   bool          `json:"suspended"`
 	Source      string        `json:"source,omitempty"`
 	Path        string        `json:"path,omitempty"`
+	InventoryCount int        `json:"inventory_count,omitempty"` // Kustomizations only: the objects in status.inventory
+	DecryptFailed  bool       `json:"decrypt_failed,omitempty"`  // Kustomizations only: the build failed to decrypt SOPS secrets
 	Revision    string        `json:"revision,omitempty"`
+	Drift       bool          `json:"drift,omitempty"` // Kustomizations only: the applied revision lags the source's artifact, see MarkDrift
+	Digest      string        `json:"digest,omitempty"` // Sources only: the artifact digest, as <algorithm>:<checksum>
+	Size        int64         `json:"size,omitempty"`   // Sources only: the artifact size in bytes
 	URL         string        `json:"url,omitempty"`
 	Chart       string        `json:"chart,omitempty"`
 	Version     string        `json:"version,omitempty"`
+	Ref         string        `json:"ref,omitempty"` // OCIRepositories: the tag, semver range or digest followed; ImagePolicies: the latest selected tag; ImageUpdateAutomations: the git ref pushed to
+	Provider    string        `json:"provider,omitempty"` // Buckets: generic, aws, gcp or azure; Providers and Receivers: the spec.type
+	LastScan    *ScanResult   `json:"last_scan,omitempty"` // ImageRepositories only
+	EventSources []string     `json:"event_sources,omitempty"` // Alerts and Receivers: the objects watched or reconciled, as Kind/name
+	Labels      map[string]string `json:"labels,omitempty"`
+	FetchError  string        `json:"fetch_error,omitempty"` // Sources only: the active FetchFailed/StorageOperationFailed message
+	SecretRef   string        `json:"secret_ref,omitempty"`  // Sources only: the spec.secretRef holding the credentials
+	AuthFailed  bool          `json:"auth_failed,omitempty"` // Sources only: the last fetch failed with AuthenticationFailed
+	SourceRef   *SourceReference `json:"source_ref,omitempty"` // Kustomizations only: spec.sourceRef
+	ChartSource *ChartSource  `json:"chart_source,omitempty"` // HelmReleases only
+	HelmRevision int          `json:"helm_revision,omitempty"` // HelmReleases only: the Helm release revision installed
+	History      []HelmSnapshot `json:"history,omitempty"`     // HelmReleases only: status.history, newest first
+	SnoozedUntil time.Time    `json:"snoozed_until,omitempty"` // Muted in triage views until then, see SnoozeResource
+	Interval     time.Duration `json:"interval,omitempty"`       // spec.interval
+	LastReconcile time.Time   `json:"last_reconcile,omitempty"` // Latest reconcile known from status
+	NextReconcile time.Time   `json:"next_reconcile,omitempty"` // Estimated from LastReconcile and Interval, zero when suspended or unknown
+	Overdue      bool         `json:"overdue,omitempty"`        // No reconcile for longer than interval plus the overdue margin
+	Stalled      bool         `json:"stalled,omitempty"`        // Stalled=True: the controller stopped retrying until the spec changes
+	Reconciling  bool         `json:"reconciling,omitempty"`    // Reconciling=True: a reconcile is in progress
+	ReadySince   time.Time    `json:"ready_since,omitempty"`    // When the Ready condition last changed, i.e. entered its current state
+}
+
+// SourceReference is the source a Kustomization gets its artifact from, with
+// the namespace defaulted to the Kustomization's own
+type SourceReference struct {
+	Kind      string `json:"kind"` // GitRepository, OCIRepository or Bucket
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// ChartSource describes where a HelmRelease gets its chart from
+type ChartSource struct {
+	Kind              string `json:"kind"` // HelmRepository, GitRepository, Bucket, OCIRepository or HelmChart
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	ChartRef          bool   `json:"chart_ref,omitempty"` // Set via spec.chartRef instead of a spec.chart template
+	AttemptedRevision string `json:"attempted_revision,omitempty"`
+	HelmChart         string `json:"helm_chart,omitempty"` // The HelmChart generated for a spec.chart template
+}
+
+// HelmSnapshot is one Helm release revision in a HelmRelease's history
+type HelmSnapshot struct {
+	Revision     int       `json:"revision"`
+	Status       string    `json:"status"` // deployed, superseded, failed, uninstalled...
+	ChartVersion string    `json:"chart_version"`
+	AppVersion   string    `json:"app_version,omitempty"`
+	Deployed     time.Time `json:"deployed"`
+	Rollback     bool      `json:"rollback,omitempty"` // Brought back an older revision's chart and values after a failure
 }
 
 // Condition represents a status condition
@@ -55,6 +161,167 @@ type Condition struct {
 	LastTransitionTime time.Time `json:"lastTransitionTime"`
 }
 
+// sourceFailureConditions are the source conditions that carry a more specific
+// error than the Ready condition, in order of precedence
+var sourceFailureConditions = []string{"FetchFailed", "StorageOperationFailed"}
+
+// DisplayMessage returns the message to show in summaries, preferring a
+// source's fetch error over the generic Ready message
+func (r Resource) DisplayMessage() string {
+	if r.FetchError != "" {
+		return r.FetchError
+	}
+	return r.Message
+}
+
+// setArtifact records the revision, digest and size of a source's artifact
+func (r *Resource) setArtifact(artifact *sourcev1.Artifact) {
+	if artifact == nil {
+		return
+	}
+	r.Revision = artifact.Revision
+	r.Digest = artifact.Digest
+	if artifact.Size != nil {
+		r.Size = *artifact.Size
+	}
+}
+
+// setFetchError records the first active source failure condition, and
+// whether the source failed to authenticate
+func (r *Resource) setFetchError() {
+	for _, cond := range r.Conditions {
+		active := cond.Status == string(metav1.ConditionTrue)
+		if cond.Type == "Ready" {
+			active = cond.Status == string(metav1.ConditionFalse)
+		}
+		if active && cond.Reason == sourcev1.AuthenticationFailedReason {
+			r.AuthFailed = true
+		}
+	}
+
+	for _, conditionType := range sourceFailureConditions {
+		for _, cond := range r.Conditions {
+			if cond.Type == conditionType && cond.Status == string(metav1.ConditionTrue) {
+				r.FetchError = fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
+				return
+			}
+		}
+	}
+}
+
+// DecryptFailedStatus is the Status of a Kustomization whose build failed to
+// decrypt its SOPS secrets
+const DecryptFailedStatus = "DecryptFailed"
+
+// decryptMarker identifies decryption errors, which kustomize-controller only
+// reports as a generic BuildFailed with a "decryption failed" or "failed to
+// decrypt" message. Mentions of SOPS alone, e.g. in a path, don't count.
+const decryptMarker = "decrypt"
+
+// setDecryptFailure flags a failed Ready condition caused by decryption, so it
+// isn't mistaken for a broken kustomization
+func (r *Resource) setDecryptFailure() {
+	for _, cond := range r.Conditions {
+		if cond.Type != "Ready" || cond.Status != string(metav1.ConditionFalse) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(cond.Message), decryptMarker) {
+			r.DecryptFailed = true
+			r.Status = DecryptFailedStatus
+			return
+		}
+	}
+}
+
+// setProgress records the Stalled and Reconciling conditions, and since when
+// the resource has been in its current Ready state
+func (r *Resource) setProgress() {
+	for _, cond := range r.Conditions {
+		active := cond.Status == string(metav1.ConditionTrue)
+		switch cond.Type {
+		case "Stalled":
+			r.Stalled = active
+		case "Reconciling":
+			r.Reconciling = active
+		case "Ready":
+			r.ReadySince = cond.LastTransitionTime
+		}
+	}
+}
+
+// newObject returns an empty typed object for the given resource type
+func newObject(resourceType ResourceType) (client.Object, error) {
+	switch resourceType {
+	case ResourceTypeGitRepository:
+		return &sourcev1.GitRepository{}, nil
+	case ResourceTypeHelmRepository:
+		return &sourcev1beta2.HelmRepository{}, nil
+	case ResourceTypeKustomization:
+		return &kustomizev1.Kustomization{}, nil
+	case ResourceTypeHelmRelease:
+		return &helmv2.HelmRelease{}, nil
+	case ResourceTypeOCIRepository:
+		return &sourcev1beta2.OCIRepository{}, nil
+	case ResourceTypeBucket:
+		return &sourcev1beta2.Bucket{}, nil
+	case ResourceTypeHelmChart:
+		return &sourcev1.HelmChart{}, nil
+	default:
+		if isUnstructured(resourceType) {
+			return newUnstructuredObject(resourceType), nil
+		}
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
+
+// newObjectList returns an empty typed list for the given resource type
+func newObjectList(resourceType ResourceType) (client.ObjectList, error) {
+	switch resourceType {
+	case ResourceTypeGitRepository:
+		return &sourcev1.GitRepositoryList{}, nil
+	case ResourceTypeHelmRepository:
+		return &sourcev1beta2.HelmRepositoryList{}, nil
+	case ResourceTypeKustomization:
+		return &kustomizev1.KustomizationList{}, nil
+	case ResourceTypeHelmRelease:
+		return &helmv2.HelmReleaseList{}, nil
+	case ResourceTypeOCIRepository:
+		return &sourcev1beta2.OCIRepositoryList{}, nil
+	case ResourceTypeBucket:
+		return &sourcev1beta2.BucketList{}, nil
+	default:
+		if isUnstructured(resourceType) {
+			return newUnstructuredList(resourceType), nil
+		}
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
+
+// crdMissingMessages identify errors about kinds the API server doesn't
+// serve that have lost their type, e.g. when wrapped as plain strings
+var crdMissingMessages = []string{
+	"no matches for kind",
+	"could not find the requested resource",
+}
+
+// isCRDMissing reports whether an error means the kind's CRD is not
+// installed. Typed errors are checked first, the messages only as a fallback.
+func isCRDMissing(err error) bool {
+	if err == nil {
+		return false
+	}
+	if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+		return true
+	}
+	message := err.Error()
+	for _, missing := range crdMissingMessages {
+		if strings.Contains(message, missing) {
+			return true
+		}
+	}
+	return false
+}
+
 // safeList wraps client.List with panic recovery
 func (c *Client) safeList(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
 	defer func() {
@@ -63,13 +330,19 @@ func (c *Client) safeList(ctx context.Context, list client.ObjectList, opts ...c
 		}
 	}()
 	
-	return c.List(ctx, list, opts...)
+	reader, cached := c.reader(list)
+	if cached {
+		// The cache holds every object already and doesn't page
+		c.setTruncated(list, false)
+		return reader.List(ctx, list, opts...)
+	}
+	return c.listPages(ctx, reader, list, opts...)
 }
 
 // ListGitRepositories lists all GitRepository resources
-func (c *Client) ListGitRepositories(ctx context.Context, namespace string) ([]Resource, error) {
+func (c *Client) ListGitRepositories(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
 	var gitRepos sourcev1.GitRepositoryList
-	opts := []client.ListOption{}
+	opts := NewListOptions(options...).clientOptions()
 	if namespace != "" {
 		// Additional safety check for namespace parameter
 		if len(namespace) > 0 && namespace != "<nil>" {
@@ -78,68 +351,95 @@ func (c *Client) ListGitRepositories(ctx context.Context, namespace string) ([]R
 	}
 
 	if err := c.safeList(ctx, &gitRepos, opts...); err != nil {
-		// Check if this is a "no matches for kind" error by looking at the error string
-		errStr := ""
-		if err != nil {
-			errStr = err.Error()
+		if isCRDMissing(err) {
+			// CRD not available, return empty list
+			return []Resource{}, nil
 		}
+		return nil, fmt.Errorf("failed to list GitRepositories: %w", err)
+	}
 
-		isCRDMissing := client.IgnoreNotFound(err) == nil ||
-			(errStr != "" && (
-				strings.Contains(errStr, "no matches for kind") ||
-				strings.Contains(errStr, "could not find the requested resource") ||
-				strings.Contains(errStr, "the server could not find the requested resource")))
+	resources := make([]Resource, 0, len(gitRepos.Items))
+	for i := range gitRepos.Items {
+		resources = append(resources, c.gitRepositoryResource(&gitRepos.Items[i]))
+	}
+
+	return resources, nil
+}
 
-		if isCRDMissing {
+
+// ListOCIRepositories lists all OCIRepository resources
+func (c *Client) ListOCIRepositories(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	var ociRepos sourcev1beta2.OCIRepositoryList
+	opts := NewListOptions(options...).clientOptions()
+	if namespace != "" {
+		// Additional safety check for namespace parameter
+		if len(namespace) > 0 && namespace != "<nil>" {
+			opts = append(opts, client.InNamespace(namespace))
+		}
+	}
+
+	if err := c.safeList(ctx, &ociRepos, opts...); err != nil {
+		if isCRDMissing(err) {
 			// CRD not available, return empty list
 			return []Resource{}, nil
 		}
-		return nil, fmt.Errorf("failed to list GitRepositories: %w", err)
+		return nil, fmt.Errorf("failed to list OCIRepositories: %w", err)
 	}
 
-	resources := make([]Resource, 0, len(gitRepos.Items))
-	for _, repo := range gitRepos.Items {
-		resource := Resource{
-			Type:       ResourceTypeGitRepository,
-			Name:       repo.Name,
-			Namespace:  repo.Namespace,
-			Age:        time.Since(repo.CreationTimestamp.Time),
-			LastUpdate: time.Now(),
-			Suspended:  repo.Spec.Suspend,
-			URL:        repo.Spec.URL,
-		}
-
-		// Parse status
-		if repo.Status.Conditions != nil {
-			for _, cond := range repo.Status.Conditions {
-				resource.Conditions = append(resource.Conditions, Condition{
-					Type:               cond.Type,
-					Status:             string(cond.Status),
-					Reason:             cond.Reason,
-					Message:            cond.Message,
-					LastTransitionTime: cond.LastTransitionTime.Time,
-				})
-
-				if cond.Type == "Ready" {
-					resource.Ready = cond.Status == metav1.ConditionTrue
-					resource.Status = cond.Reason
-					resource.Message = cond.Message
-				}
-			}
+	resources := make([]Resource, 0, len(ociRepos.Items))
+	for i := range ociRepos.Items {
+		resources = append(resources, c.ociRepositoryResource(&ociRepos.Items[i]))
+	}
+
+	return resources, nil
+}
+
+// ListBuckets lists all Bucket resources
+func (c *Client) ListBuckets(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
+	var buckets sourcev1beta2.BucketList
+	opts := NewListOptions(options...).clientOptions()
+	if namespace != "" {
+		// Additional safety check for namespace parameter
+		if len(namespace) > 0 && namespace != "<nil>" {
+			opts = append(opts, client.InNamespace(namespace))
 		}
+	}
 
-		if repo.Status.Artifact != nil {
-			resource.Revision = repo.Status.Artifact.Revision
+	if err := c.safeList(ctx, &buckets, opts...); err != nil {
+		if isCRDMissing(err) {
+			// CRD not available, return empty list
+			return []Resource{}, nil
 		}
+		return nil, fmt.Errorf("failed to list Buckets: %w", err)
+	}
 
-		resources = append(resources, resource)
+	resources := make([]Resource, 0, len(buckets.Items))
+	for i := range buckets.Items {
+		resources = append(resources, c.bucketResource(&buckets.Items[i]))
 	}
 
 	return resources, nil
 }
 
+// ociReference renders the artifact an OCIRepository follows, in the order of
+// precedence source-controller applies: digest, then semver, then tag
+func ociReference(ref *sourcev1beta2.OCIRepositoryRef) string {
+	switch {
+	case ref == nil:
+		return "latest"
+	case ref.Digest != "":
+		return ref.Digest
+	case ref.SemVer != "":
+		return ref.SemVer
+	case ref.Tag != "":
+		return ref.Tag
+	default:
+		return "latest"
+	}
+}
+
 // ListHelmRepositories lists all HelmRepository resources
-func (c *Client) ListHelmRepositories(ctx context.Context, namespace string) ([]Resource, error) {
+func (c *Client) ListHelmRepositories(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
 	// Add safety checks
 	if c == nil {
 		return nil, fmt.Errorf("kubernetes client is nil")
@@ -151,7 +451,7 @@ func (c *Client) ListHelmRepositories(ctx context.Context, namespace string) ([]
 		return nil, fmt.Errorf("context is nil")
 	}
 
-	opts := []client.ListOption{}
+	opts := NewListOptions(options...).clientOptions()
 	if namespace != "" {
 		// Additional safety check for namespace parameter
 		if len(namespace) > 0 && namespace != "<nil>" {
@@ -167,34 +467,11 @@ func (c *Client) ListHelmRepositories(ctx context.Context, namespace string) ([]
 	listErr = c.safeList(ctx, &helmRepos, opts...)
 
 	if listErr != nil {
-		// Check if this is a "no matches for kind" error by looking at the error string
-		errStr := ""
-		if listErr != nil {
-			errStr = listErr.Error()
-		}
-
-		isCRDMissing := client.IgnoreNotFound(listErr) == nil ||
-			(errStr != "" && (
-				strings.Contains(errStr, "no matches for kind") ||
-				strings.Contains(errStr, "could not find the requested resource") ||
-				strings.Contains(errStr, "the server could not find the requested resource")))
-
-		if isCRDMissing {
+		if isCRDMissing(listErr) {
 			// Resource not found or CRD not available - try v1 fallback
 			var helmReposV1 sourcev1.HelmRepositoryList
 			if errV1 := c.safeList(ctx, &helmReposV1, opts...); errV1 != nil {
-				errV1Str := ""
-				if errV1 != nil {
-					errV1Str = errV1.Error()
-				}
-
-				isV1CRDMissing := client.IgnoreNotFound(errV1) == nil ||
-					(errV1Str != "" && (
-						strings.Contains(errV1Str, "no matches for kind") ||
-						strings.Contains(errV1Str, "could not find the requested resource") ||
-						strings.Contains(errV1Str, "the server could not find the requested resource")))
-
-				if isV1CRDMissing {
+				if isCRDMissing(errV1) {
 					// Both v1beta2 and v1 are not available, return empty list
 					return []Resource{}, nil
 				}
@@ -204,38 +481,8 @@ func (c *Client) ListHelmRepositories(ctx context.Context, namespace string) ([]
 
 			// Convert v1 results to our format
 			resources := make([]Resource, 0, len(helmReposV1.Items))
-			for _, repo := range helmReposV1.Items {
-				resource := Resource{
-					Type:       ResourceTypeHelmRepository,
-					Name:       repo.Name,
-					Namespace:  repo.Namespace,
-					Age:        time.Since(repo.CreationTimestamp.Time),
-					LastUpdate: time.Now(),
-					Suspended:  repo.Spec.Suspend,
-					URL:        repo.Spec.URL,
-				}
-
-				// Parse status (v1 format)
-				if repo.Status.Conditions != nil {
-					for _, cond := range repo.Status.Conditions {
-						resource.Conditions = append(resource.Conditions, Condition{
-							Type:               cond.Type,
-							Status:             string(cond.Status),
-							Reason:             cond.Reason,
-							Message:            cond.Message,
-							LastTransitionTime: cond.LastTransitionTime.Time,
-						})
-					}
-				}
-
-				if len(repo.Status.Conditions) > 0 {
-					lastCond := repo.Status.Conditions[len(repo.Status.Conditions)-1]
-					resource.Status = string(lastCond.Status)
-					resource.Message = lastCond.Message
-					resource.Ready = lastCond.Status == metav1.ConditionTrue
-				}
-
-				resources = append(resources, resource)
+			for i := range helmReposV1.Items {
+				resources = append(resources, c.helmRepositoryV1Resource(&helmReposV1.Items[i]))
 			}
 			return resources, nil
 		}
@@ -246,47 +493,17 @@ func (c *Client) ListHelmRepositories(ctx context.Context, namespace string) ([]
 
 	// Process v1beta2 results normally
 	resources := make([]Resource, 0, len(helmRepos.Items))
-	for _, repo := range helmRepos.Items {
-		resource := Resource{
-			Type:       ResourceTypeHelmRepository,
-			Name:       repo.Name,
-			Namespace:  repo.Namespace,
-			Age:        time.Since(repo.CreationTimestamp.Time),
-			LastUpdate: time.Now(),
-			Suspended:  repo.Spec.Suspend,
-			URL:        repo.Spec.URL,
-		}
-
-		// Parse status (v1beta2 format)
-		if repo.Status.Conditions != nil {
-			for _, cond := range repo.Status.Conditions {
-				resource.Conditions = append(resource.Conditions, Condition{
-					Type:               cond.Type,
-					Status:             string(cond.Status),
-					Reason:             cond.Reason,
-					Message:            cond.Message,
-					LastTransitionTime: cond.LastTransitionTime.Time,
-				})
-			}
-		}
-
-		if len(repo.Status.Conditions) > 0 {
-			lastCond := repo.Status.Conditions[len(repo.Status.Conditions)-1]
-			resource.Status = string(lastCond.Status)
-			resource.Message = lastCond.Message
-			resource.Ready = lastCond.Status == metav1.ConditionTrue
-		}
-
-		resources = append(resources, resource)
+	for i := range helmRepos.Items {
+		resources = append(resources, c.helmRepositoryResource(&helmRepos.Items[i]))
 	}
 
 	return resources, nil
 }
 
 // ListKustomizations lists all Kustomization resources
-func (c *Client) ListKustomizations(ctx context.Context, namespace string) ([]Resource, error) {
+func (c *Client) ListKustomizations(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
 	var kustomizations kustomizev1.KustomizationList
-	opts := []client.ListOption{}
+	opts := NewListOptions(options...).clientOptions()
 	if namespace != "" {
 		// Additional safety check for namespace parameter
 		if len(namespace) > 0 && namespace != "<nil>" {
@@ -295,19 +512,7 @@ func (c *Client) ListKustomizations(ctx context.Context, namespace string) ([]Re
 	}
 
 	if err := c.safeList(ctx, &kustomizations, opts...); err != nil {
-		// Check if this is a "no matches for kind" error by looking at the error string
-		errStr := ""
-		if err != nil {
-			errStr = err.Error()
-		}
-
-		isCRDMissing := client.IgnoreNotFound(err) == nil ||
-			(errStr != "" && (
-				strings.Contains(errStr, "no matches for kind") ||
-				strings.Contains(errStr, "could not find the requested resource") ||
-				strings.Contains(errStr, "the server could not find the requested resource")))
-
-		if isCRDMissing {
+		if isCRDMissing(err) {
 			// CRD not available, return empty list
 			return []Resource{}, nil
 		}
@@ -315,54 +520,17 @@ func (c *Client) ListKustomizations(ctx context.Context, namespace string) ([]Re
 	}
 
 	resources := make([]Resource, 0, len(kustomizations.Items))
-	for _, ks := range kustomizations.Items {
-		resource := Resource{
-			Type:       ResourceTypeKustomization,
-			Name:       ks.Name,
-			Namespace:  ks.Namespace,
-			Age:        time.Since(ks.CreationTimestamp.Time),
-			LastUpdate: time.Now(),
-			Suspended:  ks.Spec.Suspend,
-			Path:       ks.Spec.Path,
-		}
-
-		if ks.Spec.SourceRef.Kind == "GitRepository" {
-			resource.Source = ks.Spec.SourceRef.Name
-		}
-
-		// Parse status
-		if ks.Status.Conditions != nil {
-			for _, cond := range ks.Status.Conditions {
-				resource.Conditions = append(resource.Conditions, Condition{
-					Type:               cond.Type,
-					Status:             string(cond.Status),
-					Reason:             cond.Reason,
-					Message:            cond.Message,
-					LastTransitionTime: cond.LastTransitionTime.Time,
-				})
-
-				if cond.Type == "Ready" {
-					resource.Ready = cond.Status == metav1.ConditionTrue
-					resource.Status = cond.Reason
-					resource.Message = cond.Message
-				}
-			}
-		}
-
-		if ks.Status.LastAppliedRevision != "" {
-			resource.Revision = ks.Status.LastAppliedRevision
-		}
-
-		resources = append(resources, resource)
+	for i := range kustomizations.Items {
+		resources = append(resources, c.kustomizationResource(&kustomizations.Items[i]))
 	}
 
 	return resources, nil
 }
 
 // ListHelmReleases lists all HelmRelease resources
-func (c *Client) ListHelmReleases(ctx context.Context, namespace string) ([]Resource, error) {
+func (c *Client) ListHelmReleases(ctx context.Context, namespace string, options ...ListOption) ([]Resource, error) {
 	var helmReleases helmv2.HelmReleaseList
-	opts := []client.ListOption{}
+	opts := NewListOptions(options...).clientOptions()
 	if namespace != "" {
 		// Additional safety check for namespace parameter
 		if len(namespace) > 0 && namespace != "<nil>" {
@@ -371,19 +539,7 @@ func (c *Client) ListHelmReleases(ctx context.Context, namespace string) ([]Reso
 	}
 
 	if err := c.safeList(ctx, &helmReleases, opts...); err != nil {
-		// Check if this is a "no matches for kind" error by looking at the error string
-		errStr := ""
-		if err != nil {
-			errStr = err.Error()
-		}
-
-		isCRDMissing := client.IgnoreNotFound(err) == nil ||
-			(errStr != "" && (
-				strings.Contains(errStr, "no matches for kind") ||
-				strings.Contains(errStr, "could not find the requested resource") ||
-				strings.Contains(errStr, "the server could not find the requested resource")))
-
-		if isCRDMissing {
+		if isCRDMissing(err) {
 			// CRD not available, return empty list
 			return []Resource{}, nil
 		}
@@ -391,49 +547,496 @@ func (c *Client) ListHelmReleases(ctx context.Context, namespace string) ([]Reso
 	}
 
 	resources := make([]Resource, 0, len(helmReleases.Items))
-	for _, hr := range helmReleases.Items {
-		resource := Resource{
-			Type:       ResourceTypeHelmRelease,
-			Name:       hr.Name,
-			Namespace:  hr.Namespace,
-			Age:        time.Since(hr.CreationTimestamp.Time),
-			LastUpdate: time.Now(),
-			Suspended:  hr.Spec.Suspend,
-			Chart:      hr.Spec.Chart.Spec.Chart,
-			Version:    hr.Spec.Chart.Spec.Version,
-		}
-
-		if hr.Spec.Chart.Spec.SourceRef.Kind == "HelmRepository" {
-			resource.Source = hr.Spec.Chart.Spec.SourceRef.Name
-		}
-
-		// Parse status
-		if hr.Status.Conditions != nil {
-			for _, cond := range hr.Status.Conditions {
-				resource.Conditions = append(resource.Conditions, Condition{
-					Type:               cond.Type,
-					Status:             string(cond.Status),
-					Reason:             cond.Reason,
-					Message:            cond.Message,
-					LastTransitionTime: cond.LastTransitionTime.Time,
-				})
-
-				if cond.Type == "Ready" {
-					resource.Ready = cond.Status == metav1.ConditionTrue
-					resource.Status = cond.Reason
-					resource.Message = cond.Message
+	for i := range helmReleases.Items {
+		resources = append(resources, c.helmReleaseResource(&helmReleases.Items[i]))
+	}
+
+	return resources, nil
+}
+
+// gitRepositoryResource converts a GitRepository into a Resource
+func (c *Client) gitRepositoryResource(repo *sourcev1.GitRepository) Resource {
+	resource := Resource{
+		Type:       ResourceTypeGitRepository,
+		Name:       repo.Name,
+		Namespace:  repo.Namespace,
+		Labels:     repo.Labels,
+		UID:        string(repo.UID),
+		SnoozedUntil: snoozedUntil(repo.Annotations),
+		Age:        time.Since(repo.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  repo.Spec.Suspend,
+		URL:        repo.Spec.URL,
+	}
+
+	// Parse status
+	if repo.Status.Conditions != nil {
+		for _, cond := range repo.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+
+			if cond.Type == "Ready" {
+				resource.Ready = cond.Status == metav1.ConditionTrue
+				resource.Status = readyStatus(cond.Status, cond.Reason)
+				resource.Message = cond.Message
+			}
+		}
+	}
+
+	resource.setArtifact(repo.Status.Artifact)
+	resource.setFetchError()
+	if repo.Spec.SecretRef != nil {
+		resource.SecretRef = repo.Spec.SecretRef.Name
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, repo, repo.Spec.Interval.Duration, repo.Status.LastHandledReconcileAt, repo.Status.ObservedGeneration)
+
+	return resource
+}
+
+// ociRepositoryResource converts an OCIRepository into a Resource
+func (c *Client) ociRepositoryResource(repo *sourcev1beta2.OCIRepository) Resource {
+	resource := Resource{
+		Type:       ResourceTypeOCIRepository,
+		Name:       repo.Name,
+		Namespace:  repo.Namespace,
+		Labels:     repo.Labels,
+		UID:        string(repo.UID),
+		SnoozedUntil: snoozedUntil(repo.Annotations),
+		Age:        time.Since(repo.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  repo.Spec.Suspend,
+		URL:        repo.Spec.URL,
+		Ref:        ociReference(repo.Spec.Reference),
+	}
+
+	// Parse status
+	if repo.Status.Conditions != nil {
+		for _, cond := range repo.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+
+			if cond.Type == "Ready" {
+				resource.Ready = cond.Status == metav1.ConditionTrue
+				resource.Status = readyStatus(cond.Status, cond.Reason)
+				resource.Message = cond.Message
+			}
+		}
+	}
+
+	resource.setArtifact(repo.Status.Artifact)
+	resource.setFetchError()
+	if repo.Spec.SecretRef != nil {
+		resource.SecretRef = repo.Spec.SecretRef.Name
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, repo, repo.Spec.Interval.Duration, repo.Status.LastHandledReconcileAt, repo.Status.ObservedGeneration)
+
+	return resource
+}
+
+// bucketResource converts a Bucket into a Resource
+func (c *Client) bucketResource(bucket *sourcev1beta2.Bucket) Resource {
+	resource := Resource{
+		Type:       ResourceTypeBucket,
+		Name:       bucket.Name,
+		Namespace:  bucket.Namespace,
+		Labels:     bucket.Labels,
+		UID:        string(bucket.UID),
+		SnoozedUntil: snoozedUntil(bucket.Annotations),
+		Age:        time.Since(bucket.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  bucket.Spec.Suspend,
+		URL:        bucket.Spec.Endpoint,
+		Source:     bucket.Spec.BucketName,
+		Path:       bucket.Spec.Prefix,
+		Provider:   bucket.Spec.Provider,
+	}
+
+	// Parse status
+	if bucket.Status.Conditions != nil {
+		for _, cond := range bucket.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+
+			if cond.Type == "Ready" {
+				resource.Ready = cond.Status == metav1.ConditionTrue
+				resource.Status = readyStatus(cond.Status, cond.Reason)
+				resource.Message = cond.Message
+			}
+		}
+	}
+
+	resource.setArtifact(bucket.Status.Artifact)
+	resource.setFetchError()
+	if bucket.Spec.SecretRef != nil {
+		resource.SecretRef = bucket.Spec.SecretRef.Name
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, bucket, bucket.Spec.Interval.Duration, bucket.Status.LastHandledReconcileAt, bucket.Status.ObservedGeneration)
+
+	return resource
+}
+
+// helmRepositoryV1Resource converts a v1 HelmRepository into a Resource
+func (c *Client) helmRepositoryV1Resource(repo *sourcev1.HelmRepository) Resource {
+	resource := Resource{
+		Type:       ResourceTypeHelmRepository,
+		Name:       repo.Name,
+		Namespace:  repo.Namespace,
+		Labels:     repo.Labels,
+		UID:        string(repo.UID),
+		SnoozedUntil: snoozedUntil(repo.Annotations),
+		Age:        time.Since(repo.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  repo.Spec.Suspend,
+		URL:        repo.Spec.URL,
+	}
+
+	// Parse status (v1 format)
+	if repo.Status.Conditions != nil {
+		for _, cond := range repo.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+		}
+	}
+
+	if len(repo.Status.Conditions) > 0 {
+		lastCond := repo.Status.Conditions[len(repo.Status.Conditions)-1]
+		resource.Status = string(lastCond.Status)
+		resource.Message = lastCond.Message
+		resource.Ready = lastCond.Status == metav1.ConditionTrue
+	}
+	resource.setFetchError()
+	if repo.Spec.SecretRef != nil {
+		resource.SecretRef = repo.Spec.SecretRef.Name
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, repo, repo.Spec.Interval.Duration, repo.Status.LastHandledReconcileAt, repo.Status.ObservedGeneration)
+
+	return resource
+}
+
+// helmRepositoryResource converts a HelmRepository into a Resource
+func (c *Client) helmRepositoryResource(repo *sourcev1beta2.HelmRepository) Resource {
+	resource := Resource{
+		Type:       ResourceTypeHelmRepository,
+		Name:       repo.Name,
+		Namespace:  repo.Namespace,
+		Labels:     repo.Labels,
+		UID:        string(repo.UID),
+		SnoozedUntil: snoozedUntil(repo.Annotations),
+		Age:        time.Since(repo.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  repo.Spec.Suspend,
+		URL:        repo.Spec.URL,
+	}
+
+	// Parse status (v1beta2 format)
+	if repo.Status.Conditions != nil {
+		for _, cond := range repo.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+		}
+	}
+
+	if len(repo.Status.Conditions) > 0 {
+		lastCond := repo.Status.Conditions[len(repo.Status.Conditions)-1]
+		resource.Status = string(lastCond.Status)
+		resource.Message = lastCond.Message
+		resource.Ready = lastCond.Status == metav1.ConditionTrue
+	}
+	resource.setFetchError()
+	if repo.Spec.SecretRef != nil {
+		resource.SecretRef = repo.Spec.SecretRef.Name
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, repo, repo.Spec.Interval.Duration, repo.Status.LastHandledReconcileAt, repo.Status.ObservedGeneration)
+
+	return resource
+}
+
+// kustomizationResource converts a Kustomization into a Resource
+func (c *Client) kustomizationResource(ks *kustomizev1.Kustomization) Resource {
+	resource := Resource{
+		Type:       ResourceTypeKustomization,
+		Name:       ks.Name,
+		Namespace:  ks.Namespace,
+		Labels:     ks.Labels,
+		UID:        string(ks.UID),
+		SnoozedUntil: snoozedUntil(ks.Annotations),
+		Age:        time.Since(ks.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  ks.Spec.Suspend,
+		Path:       ks.Spec.Path,
+	}
+
+	ref := ks.Spec.SourceRef
+	resource.Source = sourceLabel(ref.Kind, ref.Name, ref.Namespace, ks.Namespace)
+	if ref.Name != "" {
+		resource.SourceRef = &SourceReference{Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace}
+		if resource.SourceRef.Namespace == "" {
+			resource.SourceRef.Namespace = ks.Namespace
+		}
+	}
+
+	// Parse status
+	if ks.Status.Conditions != nil {
+		for _, cond := range ks.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+
+			if cond.Type == "Ready" {
+				resource.Ready = cond.Status == metav1.ConditionTrue
+				resource.Status = readyStatus(cond.Status, cond.Reason)
+				resource.Message = cond.Message
+			}
+		}
+	}
+
+	if ks.Status.LastAppliedRevision != "" {
+		resource.Revision = ks.Status.LastAppliedRevision
+	}
+	if ks.Status.Inventory != nil {
+		resource.InventoryCount = len(ks.Status.Inventory.Entries)
+	}
+	resource.setDecryptFailure()
+
+	resource.setProgress()
+	c.markOverdue(&resource, ks, ks.Spec.Interval.Duration, ks.Status.LastHandledReconcileAt, ks.Status.ObservedGeneration)
+
+	return resource
+}
+
+// helmReleaseResource converts a HelmRelease into a Resource
+func (c *Client) helmReleaseResource(hr *helmv2.HelmRelease) Resource {
+	resource := Resource{
+		Type:       ResourceTypeHelmRelease,
+		Name:       hr.Name,
+		Namespace:  hr.Namespace,
+		Labels:     hr.Labels,
+		UID:        string(hr.UID),
+		SnoozedUntil: snoozedUntil(hr.Annotations),
+		Age:        time.Since(hr.CreationTimestamp.Time),
+		LastUpdate: time.Now(),
+		Suspended:  hr.Spec.Suspend,
+	}
+
+	source := &ChartSource{
+		AttemptedRevision: hr.Status.LastAttemptedRevision,
+		HelmChart:         hr.Status.HelmChart,
+	}
+	switch {
+	case hr.Spec.ChartRef != nil:
+		source.ChartRef = true
+		source.Kind = hr.Spec.ChartRef.Kind
+		source.Name = hr.Spec.ChartRef.Name
+		source.Namespace = hr.Spec.ChartRef.Namespace
+		// The chart is only named by the artifact, which the latest release
+		// records; before the first install the source's name is the best guess
+		if latest := hr.Status.History.Latest(); latest != nil {
+			resource.Chart = latest.ChartName
+			resource.Version = latest.ChartVersion
+		} else {
+			resource.Chart = hr.Spec.ChartRef.Name
+		}
+	case hr.Spec.Chart != nil:
+		resource.Chart = hr.Spec.Chart.Spec.Chart
+		resource.Version = hr.Spec.Chart.Spec.Version
+		source.Kind = hr.Spec.Chart.Spec.SourceRef.Kind
+		source.Name = hr.Spec.Chart.Spec.SourceRef.Name
+		source.Namespace = hr.Spec.Chart.Spec.SourceRef.Namespace
+	}
+	if source.Namespace == "" {
+		source.Namespace = hr.Namespace
+	}
+	resource.ChartSource = source
+
+	resource.Source = sourceLabel(source.Kind, source.Name, source.Namespace, hr.Namespace)
+
+	// Parse status
+	if hr.Status.Conditions != nil {
+		for _, cond := range hr.Status.Conditions {
+			resource.Conditions = append(resource.Conditions, Condition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+
+			if cond.Type == "Ready" {
+				resource.Ready = cond.Status == metav1.ConditionTrue
+				resource.Status = readyStatus(cond.Status, cond.Reason)
+				resource.Message = cond.Message
+			}
+		}
+	}
+
+	if hr.Status.LastAppliedRevision != "" {
+		resource.Revision = hr.Status.LastAppliedRevision
+	}
+	resource.History = helmHistory(hr)
+	resource.HelmRevision = hr.Status.LastReleaseRevision
+	if resource.HelmRevision == 0 && len(resource.History) > 0 {
+		resource.HelmRevision = resource.History[0].Revision
+	}
+
+	resource.setProgress()
+	c.markOverdue(&resource, hr, hr.Spec.Interval.Duration, hr.Status.LastHandledReconcileAt, hr.Status.ObservedGeneration)
+
+	return resource
+}
+
+// helmHistory converts the release history of a HelmRelease, newest first.
+// A release following a failed one is a rollback when it matches the chart
+// version and values of an older release.
+func helmHistory(hr *helmv2.HelmRelease) []HelmSnapshot {
+	snapshots := slices.Clone(hr.Status.History)
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Version > snapshots[j].Version })
+
+	history := make([]HelmSnapshot, 0, len(snapshots))
+	for i, snapshot := range snapshots {
+		entry := HelmSnapshot{
+			Revision:     snapshot.Version,
+			Status:       snapshot.Status,
+			ChartVersion: snapshot.ChartVersion,
+			AppVersion:   snapshot.AppVersion,
+			Deployed:     snapshot.LastDeployed.Time,
+		}
+		if i+1 < len(snapshots) && snapshots[i+1].Status == "failed" {
+			for _, older := range snapshots[i+2:] {
+				if older.ChartVersion == snapshot.ChartVersion && older.ConfigDigest == snapshot.ConfigDigest {
+					entry.Rollback = true
+					break
 				}
 			}
 		}
+		history = append(history, entry)
+	}
+	if len(history) == 0 {
+		return nil
+	}
+	return history
+}
 
-		if hr.Status.LastAppliedRevision != "" {
-			resource.Revision = hr.Status.LastAppliedRevision
+// GetResource fetches a single resource, converted exactly as the list
+// methods convert it. A missing object returns an error satisfying
+// apierrors.IsNotFound.
+func (c *Client) GetResource(ctx context.Context, resourceType ResourceType, name, namespace string) (*Resource, error) {
+	obj, err := c.fetchObject(ctx, resourceType, name, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%s %s/%s not found: %w", resourceType, namespace, name, err)
 		}
+		return nil, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+	}
 
-		resources = append(resources, resource)
+	resource := c.objectResource(resourceType, obj)
+	return &resource, nil
+}
+
+// objectResource converts an object returned by fetchObject into a Resource
+func (c *Client) objectResource(resourceType ResourceType, obj client.Object) Resource {
+	switch o := obj.(type) {
+	case *sourcev1.GitRepository:
+		return c.gitRepositoryResource(o)
+	case *sourcev1beta2.HelmRepository:
+		return c.helmRepositoryResource(o)
+	case *sourcev1.HelmRepository:
+		return c.helmRepositoryV1Resource(o)
+	case *kustomizev1.Kustomization:
+		return c.kustomizationResource(o)
+	case *helmv2.HelmRelease:
+		return c.helmReleaseResource(o)
+	case *sourcev1beta2.OCIRepository:
+		return c.ociRepositoryResource(o)
+	case *sourcev1beta2.Bucket:
+		return c.bucketResource(o)
 	}
 
-	return resources, nil
+	u := obj.(*unstructured.Unstructured)
+	switch resourceType {
+	case ResourceTypeImageRepository:
+		return c.imageRepositoryResource(u)
+	case ResourceTypeImagePolicy:
+		return c.imagePolicyResource(u)
+	case ResourceTypeImageUpdateAutomation:
+		return c.imageUpdateAutomationResource(u)
+	case ResourceTypeAlert:
+		return c.alertResource(u)
+	case ResourceTypeProvider:
+		return c.providerResource(u)
+	default:
+		return c.receiverResource(u)
+	}
+}
+
+// CountResources estimates the number of resources of a type without listing them all.
+// It fetches a single item and reads the server-provided remaining item count. The
+// returned count is -1 when the server does not report one.
+func (c *Client) CountResources(ctx context.Context, resourceType ResourceType, namespace string) (int64, error) {
+	list, err := newObjectList(resourceType)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := []client.ListOption{client.Limit(1)}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	if err := c.safeList(ctx, list, opts...); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", resourceType, err)
+	}
+
+	items := int64(meta.LenList(list))
+	listMeta, ok := list.(metav1.ListInterface)
+	if !ok {
+		return items, nil
+	}
+	if remaining := listMeta.GetRemainingItemCount(); remaining != nil {
+		return items + *remaining, nil
+	}
+	if listMeta.GetContinue() != "" {
+		return -1, nil
+	}
+	return items, nil
 }
 
 // SuspendResource suspends a FluxCD resource
@@ -448,6 +1051,10 @@ func (c *Client) ResumeResource(ctx context.Context, resourceType ResourceType,
 
 // updateSuspendStatus updates the suspend status of a resource
 func (c *Client) updateSuspendStatus(ctx context.Context, resourceType ResourceType, name, namespace string, suspend bool) error {
+	if err := checkAction(resourceType, ActionSuspend); err != nil {
+		return err
+	}
+
 	var obj client.Object
 
 	switch resourceType {
@@ -459,8 +1066,15 @@ func (c *Client) updateSuspendStatus(ctx context.Context, resourceType ResourceT
 		obj = &kustomizev1.Kustomization{}
 	case ResourceTypeHelmRelease:
 		obj = &helmv2.HelmRelease{}
+	case ResourceTypeOCIRepository:
+		obj = &sourcev1beta2.OCIRepository{}
+	case ResourceTypeBucket:
+		obj = &sourcev1beta2.Bucket{}
 	default:
-		return fmt.Errorf("unsupported resource type: %s", resourceType)
+		if !isUnstructured(resourceType) {
+			return fmt.Errorf("unsupported resource type: %s", resourceType)
+		}
+		obj = newUnstructuredObject(resourceType)
 	}
 
 	key := types.NamespacedName{Name: name, Namespace: namespace}
@@ -482,6 +1096,17 @@ func (c *Client) updateSuspendStatus(ctx context.Context, resourceType ResourceT
 	case ResourceTypeHelmRelease:
 		hr := obj.(*helmv2.HelmRelease)
 		hr.Spec.Suspend = suspend
+	case ResourceTypeOCIRepository:
+		repo := obj.(*sourcev1beta2.OCIRepository)
+		repo.Spec.Suspend = suspend
+	case ResourceTypeBucket:
+		bucket := obj.(*sourcev1beta2.Bucket)
+		bucket.Spec.Suspend = suspend
+	default:
+		u := obj.(*unstructured.Unstructured)
+		if err := unstructured.SetNestedField(u.Object, suspend, "spec", "suspend"); err != nil {
+			return fmt.Errorf("failed to set suspend on %s/%s: %w", resourceType, name, err)
+		}
 	}
 
 	if err := c.Update(ctx, obj); err != nil {
@@ -491,8 +1116,51 @@ func (c *Client) updateSuspendStatus(ctx context.Context, resourceType ResourceT
 	return nil
 }
 
+// DeleteResource deletes a FluxCD resource. The object is only removed; the
+// controller's finalizer garbage collects what a Kustomization applied when
+// it has prune enabled, and uninstalls a HelmRelease's release.
+func (c *Client) DeleteResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	if err := checkAction(resourceType, ActionDelete); err != nil {
+		return err
+	}
+
+	var obj client.Object
+
+	switch resourceType {
+	case ResourceTypeGitRepository:
+		obj = &sourcev1.GitRepository{}
+	case ResourceTypeHelmRepository:
+		obj = &sourcev1beta2.HelmRepository{}
+	case ResourceTypeKustomization:
+		obj = &kustomizev1.Kustomization{}
+	case ResourceTypeHelmRelease:
+		obj = &helmv2.HelmRelease{}
+	case ResourceTypeOCIRepository:
+		obj = &sourcev1beta2.OCIRepository{}
+	case ResourceTypeBucket:
+		obj = &sourcev1beta2.Bucket{}
+	default:
+		if !isUnstructured(resourceType) {
+			return fmt.Errorf("unsupported resource type: %s", resourceType)
+		}
+		obj = newUnstructuredObject(resourceType)
+	}
+
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	if err := c.Delete(ctx, obj); err != nil {
+		return fmt.Errorf("failed to delete %s/%s: %w", resourceType, name, err)
+	}
+
+	return nil
+}
+
 // ReconcileResource triggers reconciliation of a FluxCD resource
 func (c *Client) ReconcileResource(ctx context.Context, resourceType ResourceType, name, namespace string) error {
+	if err := checkAction(resourceType, ActionReconcile); err != nil {
+		return err
+	}
+
 	var obj client.Object
 
 	switch resourceType {
@@ -504,8 +1172,17 @@ func (c *Client) ReconcileResource(ctx context.Context, resourceType ResourceTyp
 		obj = &kustomizev1.Kustomization{}
 	case ResourceTypeHelmRelease:
 		obj = &helmv2.HelmRelease{}
+	case ResourceTypeOCIRepository:
+		obj = &sourcev1beta2.OCIRepository{}
+	case ResourceTypeBucket:
+		obj = &sourcev1beta2.Bucket{}
+	case ResourceTypeHelmChart:
+		obj = &sourcev1.HelmChart{}
 	default:
-		return fmt.Errorf("unsupported resource type: %s", resourceType)
+		if !isUnstructured(resourceType) {
+			return fmt.Errorf("unsupported resource type: %s", resourceType)
+		}
+		obj = newUnstructuredObject(resourceType)
 	}
 
 	key := types.NamespacedName{Name: name, Namespace: namespace}
@@ -513,12 +1190,17 @@ func (c *Client) ReconcileResource(ctx context.Context, resourceType ResourceTyp
 		return fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
 	}
 
+	// Don't reset the clock on a request the controller hasn't picked up yet
+	if pending, age := c.reconcilePending(obj); pending {
+		return fmt.Errorf("%s/%s was requested %s ago: %w", resourceType, name, age.Round(time.Second), ErrReconcilePending)
+	}
+
 	// Add reconcile annotation
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
-	annotations["reconcile.fluxcd.io/requestedAt"] = time.Now().UTC().Format(time.RFC3339)
+	requestReconcile(annotations)
 	obj.SetAnnotations(annotations)
 
 	if err := c.Update(ctx, obj); err != nil {
@@ -528,36 +1210,237 @@ func (c *Client) ReconcileResource(ctx context.Context, resourceType ResourceTyp
 	return nil
 }
 
-// GetEvents returns Kubernetes events related to FluxCD resources
+// Reconcile request annotations understood by the Flux controllers
+const (
+	reconcileRequestAnnotation = "reconcile.fluxcd.io/requestedAt"
+	resetRequestAnnotation     = "reconcile.fluxcd.io/resetAt"
+	// Read by controllers predating the reconcile.fluxcd.io annotations
+	legacyReconcileAnnotation = "fluxcd.io/reconcileAt"
+)
+
+// requestReconcile stamps a new reconcile request token on annotations and
+// returns it. The token has nanosecond precision so a second request within
+// the same second still differs from the one the controller last handled.
+func requestReconcile(annotations map[string]string) string {
+	token := time.Now().UTC().Format(time.RFC3339Nano)
+	annotations[reconcileRequestAnnotation] = token
+	annotations[legacyReconcileAnnotation] = token
+	return token
+}
+
+// DefaultReconcileDedupWindow is how long an unhandled reconcile request
+// suppresses new ones
+const DefaultReconcileDedupWindow = 30 * time.Second
+
+// ErrReconcilePending is returned when a recent reconcile request has not been handled yet
+var ErrReconcilePending = errors.New("reconcile already pending")
+
+// reconcilePending reports whether the object carries a reconcile request that
+// is younger than the dedup window and not yet handled by its controller
+func (c *Client) reconcilePending(obj client.Object) (bool, time.Duration) {
+	if c.ReconcileDedupWindow <= 0 {
+		return false, 0
+	}
+
+	requested := obj.GetAnnotations()[reconcileRequestAnnotation]
+	if requested == "" || requested == lastHandledReconcile(obj) {
+		return false, 0
+	}
+
+	// Tokens are usually timestamps; anything else can't be aged and is overwritten.
+	// RFC3339 parsing also accepts the fractional seconds of our own tokens.
+	requestedAt, err := time.Parse(time.RFC3339, requested)
+	if err != nil {
+		return false, 0
+	}
+
+	age := time.Since(requestedAt)
+	return age < c.ReconcileDedupWindow, age
+}
+
+// lastHandledReconcile returns the reconcile request token the controller last handled
+func lastHandledReconcile(obj client.Object) string {
+	switch o := obj.(type) {
+	case *sourcev1.GitRepository:
+		return o.Status.LastHandledReconcileAt
+	case *sourcev1beta2.HelmRepository:
+		return o.Status.LastHandledReconcileAt
+	case *kustomizev1.Kustomization:
+		return o.Status.LastHandledReconcileAt
+	case *helmv2.HelmRelease:
+		return o.Status.LastHandledReconcileAt
+	case *sourcev1beta2.OCIRepository:
+		return o.Status.LastHandledReconcileAt
+	case *sourcev1beta2.Bucket:
+		return o.Status.LastHandledReconcileAt
+	case *sourcev1.HelmChart:
+		return o.Status.LastHandledReconcileAt
+	case *unstructured.Unstructured:
+		return unstructuredLastHandled(o)
+	default:
+		return ""
+	}
+}
+
+// sourceLabel renders a source reference as Kind/name, or Kind/namespace/name
+// when the source lives in another namespace than the referencing resource
+func sourceLabel(kind, name, namespace, ownNamespace string) string {
+	if name == "" {
+		return ""
+	}
+	if namespace != "" && namespace != ownNamespace {
+		return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// readyStatus returns the Status column value of a Ready condition. Some
+// controllers leave the reason empty, so fall back to the condition status
+// rather than rendering a blank or Unknown cell for a healthy resource.
+func readyStatus(status metav1.ConditionStatus, reason string) string {
+	if reason != "" {
+		return reason
+	}
+	switch status {
+	case metav1.ConditionTrue:
+		return "Ready"
+	case metav1.ConditionFalse:
+		return "Failed"
+	}
+	return ""
+}
+
+// ResetHelmRelease resets the remediation retry counters of a HelmRelease so
+// helm-controller retries it from scratch, like flux reconcile hr --reset
+func (c *Client) ResetHelmRelease(ctx context.Context, name, namespace string) error {
+	if err := checkAction(ResourceTypeHelmRelease, ActionReset); err != nil {
+		return err
+	}
+
+	hr := &helmv2.HelmRelease{}
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	if err := c.Get(ctx, key, hr); err != nil {
+		return fmt.Errorf("failed to get %s/%s: %w", ResourceTypeHelmRelease, name, err)
+	}
+
+	// helm-controller only honours the reset when its token matches the reconcile request
+	annotations := hr.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[resetRequestAnnotation] = requestReconcile(annotations)
+	hr.SetAnnotations(annotations)
+
+	if err := c.Update(ctx, hr); err != nil {
+		return fmt.Errorf("failed to update %s/%s: %w", ResourceTypeHelmRelease, name, err)
+	}
+
+	return nil
+}
+
+// EventMatchesResource reports whether an event is about the given resource.
+// The involved object's UID is authoritative when both sides carry one, which
+// keeps same-named objects of different kinds apart; otherwise the kind, name
+// and namespace must match.
+func EventMatchesResource(event corev1.Event, resource Resource) bool {
+	involved := event.InvolvedObject
+	if involved.UID != "" && resource.UID != "" {
+		return string(involved.UID) == resource.UID
+	}
+	return involved.Kind == string(resource.Type) &&
+		involved.Name == resource.Name &&
+		involved.Namespace == resource.Namespace
+}
+
+// DefaultEventsLookback is how far back GetEvents reaches by default
+const DefaultEventsLookback = time.Hour
+
+// GetEvents returns Kubernetes events related to FluxCD resources seen within
+// the client's EventsLookback, most recently seen first
 func (c *Client) GetEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
 	// Get all events first, then filter in-memory since Kubernetes field selectors
 	// don't support OR conditions for the same field or complex time comparisons
-	oneHourAgo := time.Now().Add(-1 * time.Hour)
-	eventList, err := c.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
-		// Remove all field selectors to avoid API errors - do filtering in-memory instead
+	var since time.Time
+	if c.EventsLookback > 0 {
+		since = time.Now().Add(-c.EventsLookback)
+	}
+	var eventList *corev1.EventList
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		eventList, err = c.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			// Remove all field selectors to avoid API errors - do filtering in-memory instead
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
 
-	// Filter events to only include FluxCD-related resources from the last hour
+	// Filter events to only include FluxCD-related resources within the lookback
 	fluxEvents := make([]corev1.Event, 0)
 	for _, event := range eventList.Items {
-		// Time-based filtering - only include events from the last hour
-		if event.FirstTimestamp.Time.Before(oneHourAgo) && event.LastTimestamp.Time.Before(oneHourAgo) {
+		// Time-based filtering - skip events last seen before the lookback
+		if !since.IsZero() && event.FirstTimestamp.Time.Before(since) && event.LastTimestamp.Time.Before(since) {
 			continue
 		}
 
-		apiVersion := event.InvolvedObject.APIVersion
-		// Check if event is related to FluxCD resources
-		if apiVersion == "source.toolkit.fluxcd.io/v1" ||
-			apiVersion == "source.toolkit.fluxcd.io/v1beta2" ||
-			apiVersion == "kustomize.toolkit.fluxcd.io/v1" ||
-			apiVersion == "helm.toolkit.fluxcd.io/v2beta1" ||
-			apiVersion == "helm.toolkit.fluxcd.io/v2" {
+		if isFluxEvent(event) {
 			fluxEvents = append(fluxEvents, event)
 		}
 	}
 
+	sort.SliceStable(fluxEvents, func(i, j int) bool {
+		return fluxEvents[i].LastTimestamp.After(fluxEvents[j].LastTimestamp.Time)
+	})
 	return fluxEvents, nil
 }
+
+// GetResourceEvents returns the events of a single FluxCD resource, newest
+// first. Unlike GetEvents it reaches back as far as the API server keeps them.
+func (c *Client) GetResourceEvents(ctx context.Context, resource Resource) ([]corev1.Event, error) {
+	var eventList *corev1.EventList
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		eventList, err = c.CoreV1().Events(resource.Namespace).List(ctx, metav1.ListOptions{
+			// Let the API server narrow to the object, the API group and UID are checked below
+			FieldSelector: fields.Set{
+				"involvedObject.kind": string(resource.Type),
+				"involvedObject.name": resource.Name,
+			}.AsSelector().String(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return FilterResourceEvents(eventList.Items, resource), nil
+}
+
+// FilterResourceEvents keeps the events of a FluxCD resource, matched with
+// EventMatchesResource, and sorts them by last occurrence, newest first
+func FilterResourceEvents(events []corev1.Event, resource Resource) []corev1.Event {
+	filtered := make([]corev1.Event, 0)
+	for _, event := range events {
+		if isFluxEvent(event) && EventMatchesResource(event, resource) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].LastTimestamp.After(filtered[j].LastTimestamp.Time)
+	})
+	return filtered
+}
+
+// isFluxEvent reports whether an event is about a FluxCD resource
+func isFluxEvent(event corev1.Event) bool {
+	group, _, _ := strings.Cut(event.InvolvedObject.APIVersion, "/")
+	switch group {
+	case "source.toolkit.fluxcd.io",
+		"kustomize.toolkit.fluxcd.io",
+		"helm.toolkit.fluxcd.io",
+		"image.toolkit.fluxcd.io",
+		"notification.toolkit.fluxcd.io":
+		return true
+	}
+	return false
+}
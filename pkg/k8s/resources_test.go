@@ -0,0 +1,930 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	helmctrlv2 "github.com/fluxcd/helm-controller/api/v2"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestResource_SetFetchError(t *testing.T) {
+	resource := Resource{
+		Type:    ResourceTypeGitRepository,
+		Message: "reconciliation failed",
+		Conditions: []Condition{
+			{Type: "Ready", Status: "False", Reason: "GitOperationFailed", Message: "reconciliation failed"},
+			{Type: "StorageOperationFailed", Status: "True", Reason: "ArchiveOperationFailed", Message: "disk full"},
+			{Type: "FetchFailed", Status: "True", Reason: "AuthenticationFailed", Message: "failed to clone: authentication required"},
+		},
+	}
+
+	resource.setFetchError()
+
+	// FetchFailed takes precedence over storage failures
+	assert.Equal(t, "AuthenticationFailed: failed to clone: authentication required", resource.FetchError)
+	assert.Equal(t, resource.FetchError, resource.DisplayMessage())
+	assert.True(t, resource.AuthFailed)
+
+	// HelmRepositories report it on the Ready condition
+	helmRepo := Resource{Conditions: []Condition{{Type: "Ready", Status: "False", Reason: "AuthenticationFailed", Message: "401 Unauthorized"}}}
+	helmRepo.setFetchError()
+	assert.True(t, helmRepo.AuthFailed)
+
+	healthy := Resource{
+		Message:    "stored artifact",
+		Conditions: []Condition{{Type: "FetchFailed", Status: "False"}},
+	}
+	healthy.setFetchError()
+	assert.Empty(t, healthy.FetchError)
+	assert.False(t, healthy.AuthFailed)
+	assert.Equal(t, "stored artifact", healthy.DisplayMessage())
+}
+
+func TestReadyStatus(t *testing.T) {
+	assert.Equal(t, "ReconciliationSucceeded", readyStatus(metav1.ConditionTrue, "ReconciliationSucceeded"))
+	assert.Equal(t, "InstallFailed", readyStatus(metav1.ConditionFalse, "InstallFailed"))
+
+	// Empty reasons fall back to the condition status
+	assert.Equal(t, "Ready", readyStatus(metav1.ConditionTrue, ""))
+	assert.Equal(t, "Failed", readyStatus(metav1.ConditionFalse, ""))
+	assert.Equal(t, "", readyStatus(metav1.ConditionUnknown, ""))
+}
+
+func TestSnoozedUntil(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	until := snoozedUntil(map[string]string{snoozeAnnotation: "2024-05-01T13:00:00Z"})
+	assert.True(t, Resource{SnoozedUntil: until}.Snoozed(now))
+	assert.False(t, Resource{SnoozedUntil: until}.Snoozed(now.Add(2*time.Hour)))
+
+	// Missing or malformed annotations never snooze
+	assert.True(t, snoozedUntil(nil).IsZero())
+	assert.True(t, snoozedUntil(map[string]string{snoozeAnnotation: "tomorrow"}).IsZero())
+	assert.False(t, Resource{}.Snoozed(now))
+}
+
+func TestResource_SetOverdue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	margin := 5 * time.Minute
+
+	// The latest of the handled request and condition transitions counts
+	r := Resource{Conditions: []Condition{{Type: "Ready", LastTransitionTime: now.Add(-time.Hour)}}}
+	r.setOverdue(10*time.Minute, now.Add(-16*time.Minute).Format(time.RFC3339), time.Time{}, margin, now)
+	assert.Equal(t, now.Add(-16*time.Minute), r.LastReconcile)
+	assert.False(t, r.Overdue, "a controller with nothing pending is never overdue")
+	assert.Equal(t, 10*time.Minute, r.Interval)
+	assert.Equal(t, 16*time.Minute, r.SinceReconcile(now))
+	assert.Equal(t, now.Add(4*time.Minute), r.NextReconcile, "rolled forward past steady reconciles")
+	assert.Equal(t, 4*time.Minute, r.UntilReconcile(now))
+	assert.Equal(t, -time.Minute, r.UntilReconcile(now.Add(5*time.Minute)), "negative once due")
+
+	// A last reconcile several intervals old still yields a countdown
+	r.setOverdue(10*time.Minute, now.Add(-47*time.Minute).Format(time.RFC3339), time.Time{}, margin, now)
+	assert.Equal(t, now.Add(3*time.Minute), r.NextReconcile)
+	r.setOverdue(10*time.Minute, now.Add(-50*time.Minute).Format(time.RFC3339), time.Time{}, margin, now)
+	assert.Equal(t, now.Add(10*time.Minute), r.NextReconcile, "a reconcile due right now counts as done")
+
+	r.setOverdue(10*time.Minute, "", now.Add(-4*time.Minute), margin, now)
+	assert.False(t, r.Overdue)
+	r.setOverdue(10*time.Minute, "", now.Add(-6*time.Minute), margin, now)
+	assert.True(t, r.Overdue)
+
+	// Suspended resources and kinds without an interval are never overdue
+	r.Suspended = true
+	r.setOverdue(10*time.Minute, "", now.Add(-time.Hour), margin, now)
+	assert.False(t, r.Overdue)
+	assert.True(t, r.NextReconcile.IsZero())
+	assert.Zero(t, r.UntilReconcile(now))
+	assert.False(t, isOverdue(0, now.Add(-time.Hour), false, margin, now))
+}
+
+func TestClient_Overdue(t *testing.T) {
+	now := time.Now()
+	c := &Client{OverdueMargin: 5 * time.Minute}
+	ready := metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconciliationSucceeded", LastTransitionTime: metav1.NewTime(now.Add(-30 * 24 * time.Hour))}
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system", UID: "uid-apps", Generation: 3},
+		Spec:       kustomizev1.KustomizationSpec{Interval: metav1.Duration{Duration: 10 * time.Minute}},
+		Status:     kustomizev1.KustomizationStatus{ObservedGeneration: 3, Conditions: []metav1.Condition{ready}},
+	}
+	ks.Status.LastHandledReconcileAt = now.Add(-7 * 24 * time.Hour).Format(time.RFC3339)
+
+	// A healthy resource whose Ready transition and last request are old is
+	// reconciling fine
+	assert.False(t, c.kustomizationResource(ks).Overdue)
+
+	// An unhandled reconcile request counts once it is older than the margin
+	ks.Annotations = map[string]string{reconcileRequestAnnotation: now.Add(-2 * time.Minute).Format(time.RFC3339Nano)}
+	assert.False(t, c.kustomizationResource(ks).Overdue)
+	ks.Annotations[reconcileRequestAnnotation] = now.Add(-6 * time.Minute).Format(time.RFC3339Nano)
+	assert.True(t, c.kustomizationResource(ks).Overdue)
+	ks.Status.LastHandledReconcileAt = ks.Annotations[reconcileRequestAnnotation]
+	assert.False(t, c.kustomizationResource(ks).Overdue, "handled requests don't count")
+
+	// An unobserved generation counts from the first list that saw it
+	ks.Generation = 4
+	assert.True(t, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 3, now).Equal(now))
+	assert.True(t, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 3, now.Add(time.Minute)).Equal(now))
+	assert.False(t, isOverdue(time.Minute, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 3, now.Add(4*time.Minute)), false, c.OverdueMargin, now.Add(4*time.Minute)))
+	assert.True(t, isOverdue(time.Minute, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 3, now.Add(6*time.Minute)), false, c.OverdueMargin, now.Add(6*time.Minute)))
+
+	// Once observed, a later generation starts over
+	assert.True(t, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 4, now).IsZero())
+	ks.Generation = 5
+	assert.True(t, c.behindSince(ks, ks.Status.LastHandledReconcileAt, 4, now.Add(time.Hour)).Equal(now.Add(time.Hour)))
+}
+
+func TestEventMatchesResource(t *testing.T) {
+	gitRepo := Resource{Type: ResourceTypeGitRepository, Name: "podinfo", Namespace: "flux-system", UID: "uid-git"}
+	helmRepo := Resource{Type: ResourceTypeHelmRepository, Name: "podinfo", Namespace: "flux-system", UID: "uid-helm"}
+
+	event := corev1.Event{InvolvedObject: corev1.ObjectReference{
+		Kind: "HelmRepository", Name: "podinfo", Namespace: "flux-system", UID: types.UID("uid-helm"),
+	}}
+	assert.True(t, EventMatchesResource(event, helmRepo))
+	assert.False(t, EventMatchesResource(event, gitRepo))
+
+	// Without a UID the kind keeps same-named objects apart
+	event.InvolvedObject.UID = ""
+	assert.True(t, EventMatchesResource(event, helmRepo))
+	assert.False(t, EventMatchesResource(event, gitRepo))
+
+	// A UID on both sides is authoritative, e.g. after a delete and recreate
+	event.InvolvedObject.UID = types.UID("uid-old")
+	assert.False(t, EventMatchesResource(event, helmRepo))
+}
+
+func TestClient_GetEventsLookback(t *testing.T) {
+	event := func(name string, age time.Duration) *corev1.Event {
+		seen := metav1.NewTime(time.Now().Add(-age))
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			InvolvedObject: corev1.ObjectReference{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "apps"},
+			FirstTimestamp: seen,
+			LastTimestamp:  seen,
+		}
+	}
+	c := &Client{Interface: k8sfake.NewSimpleClientset(
+		event("recent", 5*time.Minute),
+		event("earlier", 3*time.Hour),
+	)}
+
+	names := func() []string {
+		events, err := c.GetEvents(t.Context(), "")
+		require.NoError(t, err)
+		names := make([]string, 0, len(events))
+		for _, event := range events {
+			names = append(names, event.Name)
+		}
+		return names
+	}
+
+	c.EventsLookback = DefaultEventsLookback
+	assert.Equal(t, []string{"recent"}, names())
+
+	c.EventsLookback = 10 * time.Minute
+	assert.Equal(t, []string{"recent"}, names())
+
+	c.EventsLookback = 4 * time.Hour
+	assert.ElementsMatch(t, []string{"recent", "earlier"}, names())
+
+	// Zero disables the time filter
+	c.EventsLookback = 0
+	assert.ElementsMatch(t, []string{"recent", "earlier"}, names())
+}
+
+func TestClient_GetResourceEvents(t *testing.T) {
+	now := time.Now()
+	event := func(name, apiVersion, kind, object string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			InvolvedObject: corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: object, Namespace: "flux-system"},
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	// An earlier object of the same name doesn't belong to this one
+	recreated := event("recreated", "kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps", now)
+	recreated.InvolvedObject.UID = types.UID("uid-deleted")
+	clientset := k8sfake.NewSimpleClientset(
+		event("older", "kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps", now.Add(-2*time.Hour)),
+		event("newer", "kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps", now.Add(-time.Minute)),
+		event("other-object", "kustomize.toolkit.fluxcd.io/v1", "Kustomization", "infra", now),
+		event("other-kind", "source.toolkit.fluxcd.io/v1", "GitRepository", "apps", now),
+		event("not-flux", "apps/v1", "Kustomization", "apps", now),
+		recreated,
+		event("policy", "image.toolkit.fluxcd.io/v1beta2", "ImagePolicy", "podinfo", now),
+		event("alert", "notification.toolkit.fluxcd.io/v1beta3", "Alert", "slack", now),
+	)
+	c := &Client{Interface: clientset}
+
+	names := func(resource Resource) []string {
+		events, err := c.GetResourceEvents(t.Context(), resource)
+		require.NoError(t, err)
+		names := make([]string, 0, len(events))
+		for _, event := range events {
+			names = append(names, event.Name)
+		}
+		return names
+	}
+
+	resource := Resource{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", UID: "uid-apps"}
+	assert.Equal(t, []string{"newer", "older"}, names(resource))
+
+	// The server narrows the list to the object
+	actions := clientset.Actions()
+	list, ok := actions[len(actions)-1].(k8stesting.ListAction)
+	require.True(t, ok)
+	assert.Equal(t, "involvedObject.kind=Kustomization,involvedObject.name=apps", list.GetListRestrictions().Fields.String())
+
+	// Image automation and notification events are Flux events too
+	assert.Equal(t, []string{"policy"}, names(Resource{Type: ResourceTypeImagePolicy, Name: "podinfo", Namespace: "flux-system"}))
+	assert.Equal(t, []string{"alert"}, names(Resource{Type: ResourceTypeAlert, Name: "slack", Namespace: "flux-system"}))
+}
+
+func TestClient_ReconcilePending(t *testing.T) {
+	c := &Client{ReconcileDedupWindow: time.Minute}
+
+	ks := &kustomizev1.Kustomization{}
+	pending, _ := c.reconcilePending(ks)
+	assert.False(t, pending, "no request yet")
+
+	recent := time.Now().Add(-10 * time.Second).UTC().Format(time.RFC3339)
+	ks.SetAnnotations(map[string]string{reconcileRequestAnnotation: recent})
+	pending, age := c.reconcilePending(ks)
+	assert.True(t, pending)
+	assert.GreaterOrEqual(t, age, 10*time.Second)
+
+	// Handled requests don't block new ones
+	ks.Status.LastHandledReconcileAt = recent
+	pending, _ = c.reconcilePending(ks)
+	assert.False(t, pending)
+
+	// Requests older than the window are considered stuck and may be overwritten
+	ks.Status.LastHandledReconcileAt = ""
+	ks.SetAnnotations(map[string]string{reconcileRequestAnnotation: time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339)})
+	pending, _ = c.reconcilePending(ks)
+	assert.False(t, pending)
+
+	// A zero window disables the check
+	ks.SetAnnotations(map[string]string{reconcileRequestAnnotation: recent})
+	c.ReconcileDedupWindow = 0
+	pending, _ = c.reconcilePending(ks)
+	assert.False(t, pending)
+}
+
+func TestClient_ReconcileResourceDistinctTokens(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	ks := &kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"}}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(ks).Build()}
+
+	requested := func() string {
+		require.NoError(t, c.ReconcileResource(context.Background(), ResourceTypeKustomization, "apps", "flux-system"))
+		updated := &kustomizev1.Kustomization{}
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "apps", Namespace: "flux-system"}, updated))
+		assert.Equal(t, updated.Annotations[reconcileRequestAnnotation], updated.Annotations[legacyReconcileAnnotation])
+		return updated.Annotations[reconcileRequestAnnotation]
+	}
+
+	// Both calls land within the same second
+	first, second := requested(), requested()
+	assert.NotEqual(t, first, second)
+	_, err := time.Parse(time.RFC3339Nano, second)
+	assert.NoError(t, err)
+}
+
+func TestListAll(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, sourcev1beta2.AddToScheme(scheme))
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	objects := []client.Object{
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"}},
+		&sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "flux-system"}},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()}
+
+	resources, err := ListAll(t.Context(), c, "flux-system")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, ResourceTypeGitRepository, resources[0].Type)
+	assert.Equal(t, ResourceTypeKustomization, resources[1].Type)
+
+	failing := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*kustomizev1.KustomizationList); ok {
+					return apierrors.NewForbidden(kustomizev1.GroupVersion.WithResource("kustomizations").GroupResource(), "", nil)
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()}
+	_, err = ListAll(t.Context(), failing, "flux-system")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list Kustomizations")
+}
+
+func TestMarkDrift(t *testing.T) {
+	sources := []Resource{
+		{Type: ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system", Revision: "main@sha1:new"},
+		{Type: ResourceTypeOCIRepository, Name: "podinfo", Namespace: "apps", Revision: "6.5.0@sha256:abc"},
+	}
+	resources := []Resource{
+		{Type: ResourceTypeKustomization, Name: "behind", Namespace: "flux-system", Source: "GitRepository/fleet", SourceRef: &SourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}, Revision: "main@sha1:old"},
+		{Type: ResourceTypeKustomization, Name: "current", Namespace: "flux-system", Source: "OCIRepository/apps/podinfo", SourceRef: &SourceReference{Kind: "OCIRepository", Name: "podinfo", Namespace: "apps"}, Revision: "6.5.0@sha256:abc"},
+		// Nothing applied yet, or a source that wasn't listed
+		{Type: ResourceTypeKustomization, Name: "new", Namespace: "flux-system", Source: "GitRepository/fleet", SourceRef: &SourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}},
+		{Type: ResourceTypeKustomization, Name: "elsewhere", Namespace: "flux-system", Source: "Bucket/fleet", SourceRef: &SourceReference{Kind: "Bucket", Name: "fleet", Namespace: "flux-system"}, Revision: "sha256:old"},
+		{Type: ResourceTypeKustomization, Name: "fixed", Namespace: "flux-system", Source: "GitRepository/fleet", SourceRef: &SourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}, Revision: "main@sha1:new", Drift: true},
+	}
+
+	MarkDrift(resources, sources)
+	drifting := map[string]bool{}
+	for _, resource := range resources {
+		drifting[resource.Name] = resource.Drift
+	}
+	assert.Equal(t, map[string]bool{"behind": true, "current": false, "new": false, "elsewhere": false, "fixed": false}, drifting)
+}
+
+func TestListInNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "team-a"}},
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "team-b"}},
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "team-c"}},
+	).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+			if listOpts.Namespace == "" {
+				return apierrors.NewForbidden(kustomizev1.GroupVersion.WithResource("kustomizations").GroupResource(), "", nil)
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()}
+
+	// Scoped lists work without cluster-wide RBAC, duplicates are listed once
+	resources, err := ListInNamespaces(t.Context(), c, ResourceTypeKustomization, []string{"team-b", "team-a", "team-b"})
+	require.NoError(t, err)
+	var listed []string
+	for _, resource := range resources {
+		listed = append(listed, resource.Namespace+"/"+resource.Name)
+	}
+	assert.Equal(t, []string{"team-b/blog", "team-a/shop"}, listed)
+
+	_, err = ListInNamespaces(t.Context(), c, ResourceTypeKustomization, nil)
+	assert.True(t, apierrors.IsForbidden(err))
+
+	_, err = ListInNamespaces(t.Context(), c, ResourceTypeKustomization, []string{"team-a", ""})
+	assert.Error(t, err)
+}
+
+func TestIsCRDMissing(t *testing.T) {
+	resource := kustomizev1.GroupVersion.WithResource("kustomizations").GroupResource()
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no kind match", &meta.NoKindMatchError{GroupKind: kustomizev1.GroupVersion.WithKind("Kustomization").GroupKind()}, true},
+		{"wrapped no kind match", fmt.Errorf("failed to list: %w", &meta.NoResourceMatchError{PartialResource: kustomizev1.GroupVersion.WithResource("kustomizations")}), true},
+		{"not found", apierrors.NewNotFound(resource, ""), true},
+		{"untyped message", errors.New("the server could not find the requested resource (get kustomizations)"), true},
+		{"forbidden", apierrors.NewForbidden(resource, "", errors.New("RBAC")), false},
+		{"timeout", apierrors.NewServerTimeout(resource, "list", 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isCRDMissing(tt.err))
+		})
+	}
+}
+
+func TestClient_DeleteResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec:       kustomizev1.KustomizationSpec{Prune: true},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(ks).Build()}
+
+	require.NoError(t, c.DeleteResource(context.Background(), ResourceTypeKustomization, "apps", "flux-system"))
+	err := c.Get(context.Background(), types.NamespacedName{Name: "apps", Namespace: "flux-system"}, &kustomizev1.Kustomization{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	err = c.DeleteResource(context.Background(), ResourceTypeKustomization, "apps", "flux-system")
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Contains(t, err.Error(), "failed to delete Kustomization/apps")
+
+	err = c.DeleteResource(context.Background(), ResourceType("Unknown"), "apps", "flux-system")
+	assert.ErrorIs(t, err, ErrUnsupportedAction)
+}
+
+func TestClient_OCIRepositories(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1beta2.AddToScheme(scheme))
+
+	size := int64(2048)
+	repo := &sourcev1beta2.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "flux-system"},
+		Spec: sourcev1beta2.OCIRepositorySpec{
+			URL:       "oci://ghcr.io/stefanprodan/manifests/podinfo",
+			Reference: &sourcev1beta2.OCIRepositoryRef{Tag: "6.5.0", SemVer: ">=6.0.0"},
+			Interval:  metav1.Duration{Duration: 10 * time.Minute},
+		},
+		Status: sourcev1beta2.OCIRepositoryStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Succeeded", LastTransitionTime: metav1.Now()}},
+			Artifact:   &sourcev1.Artifact{Revision: "6.5.0@sha256:abc", Digest: "sha256:def", Size: &size},
+		},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(repo).Build()}
+
+	resources, err := c.ListOCIRepositories(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, ResourceTypeOCIRepository, resources[0].Type)
+	assert.Equal(t, "oci://ghcr.io/stefanprodan/manifests/podinfo", resources[0].URL)
+	assert.Equal(t, ">=6.0.0", resources[0].Ref, "semver takes precedence over the tag")
+	assert.Equal(t, "6.5.0@sha256:abc", resources[0].Revision)
+	assert.Equal(t, "sha256:def", resources[0].Digest)
+	assert.Equal(t, int64(2048), resources[0].Size)
+	assert.True(t, resources[0].Ready)
+
+	require.NoError(t, c.SuspendResource(t.Context(), ResourceTypeOCIRepository, "podinfo", "flux-system"))
+	var updated sourcev1beta2.OCIRepository
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "podinfo", Namespace: "flux-system"}, &updated))
+	assert.True(t, updated.Spec.Suspend)
+
+	assert.Equal(t, "latest", ociReference(nil))
+}
+
+func TestClient_Buckets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1beta2.AddToScheme(scheme))
+
+	bucket := &sourcev1beta2.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifacts", Namespace: "flux-system"},
+		Spec: sourcev1beta2.BucketSpec{
+			Provider:   "aws",
+			BucketName: "deploy-artifacts",
+			Endpoint:   "s3.amazonaws.com",
+			Prefix:     "prod",
+			Interval:   metav1.Duration{Duration: 5 * time.Minute},
+		},
+		Status: sourcev1beta2.BucketStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "BucketOperationFailed", Message: "access denied", LastTransitionTime: metav1.Now()}},
+		},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(bucket).Build()}
+
+	resources, err := c.ListBuckets(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, ResourceTypeBucket, resources[0].Type)
+	assert.Equal(t, "s3.amazonaws.com", resources[0].URL)
+	assert.Equal(t, "deploy-artifacts", resources[0].Source)
+	assert.Equal(t, "prod", resources[0].Path)
+	assert.Equal(t, "aws", resources[0].Provider)
+	assert.False(t, resources[0].Ready)
+	assert.Equal(t, "access denied", resources[0].Message)
+
+	require.NoError(t, c.SuspendResource(t.Context(), ResourceTypeBucket, "artifacts", "flux-system"))
+	var updated sourcev1beta2.Bucket
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "artifacts", Namespace: "flux-system"}, &updated))
+	assert.True(t, updated.Spec.Suspend)
+}
+
+func TestClient_KustomizationSourceKinds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	kustomization := func(name string, ref kustomizev1.CrossNamespaceSourceReference) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Spec:       kustomizev1.KustomizationSpec{SourceRef: ref},
+		}
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		kustomization("git", kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "fleet"}),
+		kustomization("oci", kustomizev1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "podinfo", Namespace: "flux-system"}),
+		kustomization("bucket", kustomizev1.CrossNamespaceSourceReference{Kind: "Bucket", Name: "artifacts", Namespace: "shared"}),
+	).Build()}
+
+	resources, err := c.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	sources := make(map[string]string)
+	refs := make(map[string]SourceReference)
+	for _, resource := range resources {
+		sources[resource.Name] = resource.Source
+		require.NotNil(t, resource.SourceRef)
+		refs[resource.Name] = *resource.SourceRef
+	}
+	assert.Equal(t, map[string]string{
+		"git":    "GitRepository/fleet",
+		"oci":    "OCIRepository/podinfo",
+		"bucket": "Bucket/shared/artifacts",
+	}, sources)
+
+	// The reference keeps the parts, with the namespace defaulted
+	assert.Equal(t, map[string]SourceReference{
+		"git":    {Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"},
+		"oci":    {Kind: "OCIRepository", Name: "podinfo", Namespace: "flux-system"},
+		"bucket": {Kind: "Bucket", Name: "artifacts", Namespace: "shared"},
+	}, refs)
+}
+
+func TestClient_KustomizationInventoryCount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{Inventory: &kustomizev1.ResourceInventory{Entries: []kustomizev1.ResourceRef{
+				{ID: "podinfo_podinfo_apps_Deployment", Version: "v1"},
+				{ID: "podinfo_podinfo__Service", Version: "v1"},
+			}}},
+		},
+		// Without pruning there is no inventory
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "infra", Namespace: "flux-system"}},
+	).Build()}
+
+	resources, err := c.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	counts := make(map[string]int)
+	for _, resource := range resources {
+		counts[resource.Name] = resource.InventoryCount
+	}
+	assert.Equal(t, map[string]int{"apps": 2, "infra": 0}, counts)
+}
+
+func TestClient_ListLabelSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	kustomization := func(name string, labels map[string]string) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Labels: labels}}
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		kustomization("payments", map[string]string{"team": "payments"}),
+		kustomization("search", map[string]string{"team": "search"}),
+		kustomization("infra", nil),
+	).Build()}
+
+	selector, err := ParseLabelSelector("team=payments")
+	require.NoError(t, err)
+	resources, err := ListResources(t.Context(), c, ResourceTypeKustomization, "flux-system", WithLabelSelector(selector))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "payments", resources[0].Name)
+
+	selector, err = ParseLabelSelector("team")
+	require.NoError(t, err)
+	resources, err = c.ListKustomizations(t.Context(), "", WithLabelSelector(selector))
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	// No selector lists everything
+	selector, err = ParseLabelSelector("")
+	require.NoError(t, err)
+	assert.Nil(t, selector)
+	resources, err = c.ListKustomizations(t.Context(), "", WithLabelSelector(selector))
+	require.NoError(t, err)
+	assert.Len(t, resources, 3)
+
+	_, err = ParseLabelSelector("team in payments")
+	assert.ErrorContains(t, err, `invalid label selector "team in payments"`)
+}
+
+func TestClient_KustomizationDecryptFailed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	kustomization := func(name, message string) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "BuildFailed", Message: message},
+			}},
+		}
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		kustomization("secrets", "decryption failed for 'db': failed to get the data key required to decrypt the SOPS file"),
+		kustomization("apps", "kustomize build failed: accumulating resources"),
+		kustomization("infra", "kustomize build failed: accumulating resources from './infrastructure/sops': no such file or directory"),
+	).Build()}
+
+	resources, err := c.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	statuses := make(map[string]string)
+	for _, resource := range resources {
+		statuses[resource.Name] = resource.Status
+		assert.Equal(t, resource.Name == "secrets", resource.DecryptFailed, resource.Name)
+	}
+	assert.Equal(t, DecryptFailedStatus, statuses["secrets"])
+	assert.NotEqual(t, DecryptFailedStatus, statuses["apps"])
+	// A path mentioning sops is not a decryption error
+	assert.NotEqual(t, DecryptFailedStatus, statuses["infra"])
+}
+
+func TestClient_HelmReleaseChartRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps"},
+			Spec: helmv2.HelmReleaseSpec{
+				ChartRef: &helmctrlv2.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "podinfo-chart", Namespace: "flux-system"},
+			},
+			Status: helmv2.HelmReleaseStatus{
+				History: helmctrlv2.Snapshots{{Name: "podinfo", Version: 2, ChartName: "podinfo", ChartVersion: "6.5.0"}},
+			},
+		},
+		&helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "apps"},
+			Spec: helmv2.HelmReleaseSpec{
+				ChartRef: &helmctrlv2.CrossNamespaceSourceReference{Kind: "HelmChart", Name: "apps-pending"},
+			},
+		},
+		&helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "apps"},
+			Spec: helmv2.HelmReleaseSpec{
+				Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{
+					Chart:     "redis",
+					Version:   "18.x",
+					SourceRef: helmv2.CrossNamespaceObjectReference{Kind: "HelmRepository", Name: "bitnami"},
+				}},
+			},
+		},
+	).Build()}
+
+	resources, err := c.ListHelmReleases(t.Context(), "apps")
+	require.NoError(t, err)
+	byName := make(map[string]Resource)
+	for _, resource := range resources {
+		byName[resource.Name] = resource
+	}
+
+	assert.Equal(t, "podinfo", byName["podinfo"].Chart)
+	assert.Equal(t, "6.5.0", byName["podinfo"].Version)
+	assert.Equal(t, "OCIRepository/flux-system/podinfo-chart", byName["podinfo"].Source)
+	assert.True(t, byName["podinfo"].ChartSource.ChartRef)
+
+	assert.Equal(t, "apps-pending", byName["pending"].Chart)
+	assert.Empty(t, byName["pending"].Version)
+	assert.Equal(t, "HelmChart/apps-pending", byName["pending"].Source)
+
+	assert.Equal(t, "redis", byName["redis"].Chart)
+	assert.Equal(t, "18.x", byName["redis"].Version)
+	assert.Equal(t, "HelmRepository/bitnami", byName["redis"].Source)
+	assert.False(t, byName["redis"].ChartSource.ChartRef)
+}
+
+func TestClient_HelmReleaseHistory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	deployed := metav1.NewTime(time.Date(2026, 5, 1, 12, 0, 0, 0, time.Local))
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps"},
+			Status: helmv2.HelmReleaseStatus{
+				LastReleaseRevision: 4,
+				// Out of order, as the controller doesn't promise any
+				History: helmctrlv2.Snapshots{
+					{Version: 2, Status: "superseded", ChartVersion: "6.5.0", ConfigDigest: "sha256:a"},
+					{Version: 4, Status: "deployed", ChartVersion: "6.5.0", ConfigDigest: "sha256:a", LastDeployed: deployed},
+					{Version: 3, Status: "failed", ChartVersion: "6.6.0", ConfigDigest: "sha256:b"},
+				},
+			},
+		},
+		&helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "apps"},
+			Status: helmv2.HelmReleaseStatus{
+				History: helmctrlv2.Snapshots{
+					{Version: 2, Status: "deployed", ChartVersion: "18.1.0", ConfigDigest: "sha256:c"},
+					{Version: 1, Status: "superseded", ChartVersion: "18.0.0", ConfigDigest: "sha256:c"},
+				},
+			},
+		},
+		&helmv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "apps"}},
+	).Build()}
+
+	resources, err := c.ListHelmReleases(t.Context(), "apps")
+	require.NoError(t, err)
+	byName := make(map[string]Resource)
+	for _, resource := range resources {
+		byName[resource.Name] = resource
+	}
+
+	// Revision 4 restored revision 2 after the failed upgrade to 3
+	podinfo := byName["podinfo"]
+	assert.Equal(t, 4, podinfo.HelmRevision)
+	assert.Equal(t, []HelmSnapshot{
+		{Revision: 4, Status: "deployed", ChartVersion: "6.5.0", Deployed: deployed.Time, Rollback: true},
+		{Revision: 3, Status: "failed", ChartVersion: "6.6.0"},
+		{Revision: 2, Status: "superseded", ChartVersion: "6.5.0"},
+	}, podinfo.History)
+
+	// Without a recorded revision the latest release counts, upgrades aren't rollbacks
+	assert.Equal(t, 2, byName["redis"].HelmRevision)
+	assert.False(t, byName["redis"].History[0].Rollback)
+
+	assert.Zero(t, byName["pending"].HelmRevision)
+	assert.Nil(t, byName["pending"].History)
+}
+
+func TestClient_HelmReleaseSourceKinds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	release := func(name string, ref helmv2.CrossNamespaceObjectReference) *helmv2.HelmRelease {
+		return &helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+			Spec: helmv2.HelmReleaseSpec{
+				Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{Chart: "./charts/" + name, SourceRef: ref}},
+			},
+		}
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		release("helm", helmv2.CrossNamespaceObjectReference{Kind: "HelmRepository", Name: "bitnami", Namespace: "flux-system"}),
+		release("git", helmv2.CrossNamespaceObjectReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}),
+		release("oci", helmv2.CrossNamespaceObjectReference{Kind: "OCIRepository", Name: "charts"}),
+		release("bucket", helmv2.CrossNamespaceObjectReference{Kind: "Bucket", Name: "artifacts", Namespace: "apps"}),
+	).Build()}
+
+	resources, err := c.ListHelmReleases(t.Context(), "apps")
+	require.NoError(t, err)
+	sources := make(map[string]string)
+	for _, resource := range resources {
+		sources[resource.Name] = resource.Source
+	}
+	assert.Equal(t, map[string]string{
+		"helm":   "HelmRepository/flux-system/bitnami",
+		"git":    "GitRepository/flux-system/fleet",
+		"oci":    "OCIRepository/charts",
+		"bucket": "Bucket/artifacts",
+	}, sources)
+}
+
+func TestClient_HelmReleaseStalled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	release := func(name string, conditions ...metav1.Condition) *helmv2.HelmRelease {
+		return &helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+			Status:     helmv2.HelmReleaseStatus{Conditions: conditions},
+		}
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		release("exhausted",
+			metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeFailed"},
+			metav1.Condition{Type: "Stalled", Status: metav1.ConditionTrue, Reason: "RetriesExceeded"}),
+		release("upgrading",
+			metav1.Condition{Type: "Ready", Status: metav1.ConditionUnknown, Reason: "Progressing"},
+			metav1.Condition{Type: "Reconciling", Status: metav1.ConditionTrue, Reason: "Progressing"}),
+		release("recovered",
+			metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "UpgradeSucceeded"},
+			metav1.Condition{Type: "Stalled", Status: metav1.ConditionFalse}),
+	).Build()}
+
+	resources, err := c.ListHelmReleases(t.Context(), "apps")
+	require.NoError(t, err)
+	states := make(map[string][2]bool)
+	for _, resource := range resources {
+		states[resource.Name] = [2]bool{resource.Stalled, resource.Reconciling}
+	}
+	assert.Equal(t, map[string][2]bool{
+		"exhausted": {true, false},
+		"upgrading": {false, true},
+		"recovered": {false, false},
+	}, states)
+}
+
+func TestClient_ReadySince(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	// Condition times come back in local time
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{Conditions: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(since.Add(time.Hour))},
+				{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(since)},
+			}},
+		},
+		// Never reconciled, so there is no Ready condition yet
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "flux-system"}},
+	).Build()}
+
+	resources, err := c.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	readySince := make(map[string]time.Time)
+	for _, resource := range resources {
+		readySince[resource.Name] = resource.ReadySince
+	}
+	assert.Equal(t, map[string]time.Time{"apps": since, "new": {}}, readySince)
+}
+
+func TestClient_GetResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec: kustomizev1.KustomizationSpec{
+			Path:      "./apps",
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "fleet"},
+			Interval:  metav1.Duration{Duration: 10 * time.Minute},
+		},
+		Status: kustomizev1.KustomizationStatus{
+			Conditions:          []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconciliationSucceeded", LastTransitionTime: metav1.Now()}},
+			LastAppliedRevision: "main@sha1:abc123",
+		},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(ks).Build()}
+
+	resource, err := c.GetResource(t.Context(), ResourceTypeKustomization, "apps", "flux-system")
+	require.NoError(t, err)
+	listed, err := c.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	// Same conversion as the list path, apart from the timestamps taken at conversion
+	resource.Age, resource.LastUpdate = listed[0].Age, listed[0].LastUpdate
+	assert.Equal(t, listed[0], *resource)
+	assert.Equal(t, "GitRepository/fleet", resource.Source)
+	assert.Equal(t, "main@sha1:abc123", resource.Revision)
+
+	_, err = c.GetResource(t.Context(), ResourceTypeKustomization, "missing", "flux-system")
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Contains(t, err.Error(), "Kustomization flux-system/missing not found")
+}
+
+func TestClient_HelmRepositoryV1Fallback(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+
+	repo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "podinfo", Namespace: "flux-system",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: sourcev1.HelmRepositorySpec{URL: "https://stefanprodan.github.io/podinfo"},
+	}
+	// The cluster only serves v1, as after the v1beta2 API is removed
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(repo).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*sourcev1beta2.HelmRepository); ok {
+					return &meta.NoKindMatchError{GroupKind: sourcev1beta2.GroupVersion.WithKind("HelmRepository").GroupKind()}
+				}
+				return cl.Get(ctx, key, obj, opts...)
+			},
+		}).Build()}
+
+	manifest, err := c.GetResourceYAML(t.Context(), ResourceTypeHelmRepository, "podinfo", "flux-system")
+	require.NoError(t, err)
+	assert.Contains(t, manifest, "apiVersion: source.toolkit.fluxcd.io/v1\n")
+	assert.Contains(t, manifest, "url: https://stefanprodan.github.io/podinfo")
+	assert.NotContains(t, manifest, "managedFields")
+
+	resource, err := c.GetResource(t.Context(), ResourceTypeHelmRepository, "podinfo", "flux-system")
+	require.NoError(t, err)
+	assert.Equal(t, "https://stefanprodan.github.io/podinfo", resource.URL)
+
+	_, err = c.GetResource(t.Context(), ResourceTypeHelmRepository, "missing", "flux-system")
+	assert.True(t, apierrors.IsNotFound(err))
+}
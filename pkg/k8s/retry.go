@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultAPIRetries is how often a request failing with a transient API
+// error is retried before the error is returned
+const DefaultAPIRetries = 3
+
+// DefaultRequestTimeout bounds a single API request, so a hung API server
+// fails the call instead of freezing the UI
+const DefaultRequestTimeout = 30 * time.Second
+
+const (
+	// retryBaseDelay is the backoff before the first retry, doubled after each
+	retryBaseDelay = 200 * time.Millisecond
+	// retryMaxDelay caps the backoff between retries
+	retryMaxDelay = 5 * time.Second
+)
+
+// retryable reports whether an API error is transient: the server timed out,
+// asked the client to slow down or failed internally
+func retryable(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err)
+}
+
+// withRetry runs fn until it succeeds, fails with an error that is not
+// retryable or has been retried retries times. The backoff doubles with
+// jitter, and a Retry-After sent by the server is waited out in full. Each
+// attempt gets timeout to complete, zero leaves it to ctx; an attempt running
+// out of time returns a timeout error without retrying, since a hung server
+// would only hang again.
+func withRetry(ctx context.Context, retries int, timeout time.Duration, fn func(ctx context.Context) error) error {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := withTimeout(ctx, timeout, fn)
+		if err == nil || attempt >= retries || !retryable(err) || isRequestTimeout(err) {
+			return err
+		}
+
+		wait := delay/2 + rand.N(delay/2)
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+			wait = max(wait, time.Duration(seconds)*time.Second)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		delay = min(2*delay, retryMaxDelay)
+	}
+}
+
+// errRequestTimeout marks the timeout errors of requests that ran out of
+// their own timeout rather than the caller's context
+var errRequestTimeout = errors.New("request timed out")
+
+// withTimeout runs fn with timeout to complete. Running out of time is
+// reported as an API timeout error so callers can tell it from cancellation.
+func withTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := fn(callCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", errRequestTimeout,
+			apierrors.NewTimeoutError(fmt.Sprintf("the API server did not respond within %s", timeout), 0))
+	}
+	return err
+}
+
+// isRequestTimeout reports whether err comes from a request running out of
+// its timeout
+func isRequestTimeout(err error) bool {
+	return errors.Is(err, errRequestTimeout)
+}
+
+// call runs fn, an API request outside the controller-runtime client, with
+// the client's request timeout and retries
+func (c *Client) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withRetry(ctx, c.APIRetries, c.RequestTimeout, fn)
+}
+
+// retryClient retries the reads and writes of a controller-runtime client on
+// transient API errors, so a single timeout or 429 doesn't reach the UI, and
+// bounds each attempt by the request timeout
+type retryClient struct {
+	client.Client
+	retries *int           // The owning Client's APIRetries
+	timeout *time.Duration // The owning Client's RequestTimeout
+}
+
+// Get implements client.Reader
+func (r retryClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return withRetry(ctx, *r.retries, *r.timeout, func(ctx context.Context) error { return r.Client.Get(ctx, key, obj, opts...) })
+}
+
+// List implements client.Reader
+func (r retryClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return withRetry(ctx, *r.retries, *r.timeout, func(ctx context.Context) error { return r.Client.List(ctx, list, opts...) })
+}
+
+// Update implements client.Writer
+func (r retryClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return withRetry(ctx, *r.retries, *r.timeout, func(ctx context.Context) error { return r.Client.Update(ctx, obj, opts...) })
+}
+
+// Patch implements client.Writer
+func (r retryClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return withRetry(ctx, *r.retries, *r.timeout, func(ctx context.Context) error { return r.Client.Patch(ctx, obj, patch, opts...) })
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// flakyClient returns a client whose first failures lists fail with err,
+// and a count of the lists that reached the API
+func flakyClient(t *testing.T, failures int, err error, retries int) (*Client, *int) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	calls := 0
+	ctrl := ctrlfake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				calls++
+				if calls <= failures {
+					return err
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+
+	c := &Client{APIRetries: retries}
+	c.Client = retryClient{Client: ctrl, retries: &c.APIRetries, timeout: &c.RequestTimeout}
+	return c, &calls
+}
+
+func TestRetryClient_TransientErrors(t *testing.T) {
+	resource := kustomizev1.GroupVersion.WithResource("kustomizations").GroupResource()
+	transient := []error{
+		apierrors.NewServerTimeout(resource, "list", 0),
+		apierrors.NewTooManyRequests("slow down", 0),
+		apierrors.NewInternalError(assert.AnError),
+	}
+	for _, err := range transient {
+		c, calls := flakyClient(t, 2, err, 3)
+		resources, listErr := c.ListKustomizations(t.Context(), "flux-system")
+		require.NoError(t, listErr, err.Error())
+		assert.Len(t, resources, 1)
+		assert.Equal(t, 3, *calls)
+	}
+}
+
+func TestRetryClient_GivesUp(t *testing.T) {
+	resource := kustomizev1.GroupVersion.WithResource("kustomizations").GroupResource()
+
+	// Retries are bounded by the limit
+	c, calls := flakyClient(t, 10, apierrors.NewTooManyRequests("slow down", 0), 2)
+	_, err := c.ListKustomizations(t.Context(), "flux-system")
+	assert.True(t, apierrors.IsTooManyRequests(err))
+	assert.Equal(t, 3, *calls)
+
+	// Errors that won't go away pass through right away
+	c, calls = flakyClient(t, 10, apierrors.NewForbidden(resource, "", assert.AnError), 3)
+	_, err = c.ListKustomizations(t.Context(), "flux-system")
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.Equal(t, 1, *calls)
+
+	// Zero disables retries
+	c, calls = flakyClient(t, 10, apierrors.NewInternalError(assert.AnError), 0)
+	_, err = c.ListKustomizations(t.Context(), "flux-system")
+	assert.True(t, apierrors.IsInternalError(err))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestRetryClient_RequestTimeout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	// A hung API server only answers once the request is given up
+	calls := 0
+	ctrl := ctrlfake.NewClientBuilder().WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				calls++
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}).Build()
+
+	c := &Client{APIRetries: 3, RequestTimeout: 20 * time.Millisecond}
+	c.Client = retryClient{Client: ctrl, retries: &c.APIRetries, timeout: &c.RequestTimeout}
+	_, err := c.ListKustomizations(t.Context(), "flux-system")
+	assert.True(t, apierrors.IsTimeout(err), err)
+	assert.Contains(t, err.Error(), "did not respond within 20ms")
+	assert.Equal(t, 1, calls)
+
+	// Cancelling the caller's context is not reported as a timeout
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = c.ListKustomizations(ctx, "flux-system")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, apierrors.IsTimeout(err))
+}
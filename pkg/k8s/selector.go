@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListOption narrows the resources a list method returns
+type ListOption func(*ListOptions)
+
+// ListOptions collects the settings of ListOption values
+type ListOptions struct {
+	// LabelSelector keeps the resources whose labels match; nil keeps all
+	LabelSelector labels.Selector
+}
+
+// WithLabelSelector lists only the resources matching selector, e.g. the
+// objects of one tenant labelled team=payments
+func WithLabelSelector(selector labels.Selector) ListOption {
+	return func(o *ListOptions) {
+		o.LabelSelector = selector
+	}
+}
+
+// NewListOptions applies opts to empty ListOptions
+func NewListOptions(opts ...ListOption) ListOptions {
+	var options ListOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// Matches reports whether a resource passes the options, for clients that
+// filter in memory
+func (o ListOptions) Matches(resource Resource) bool {
+	return o.LabelSelector == nil || o.LabelSelector.Matches(labels.Set(resource.Labels))
+}
+
+// Filter returns the resources that pass the options
+func (o ListOptions) Filter(resources []Resource) []Resource {
+	if o.LabelSelector == nil || o.LabelSelector.Empty() {
+		return resources
+	}
+	filtered := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		if o.Matches(resource) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// clientOptions converts the options to controller-runtime list options, so
+// the API server or cache does the filtering
+func (o ListOptions) clientOptions() []client.ListOption {
+	opts := []client.ListOption{}
+	if o.LabelSelector != nil && !o.LabelSelector.Empty() {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: o.LabelSelector})
+	}
+	return opts
+}
+
+// ParseLabelSelector parses a selector such as "team=payments,tier!=db";
+// an empty one selects everything and returns nil
+func ParseLabelSelector(selector string) (labels.Selector, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return parsed, nil
+}
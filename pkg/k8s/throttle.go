@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// throttleWaitThreshold is the rate limiter wait that counts as throttled,
+	// the same latency after which client-go logs client-side throttling
+	throttleWaitThreshold = 50 * time.Millisecond
+	// throttleWindow is the window throttled requests are counted over
+	throttleWindow = time.Minute
+	// throttleWarnAfter is how many throttled requests per window trigger a warning
+	throttleWarnAfter = 10
+)
+
+// ThrottleWarning is surfaced when the client keeps waiting on its rate limiter
+const ThrottleWarning = "client throttling — consider raising QPS"
+
+// throttleMonitor is a rate limiter that reports when requests frequently
+// wait on it, which points at too low QPS/Burst for the cluster's size
+type throttleMonitor struct {
+	flowcontrol.RateLimiter
+	warnings *WarningCollector
+
+	mu         sync.Mutex
+	waits      []time.Time
+	lastWarned time.Time
+}
+
+// newThrottleMonitor creates a token bucket limiter with the QPS and Burst of
+// config, or the client-go defaults when unset
+func newThrottleMonitor(config *rest.Config, warnings *WarningCollector) *throttleMonitor {
+	qps, burst := config.QPS, config.Burst
+	if qps == 0 {
+		qps = rest.DefaultQPS
+	}
+	if burst == 0 {
+		burst = rest.DefaultBurst
+	}
+	return &throttleMonitor{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		warnings:    warnings,
+	}
+}
+
+// Wait implements flowcontrol.RateLimiter
+func (t *throttleMonitor) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := t.RateLimiter.Wait(ctx)
+	if time.Since(start) > throttleWaitThreshold {
+		t.observe(time.Now())
+	}
+	return err
+}
+
+// Accept implements flowcontrol.RateLimiter
+func (t *throttleMonitor) Accept() {
+	start := time.Now()
+	t.RateLimiter.Accept()
+	if time.Since(start) > throttleWaitThreshold {
+		t.observe(time.Now())
+	}
+}
+
+// observe records a throttled request and warns, at most once per window,
+// when too many fell into the current window
+func (t *throttleMonitor) observe(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.waits = append(t.waits, now)
+	cutoff := now.Add(-throttleWindow)
+	for len(t.waits) > 0 && t.waits[0].Before(cutoff) {
+		t.waits = t.waits[1:]
+	}
+
+	if len(t.waits) >= throttleWarnAfter && now.Sub(t.lastWarned) > throttleWindow {
+		t.lastWarned = now
+		t.warnings.Notify(ThrottleWarning)
+	}
+}
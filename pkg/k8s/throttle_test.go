@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestThrottleMonitor_Observe(t *testing.T) {
+	warnings := NewWarningCollector()
+	monitor := newThrottleMonitor(&rest.Config{}, warnings)
+	assert.Equal(t, rest.DefaultQPS, monitor.QPS())
+
+	now := time.Now()
+	for i := 0; i < throttleWarnAfter-1; i++ {
+		monitor.observe(now)
+	}
+	assert.Empty(t, warnings.Drain(), "below the threshold")
+
+	monitor.observe(now)
+	assert.Equal(t, []string{ThrottleWarning}, warnings.Drain())
+
+	// Warnings repeat at most once per window while throttling persists
+	monitor.observe(now.Add(time.Second))
+	assert.Empty(t, warnings.Drain())
+
+	later := now.Add(2 * throttleWindow)
+	for i := 0; i < throttleWarnAfter; i++ {
+		monitor.observe(later)
+	}
+	assert.Equal(t, []string{ThrottleWarning}, warnings.Drain())
+}
+
+func TestThrottleMonitor_Wait(t *testing.T) {
+	warnings := NewWarningCollector()
+	// Requests served from the burst don't wait and are not counted
+	monitor := newThrottleMonitor(&rest.Config{QPS: 1000, Burst: 1}, warnings)
+
+	assert.NoError(t, monitor.Wait(t.Context()))
+	assert.Empty(t, monitor.waits)
+}
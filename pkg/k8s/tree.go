@@ -0,0 +1,252 @@
+package k8s
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxTreeDepth bounds how deep BuildDependencyTree follows nested Flux
+// objects, guarding against Kustomizations that end up applying themselves
+const maxTreeDepth = 10
+
+// TreeNode is an object in a dependency tree with the objects it manages
+type TreeNode struct {
+	Object   ObjectRef   `json:"object"`
+	Children []*TreeNode `json:"children,omitempty"`
+	Err      string      `json:"error,omitempty"` // Why the children could not be listed
+}
+
+// BuildDependencyTree returns the objects a Kustomization applied, from its
+// inventory, or a HelmRelease rendered, from its latest Helm release, like
+// flux tree. Kustomizations and HelmReleases among them are expanded in turn.
+func (c *Client) BuildDependencyTree(ctx context.Context, resourceType ResourceType, name, namespace string) (*TreeNode, error) {
+	if resourceType != ResourceTypeKustomization && resourceType != ResourceTypeHelmRelease {
+		return nil, fmt.Errorf("%s manages no objects: %w", resourceType, ErrUnsupportedAction)
+	}
+
+	obj, err := newObject(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	root := &TreeNode{Object: ObjectRef{
+		Namespace: namespace,
+		Name:      name,
+		Kind:      string(resourceType),
+	}}
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		root.Object.Group, root.Object.Version = gvk.Group, gvk.Version
+	}
+
+	children, err := c.managedObjects(ctx, root.Object)
+	if err != nil {
+		return nil, err
+	}
+	root.Children = children
+	c.expandTree(ctx, root, map[string]bool{root.Object.String(): true}, 1)
+	return root, nil
+}
+
+// expandTree lists the objects of the Flux objects below a node. Failures
+// are kept on the node so the rest of the tree still shows.
+func (c *Client) expandTree(ctx context.Context, node *TreeNode, seen map[string]bool, depth int) {
+	for _, child := range node.Children {
+		if !managesObjects(child.Object) || seen[child.Object.String()] {
+			continue
+		}
+		if depth >= maxTreeDepth {
+			child.Err = "too deeply nested"
+			continue
+		}
+		seen[child.Object.String()] = true
+
+		children, err := c.managedObjects(ctx, child.Object)
+		if err != nil {
+			child.Err = err.Error()
+			continue
+		}
+		child.Children = children
+		c.expandTree(ctx, child, seen, depth+1)
+	}
+}
+
+// managesObjects reports whether a reference is a Flux object with children
+func managesObjects(ref ObjectRef) bool {
+	return (ref.Group == "kustomize.toolkit.fluxcd.io" && ref.Kind == "Kustomization") ||
+		(ref.Group == "helm.toolkit.fluxcd.io" && ref.Kind == "HelmRelease")
+}
+
+// managedObjects lists the objects a Kustomization or HelmRelease manages
+func (c *Client) managedObjects(ctx context.Context, ref ObjectRef) ([]*TreeNode, error) {
+	var refs []ObjectRef
+	var err error
+	switch ref.Kind {
+	case string(ResourceTypeKustomization):
+		refs, err = c.GetInventory(ctx, ref.Name, ref.Namespace)
+	case string(ResourceTypeHelmRelease):
+		refs, err = c.helmReleaseObjects(ctx, ref.Name, ref.Namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*TreeNode, 0, len(refs))
+	for _, ref := range refs {
+		nodes = append(nodes, &TreeNode{Object: ref})
+	}
+	return nodes, nil
+}
+
+// helmReleaseObjects parses the manifest of a HelmRelease's deployed Helm
+// release into the objects it rendered
+func (c *Client) helmReleaseObjects(ctx context.Context, name, namespace string) ([]ObjectRef, error) {
+	hr := &helmv2.HelmRelease{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, hr); err != nil {
+		return nil, fmt.Errorf("failed to get helmrelease %s/%s: %w", namespace, name, err)
+	}
+
+	secret, err := c.latestReleaseSecret(ctx, hr.GetReleaseName(), hr.GetStorageNamespace())
+	if err != nil || secret == nil {
+		return nil, err
+	}
+	release, err := decodeHelmRelease(secret.Data["release"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode helm release %s: %w", secret.Name, err)
+	}
+
+	releaseNamespace := release.Namespace
+	if releaseNamespace == "" {
+		releaseNamespace = hr.GetReleaseNamespace()
+	}
+	return manifestObjects(release.Manifest, releaseNamespace, c.namespaced)
+}
+
+// namespaced reports whether a kind is namespaced, assuming it is when the
+// API server does not know it
+func (c *Client) namespaced(gvk schema.GroupVersionKind) bool {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return true
+	}
+	return mapping.Scope.Name() != meta.RESTScopeNameRoot
+}
+
+// latestReleaseSecret returns the Helm storage secret of the newest deployed
+// revision of a release, or nil when it has not been installed
+func (c *Client) latestReleaseSecret(ctx context.Context, release, storageNamespace string) (*corev1.Secret, error) {
+	var secrets corev1.SecretList
+	if err := c.List(ctx, &secrets, client.InNamespace(storageNamespace),
+		client.MatchingLabels{"owner": "helm", "name": release, "status": "deployed"}); err != nil {
+		return nil, fmt.Errorf("failed to list helm releases of %s: %w", release, err)
+	}
+	if len(secrets.Items) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		return releaseVersion(secrets.Items[i]) > releaseVersion(secrets.Items[j])
+	})
+	return &secrets.Items[0], nil
+}
+
+// releaseVersion returns the revision of a Helm storage secret
+func releaseVersion(secret corev1.Secret) int {
+	version, _ := strconv.Atoi(secret.Labels["version"])
+	return version
+}
+
+// helmRelease holds the fields of a Helm release fluxcli reads
+type helmRelease struct {
+	Manifest  string `json:"manifest"`
+	Namespace string `json:"namespace"`
+}
+
+// gzipMagic prefixes the gzip'd releases Helm stores
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// decodeHelmRelease decodes a release the way Helm's secret storage encodes
+// it: JSON, gzip'd and base64 encoded
+func decodeHelmRelease(data []byte) (*helmRelease, error) {
+	if len(data) == 0 {
+		return nil, errors.New("no release data")
+	}
+	raw, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(raw, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		if raw, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	release := &helmRelease{}
+	if err := json.Unmarshal(raw, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// manifestObjects lists the objects of a multi-document YAML manifest.
+// Namespaced objects without a namespace land in the release namespace.
+func manifestObjects(manifest, namespace string, namespaced func(schema.GroupVersionKind) bool) ([]ObjectRef, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	var refs []ObjectRef
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+		}
+		if doc.Kind == "" || doc.Metadata.Name == "" {
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(doc.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+		}
+		ref := ObjectRef{
+			Namespace: doc.Metadata.Namespace,
+			Name:      doc.Metadata.Name,
+			Group:     gv.Group,
+			Version:   gv.Version,
+			Kind:      doc.Kind,
+		}
+		if ref.Namespace == "" && namespaced(gv.WithKind(doc.Kind)) {
+			ref.Namespace = namespace
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
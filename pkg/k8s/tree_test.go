@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// helmReleaseSecret encodes a release manifest the way Helm's secret storage does
+func helmReleaseSecret(t *testing.T, release, namespace, version, manifest string) *corev1.Secret {
+	raw, err := json.Marshal(map[string]interface{}{"name": release, "namespace": namespace, "manifest": manifest})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err = writer.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sh.helm.release.v1." + release + ".v" + version,
+			Namespace: namespace,
+			Labels:    map[string]string{"owner": "helm", "name": release, "status": "deployed", "version": version},
+		},
+		Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(buf.Bytes()))},
+	}
+}
+
+func TestClient_BuildDependencyTree(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+	require.NoError(t, helmv2.AddToScheme(scheme))
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Status: kustomizev1.KustomizationStatus{Inventory: &kustomizev1.ResourceInventory{Entries: []kustomizev1.ResourceRef{
+			{ID: "apps_podinfo_helm.toolkit.fluxcd.io_HelmRelease", Version: "v2"},
+			{ID: "apps_frontend_apps_Deployment", Version: "v1"},
+		}}},
+	}
+	hr := &helmv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps"}}
+	manifest := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+  namespace: apps
+`
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		ks, hr,
+		helmReleaseSecret(t, "podinfo", "apps", "1", "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: stale\n"),
+		helmReleaseSecret(t, "podinfo", "apps", "2", manifest),
+	).Build()}
+
+	tree, err := c.BuildDependencyTree(t.Context(), ResourceTypeKustomization, "apps", "flux-system")
+	require.NoError(t, err)
+	assert.Equal(t, "Kustomization/flux-system/apps", tree.Object.String())
+	assert.Equal(t, "kustomize.toolkit.fluxcd.io", tree.Object.Group)
+	require.Len(t, tree.Children, 2)
+
+	release := tree.Children[0]
+	assert.Equal(t, "HelmRelease/apps/podinfo", release.Object.String())
+	assert.Empty(t, release.Err)
+	var rendered []string
+	for _, child := range release.Children {
+		rendered = append(rendered, child.Object.String())
+	}
+	assert.Equal(t, []string{"Service/apps/podinfo", "Deployment/apps/podinfo"}, rendered, "objects of the latest revision")
+	assert.Empty(t, tree.Children[1].Children)
+
+	_, err = c.BuildDependencyTree(t.Context(), ResourceTypeGitRepository, "fleet", "flux-system")
+	assert.ErrorIs(t, err, ErrUnsupportedAction)
+}
+
+func TestClient_BuildDependencyTreeCycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	// flux-system applies itself, as flux bootstrap sets it up
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+		Status: kustomizev1.KustomizationStatus{Inventory: &kustomizev1.ResourceInventory{Entries: []kustomizev1.ResourceRef{
+			{ID: "flux-system_flux-system_kustomize.toolkit.fluxcd.io_Kustomization", Version: "v1"},
+		}}},
+	}
+	c := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(ks).Build()}
+
+	tree, err := c.BuildDependencyTree(t.Context(), ResourceTypeKustomization, "flux-system", "flux-system")
+	require.NoError(t, err)
+	require.Len(t, tree.Children, 1)
+	assert.Empty(t, tree.Children[0].Children, "the root is not expanded again")
+}
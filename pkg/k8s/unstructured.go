@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unstructuredKinds maps the resource types whose Go API modules are not
+// dependencies to the kinds they are read as, as unstructured objects
+var unstructuredKinds = map[ResourceType]schema.GroupVersionKind{
+	ResourceTypeImageRepository:       imageGroupVersion.WithKind("ImageRepository"),
+	ResourceTypeImagePolicy:           imageGroupVersion.WithKind("ImagePolicy"),
+	ResourceTypeImageUpdateAutomation: imageGroupVersion.WithKind("ImageUpdateAutomation"),
+	ResourceTypeAlert:                 notificationGroupVersion.WithKind("Alert"),
+	ResourceTypeProvider:              notificationGroupVersion.WithKind("Provider"),
+	ResourceTypeReceiver:              receiverGroupVersion.WithKind("Receiver"),
+}
+
+// isUnstructured reports whether a resource type is read as unstructured objects
+func isUnstructured(resourceType ResourceType) bool {
+	_, exists := unstructuredKinds[resourceType]
+	return exists
+}
+
+// newUnstructuredObject returns an empty unstructured object of a resource type
+func newUnstructuredObject(resourceType ResourceType) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(unstructuredKinds[resourceType])
+	return obj
+}
+
+// newUnstructuredList returns an empty unstructured list of a resource type
+func newUnstructuredList(resourceType ResourceType) *unstructured.UnstructuredList {
+	gvk := unstructuredKinds[resourceType]
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+// listUnstructured lists the objects of an unstructured resource type,
+// returning an empty list when its controller is not installed
+func (c *Client) listUnstructured(ctx context.Context, resourceType ResourceType, namespace string, options ...ListOption) ([]unstructured.Unstructured, error) {
+	list := newUnstructuredList(resourceType)
+	opts := NewListOptions(options...).clientOptions()
+	if namespace != "" && namespace != "<nil>" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	if err := c.safeList(ctx, list, opts...); err != nil {
+		if isCRDMissing(err) {
+			// CRD not available, return empty list
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %ss: %w", resourceType, err)
+	}
+
+	return list.Items, nil
+}
+
+// unstructuredResource extracts the fields every Flux kind shares from an
+// unstructured object
+func (c *Client) unstructuredResource(resourceType ResourceType, obj *unstructured.Unstructured) Resource {
+	resource := Resource{
+		Type:         resourceType,
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		Labels:       obj.GetLabels(),
+		UID:          string(obj.GetUID()),
+		SnoozedUntil: snoozedUntil(obj.GetAnnotations()),
+		Age:          time.Since(obj.GetCreationTimestamp().Time),
+		LastUpdate:   time.Now(),
+	}
+	resource.Suspended, _, _ = unstructured.NestedBool(obj.Object, "spec", "suspend")
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range conditions {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cond := Condition{
+			Type:    fmt.Sprint(fields["type"]),
+			Status:  fmt.Sprint(fields["status"]),
+			Reason:  stringField(fields, "reason"),
+			Message: stringField(fields, "message"),
+		}
+		cond.LastTransitionTime, _ = time.Parse(time.RFC3339, stringField(fields, "lastTransitionTime"))
+		resource.Conditions = append(resource.Conditions, cond)
+
+		if cond.Type == "Ready" {
+			resource.Ready = cond.Status == string(metav1.ConditionTrue)
+			resource.Status = readyStatus(metav1.ConditionStatus(cond.Status), cond.Reason)
+			resource.Message = cond.Message
+		}
+	}
+
+	rawInterval, _, _ := unstructured.NestedString(obj.Object, "spec", "interval")
+	interval, _ := time.ParseDuration(rawInterval)
+	resource.setProgress()
+	observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	c.markOverdue(&resource, obj, interval, unstructuredLastHandled(obj), observed)
+
+	return resource
+}
+
+// stringField returns a string field of a raw object, or "" when unset
+func stringField(fields map[string]interface{}, key string) string {
+	value, _ := fields[key].(string)
+	return value
+}
+
+// unstructuredLastHandled returns status.lastHandledReconcileAt of an unstructured object
+func unstructuredLastHandled(obj *unstructured.Unstructured) string {
+	value, _, _ := unstructured.NestedString(obj.Object, "status", "lastHandledReconcileAt")
+	return value
+}
@@ -0,0 +1,328 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// waitPollInterval is how often wait helpers re-fetch the watched resource
+const waitPollInterval = 2 * time.Second
+
+// revisionStatus is a point-in-time view of a resource's revision and readiness
+type revisionStatus struct {
+	Revision string
+	Ready    bool
+	Reason   string
+	Message  string
+	Observed bool // the controller has observed the latest generation
+}
+
+// WaitForRevision blocks until the resource has applied the given revision and
+// reports Ready, or until the timeout elapses. Short commit SHAs are matched as
+// prefixes of the applied revision's digest.
+func (c *Client) WaitForRevision(ctx context.Context, resourceType ResourceType, name, namespace, revision string, timeout time.Duration) error {
+	if revision == "" {
+		return fmt.Errorf("revision must not be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var last revisionStatus
+	for {
+		status, err := c.getRevisionStatus(ctx, resourceType, name, namespace)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		if err == nil {
+			last = status
+			if last.Ready && RevisionMatches(last.Revision, revision) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for %s %s/%s to reach revision %s (last applied revision: %q, ready: %t, message: %q)",
+				timeout, resourceType, namespace, name, revision, last.Revision, last.Ready, last.Message)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForReady blocks until the resource reports Ready for its latest
+// generation, or until the timeout elapses. On timeout the error carries the
+// last Ready condition seen.
+func (c *Client) WaitForReady(ctx context.Context, resourceType ResourceType, name, namespace string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	last, err := c.waitForReady(ctx, Resource{Type: resourceType, Name: name, Namespace: namespace})
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled):
+		return fmt.Errorf("timed out after %s waiting for %s %s/%s to become ready: %w", timeout, resourceType, namespace, name, err)
+	case last.Ready && !last.Observed:
+		return fmt.Errorf("timed out after %s waiting for %s %s/%s to become ready (latest generation not yet reconciled)", timeout, resourceType, namespace, name)
+	}
+	return fmt.Errorf("timed out after %s waiting for %s %s/%s to become ready (reason: %q, message: %q)",
+		timeout, resourceType, namespace, name, last.Reason, last.Message)
+}
+
+// progressingReasons are Ready=False reasons that indicate a resource is still converging
+var progressingReasons = map[string]bool{
+	"Progressing":          true,
+	"ProgressingWithRetry": true,
+	"DependencyNotReady":   true,
+}
+
+// WaitForAll blocks until every resource reports Ready for its latest
+// generation, or until the timeout elapses. On timeout the error summarizes
+// the status of each resource.
+func (c *Client) WaitForAll(ctx context.Context, resources []Resource, timeout time.Duration) error {
+	if len(resources) == 0 {
+		return fmt.Errorf("no resources to wait for")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statuses := make([]revisionStatus, len(resources))
+	errs := make([]error, len(resources))
+
+	var g errgroup.Group
+	for i, resource := range resources {
+		g.Go(func() error {
+			statuses[i], errs[i] = c.waitForReady(ctx, resource)
+			return errs[i]
+		})
+	}
+	if g.Wait() == nil {
+		return nil
+	}
+
+	summary := formatWaitSummary(resources, statuses, errs)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		// The caller gave up, e.g. on ctrl+c, before the timeout
+		return fmt.Errorf("cancelled waiting for %d resources: %w\n%s", len(resources), ctx.Err(), summary)
+	}
+	return fmt.Errorf("timed out after %s waiting for %d resources:\n%s", timeout, len(resources), summary)
+}
+
+// waitForReady polls a resource until it is Ready for its latest generation.
+// Fetch errors are retried until the context expires, since resources may
+// not have been created yet when the wait starts.
+func (c *Client) waitForReady(ctx context.Context, resource Resource) (revisionStatus, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var last revisionStatus
+	var lastErr error
+	for {
+		status, err := c.getRevisionStatus(ctx, resource.Type, resource.Name, resource.Namespace)
+		if err == nil {
+			last, lastErr = status, nil
+			if last.Ready && last.Observed {
+				return last, nil
+			}
+		} else if ctx.Err() == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, lastErr
+			}
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatWaitSummary renders one line per resource with its final wait status
+func formatWaitSummary(resources []Resource, statuses []revisionStatus, errs []error) string {
+	var b strings.Builder
+	for i, resource := range resources {
+		status := statuses[i]
+		state := "Pending"
+		detail := status.Message
+
+		switch {
+		case errs[i] == nil:
+			state = "Ready"
+			detail = ""
+		case !errors.Is(errs[i], context.DeadlineExceeded) && !errors.Is(errs[i], context.Canceled):
+			state = "Error"
+			detail = errs[i].Error()
+		case !status.Ready && status.Reason != "" && !progressingReasons[status.Reason]:
+			state = "Failed"
+			detail = fmt.Sprintf("%s: %s", status.Reason, status.Message)
+		case status.Ready && !status.Observed:
+			detail = "latest generation not yet reconciled"
+		}
+
+		fmt.Fprintf(&b, "  %-8s %s %s/%s", state, resource.Type, resource.Namespace, resource.Name)
+		if detail != "" {
+			fmt.Fprintf(&b, ": %s", detail)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// getRevisionStatus fetches a resource and extracts its current revision and Ready condition
+func (c *Client) getRevisionStatus(ctx context.Context, resourceType ResourceType, name, namespace string) (revisionStatus, error) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	var status revisionStatus
+	var conditions []metav1.Condition
+
+	switch resourceType {
+	case ResourceTypeGitRepository:
+		obj := &sourcev1.GitRepository{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		if obj.Status.Artifact != nil {
+			status.Revision = obj.Status.Artifact.Revision
+		}
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeHelmRepository:
+		obj := &sourcev1beta2.HelmRepository{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		if obj.Status.Artifact != nil {
+			status.Revision = obj.Status.Artifact.Revision
+		}
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeKustomization:
+		obj := &kustomizev1.Kustomization{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		status.Revision = obj.Status.LastAppliedRevision
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeHelmRelease:
+		obj := &helmv2.HelmRelease{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		status.Revision = obj.Status.LastAppliedRevision
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeOCIRepository:
+		obj := &sourcev1beta2.OCIRepository{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		if obj.Status.Artifact != nil {
+			status.Revision = obj.Status.Artifact.Revision
+		}
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeBucket:
+		obj := &sourcev1beta2.Bucket{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		if obj.Status.Artifact != nil {
+			status.Revision = obj.Status.Artifact.Revision
+		}
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	case ResourceTypeHelmChart:
+		obj := &sourcev1.HelmChart{}
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		if obj.Status.Artifact != nil {
+			status.Revision = obj.Status.Artifact.Revision
+		}
+		conditions = obj.Status.Conditions
+		status.Observed = obj.Status.ObservedGeneration >= obj.Generation
+	default:
+		if !isUnstructured(resourceType) {
+			return status, fmt.Errorf("unsupported resource type: %s", resourceType)
+		}
+		obj := newUnstructuredObject(resourceType)
+		if err := c.Get(ctx, key, obj); err != nil {
+			return status, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+		}
+		switch resourceType {
+		case ResourceTypeImagePolicy:
+			status.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "latestImage")
+		case ResourceTypeImageUpdateAutomation:
+			status.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "lastPushCommit")
+		}
+		for _, cond := range c.unstructuredResource(resourceType, obj).Conditions {
+			conditions = append(conditions, metav1.Condition{Type: cond.Type, Status: metav1.ConditionStatus(cond.Status), Reason: cond.Reason, Message: cond.Message})
+		}
+		observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+		status.Observed = observed >= obj.GetGeneration()
+	}
+
+	for _, cond := range conditions {
+		if cond.Type == "Ready" {
+			status.Ready = cond.Status == metav1.ConditionTrue
+			status.Reason = cond.Reason
+			status.Message = cond.Message
+		}
+	}
+
+	return status, nil
+}
+
+// RevisionMatches reports whether an applied Flux revision (e.g. "main@sha1:abc123...")
+// matches the wanted revision. The wanted revision may be the full revision string or
+// a (possibly shortened) commit SHA or digest.
+func RevisionMatches(applied, wanted string) bool {
+	if applied == "" || wanted == "" {
+		return false
+	}
+	if applied == wanted {
+		return true
+	}
+
+	appliedDigest := revisionDigest(applied)
+	wantedDigest := revisionDigest(wanted)
+	if appliedDigest == "" || wantedDigest == "" {
+		return false
+	}
+
+	return strings.HasPrefix(appliedDigest, wantedDigest)
+}
+
+// revisionDigest extracts the commit SHA or digest from a Flux revision string.
+// It understands both the "<ref>@<algo>:<digest>" and legacy "<ref>/<sha>" formats.
+func revisionDigest(revision string) string {
+	if i := strings.LastIndex(revision, ":"); i >= 0 {
+		return revision[i+1:]
+	}
+	if i := strings.LastIndex(revision, "/"); i >= 0 {
+		return revision[i+1:]
+	}
+	if i := strings.LastIndex(revision, "@"); i >= 0 {
+		return revision[i+1:]
+	}
+	return revision
+}
@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRevisionMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied string
+		wanted  string
+		match   bool
+	}{
+		{"exact", "main@sha1:abc123def456", "main@sha1:abc123def456", true},
+		{"short sha", "main@sha1:abc123def456", "abc123", true},
+		{"full sha", "main@sha1:abc123def456", "abc123def456", true},
+		{"legacy format", "main/abc123def456", "abc123", true},
+		{"different sha", "main@sha1:abc123def456", "def456", false},
+		{"oci digest", "latest@sha256:0123456789ab", "sha256:0123", true},
+		{"empty applied", "", "abc123", false},
+		{"empty wanted", "main@sha1:abc123", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.match, RevisionMatches(tt.applied, tt.wanted))
+		})
+	}
+}
+
+func TestFormatWaitSummary(t *testing.T) {
+	resources := []Resource{
+		{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Type: ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+		{Type: ResourceTypeKustomization, Name: "db", Namespace: "flux-system"},
+		{Type: ResourceTypeKustomization, Name: "missing", Namespace: "flux-system"},
+	}
+	statuses := []revisionStatus{
+		{Ready: true, Observed: true},
+		{Reason: "Progressing", Message: "reconciliation in progress"},
+		{Reason: "HealthCheckFailed", Message: "timeout waiting for: [Deployment/db/postgres]"},
+		{},
+	}
+	errs := []error{
+		nil,
+		context.DeadlineExceeded,
+		context.DeadlineExceeded,
+		fmt.Errorf("failed to get Kustomization/missing: not found"),
+	}
+
+	summary := formatWaitSummary(resources, statuses, errs)
+	lines := strings.Split(summary, "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "Ready    Kustomization flux-system/apps")
+	assert.Contains(t, lines[1], "Pending  Kustomization flux-system/infra: reconciliation in progress")
+	assert.Contains(t, lines[2], "Failed   Kustomization flux-system/db: HealthCheckFailed")
+	assert.Contains(t, lines[3], "Error    Kustomization flux-system/missing: failed to get")
+}
+
+func TestClient_WaitForReady(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	kustomization := func(name string, ready metav1.ConditionStatus, reason, message string) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Generation: 2},
+			Status: kustomizev1.KustomizationStatus{
+				ObservedGeneration: 2,
+				Conditions:         []metav1.Condition{{Type: "Ready", Status: ready, Reason: reason, Message: message}},
+			},
+		}
+	}
+	ctrl := ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		kustomization("apps", metav1.ConditionTrue, "ReconciliationSucceeded", "Applied revision: main@sha1:abc"),
+		kustomization("db", metav1.ConditionFalse, "HealthCheckFailed", "timeout waiting for: [Deployment/db/postgres]"),
+	).Build()
+	c := &Client{Client: ctrl}
+
+	require.NoError(t, c.WaitForReady(context.Background(), ResourceTypeKustomization, "apps", "flux-system", time.Second))
+
+	// The final condition explains the failure
+	err := c.WaitForReady(context.Background(), ResourceTypeKustomization, "db", "flux-system", 50*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 50ms waiting for Kustomization flux-system/db to become ready")
+	assert.Contains(t, err.Error(), `reason: "HealthCheckFailed", message: "timeout waiting for: [Deployment/db/postgres]"`)
+
+	// Fetch errors are reported once the wait gives up
+	err = c.WaitForReady(context.Background(), ResourceTypeKustomization, "missing", "flux-system", 50*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get Kustomization/missing")
+}
+
+func TestClient_WaitForAll(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	ctrl := ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{
+				Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Progressing", Message: "reconciliation in progress"}},
+			},
+		},
+	).Build()
+	c := &Client{Client: ctrl}
+	resources := []Resource{{Type: ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}}
+
+	err := c.WaitForAll(context.Background(), resources, 50*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 50ms waiting for 1 resources")
+
+	// Cancelling isn't reported as a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	err = c.WaitForAll(ctx, resources, time.Minute)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, err.Error(), "timed out")
+	assert.Contains(t, err.Error(), "Pending  Kustomization flux-system/apps: reconciliation in progress")
+}
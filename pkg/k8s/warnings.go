@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// WarningCollector is a rest.WarningHandler that records API server warnings
+// (such as deprecated API versions) so each distinct warning is surfaced once
+type WarningCollector struct {
+	mu      sync.Mutex
+	seen    map[string]bool
+	pending []string
+	out     io.Writer
+}
+
+// NewWarningCollector creates a new warning collector
+func NewWarningCollector() *WarningCollector {
+	return &WarningCollector{
+		seen: make(map[string]bool),
+	}
+}
+
+// SetOutput makes the collector write new warnings to out instead of queueing
+// them, which is used by headless commands that have no UI to surface them in
+func (w *WarningCollector) SetOutput(out io.Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.out = out
+}
+
+// HandleWarningHeader implements rest.WarningHandler
+func (w *WarningCollector) HandleWarningHeader(code int, agent string, message string) {
+	// Only 299 "Miscellaneous persistent warning" headers carry API warnings
+	if code != 299 || message == "" {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.seen[message] {
+		return
+	}
+	w.seen[message] = true
+
+	if w.out != nil {
+		fmt.Fprintf(w.out, "Warning: %s\n", message)
+		return
+	}
+	w.pending = append(w.pending, message)
+}
+
+// Notify surfaces a client-side warning. Unlike API server warnings it is
+// not deduplicated, since callers decide how often it recurs.
+func (w *WarningCollector) Notify(message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.out != nil {
+		fmt.Fprintf(w.out, "Warning: %s\n", message)
+		return
+	}
+	w.pending = append(w.pending, message)
+}
+
+// Drain returns warnings received since the last call
+func (w *WarningCollector) Drain() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	warnings := w.pending
+	w.pending = nil
+	return warnings
+}
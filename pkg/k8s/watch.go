@@ -0,0 +1,226 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// watchedTypes are the kinds StartWatching serves from a shared cache. The
+// remaining kinds are listed from the API server on every refresh.
+var watchedTypes = []ResourceType{
+	ResourceTypeGitRepository,
+	ResourceTypeHelmRepository,
+	ResourceTypeKustomization,
+	ResourceTypeHelmRelease,
+}
+
+// watchSyncTimeout bounds how long a kind's initial list may take before it
+// falls back to direct lists
+const watchSyncTimeout = 30 * time.Second
+
+// watchState tracks the kinds a client serves from its cache
+type watchState struct {
+	reader  client.Reader
+	mu      sync.RWMutex
+	synced  map[ResourceType]bool
+	changes chan ResourceType
+}
+
+// newWatchState creates the watch state of a cache
+func newWatchState(reader client.Reader) *watchState {
+	return &watchState{
+		reader:  reader,
+		synced:  make(map[ResourceType]bool),
+		changes: make(chan ResourceType, 64),
+	}
+}
+
+// isSynced reports whether a kind is served from the cache
+func (w *watchState) isSynced(resourceType ResourceType) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.synced[resourceType]
+}
+
+// setSynced starts serving a kind from the cache
+func (w *watchState) setSynced(resourceType ResourceType) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.synced[resourceType] = true
+}
+
+// notify reports a change to a watched kind. Changes arriving while the
+// buffer is full are dropped; the next periodic refresh picks them up.
+func (w *watchState) notify(resourceType ResourceType) {
+	select {
+	case w.changes <- resourceType:
+	default:
+	}
+}
+
+// StartWatching serves the lists of the core Flux kinds from a cache that
+// watches them, and returns a channel receiving the type of every resource
+// that changes. It blocks until the initial lists have synced. Kinds that may
+// not be listed and watched across all namespaces, or fail to sync, keep
+// being listed directly. The cache stops when ctx is done.
+func (c *Client) StartWatching(ctx context.Context) (<-chan ResourceType, error) {
+	if c.Config == nil {
+		return nil, fmt.Errorf("failed to start watching: no rest config")
+	}
+
+	informers, err := cache.New(c.Config, cache.Options{
+		Scheme:           c.Scheme(),
+		Mapper:           c.RESTMapper(),
+		DefaultTransform: cache.TransformStripManagedFields(),
+		// Swallow watch errors instead of letting klog print them over the TUI
+		DefaultWatchErrorHandler: func(ctx context.Context, r *toolscache.Reflector, err error) {},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	go func() {
+		_ = informers.Start(ctx)
+	}()
+	// Informers only block until synced once the cache runs
+	if !informers.WaitForCacheSync(ctx) {
+		return nil, fmt.Errorf("failed to start cache: %w", ctx.Err())
+	}
+
+	state := newWatchState(informers)
+	c.watch.Store(state)
+
+	var errs []error
+	watched := 0
+	for _, resourceType := range watchedTypes {
+		if err := c.watchType(ctx, informers, state, resourceType); err != nil {
+			if meta.IsNoMatchError(err) {
+				// Not installed, there is nothing to poll either
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		watched++
+	}
+
+	if watched == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		if c.Warnings != nil {
+			c.Warnings.Notify(fmt.Sprintf("%v, listing directly instead", err))
+		}
+	}
+	return state.changes, nil
+}
+
+// watchType starts the informer of a kind and serves it from the cache once
+// it has synced
+func (c *Client) watchType(ctx context.Context, informers cache.Cache, state *watchState, resourceType ResourceType) error {
+	obj, err := newObject(resourceType)
+	if err != nil {
+		return err
+	}
+
+	if err := c.canWatch(ctx, obj); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", resourceType, err)
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, watchSyncTimeout)
+	defer cancel()
+
+	informer, err := informers.GetInformer(syncCtx, obj)
+	if err != nil {
+		_ = informers.RemoveInformer(ctx, obj)
+		return fmt.Errorf("failed to watch %s: %w", resourceType, err)
+	}
+
+	notify := func(interface{}) { state.notify(resourceType) }
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, obj interface{}) { notify(obj) },
+		DeleteFunc: notify,
+	}); err != nil {
+		_ = informers.RemoveInformer(ctx, obj)
+		return fmt.Errorf("failed to watch %s: %w", resourceType, err)
+	}
+
+	state.setSynced(resourceType)
+	return nil
+}
+
+// canWatch checks that the user may list and watch a kind in all namespaces,
+// which the cache needs
+func (c *Client) canWatch(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	for _, verb := range []string{"list", "watch"} {
+		review, err := c.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    mapping.Resource.Group,
+					Resource: mapping.Resource.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to check permissions: %w", err)
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("not allowed to %s %s in all namespaces", verb, mapping.Resource.Resource)
+		}
+	}
+	return nil
+}
+
+// reader returns the cache for lists of kinds it serves, and the API server
+// otherwise, reporting whether the cache was chosen
+func (c *Client) reader(list client.ObjectList) (client.Reader, bool) {
+	state := c.watch.Load()
+	if state == nil {
+		return c.Client, false
+	}
+
+	var resourceType ResourceType
+	switch list.(type) {
+	case *sourcev1.GitRepositoryList:
+		resourceType = ResourceTypeGitRepository
+	case *sourcev1beta2.HelmRepositoryList:
+		resourceType = ResourceTypeHelmRepository
+	case *kustomizev1.KustomizationList:
+		resourceType = ResourceTypeKustomization
+	case *helmv2.HelmReleaseList:
+		resourceType = ResourceTypeHelmRelease
+	default:
+		return c.Client, false
+	}
+
+	if state.isSynced(resourceType) {
+		return state.reader, true
+	}
+	return c.Client, false
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient_ListsFromWatchCache(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, kustomizev1.AddToScheme(scheme))
+
+	direct := &Client{Client: ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "from-api", Namespace: "flux-system"}},
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "from-api", Namespace: "flux-system"}},
+	).Build()}
+	cached := ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "from-cache", Namespace: "flux-system"}},
+		&kustomizev1.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "from-cache", Namespace: "flux-system"}},
+	).Build()
+
+	state := newWatchState(cached)
+	state.setSynced(ResourceTypeGitRepository)
+	direct.watch.Store(state)
+
+	repos, err := direct.ListGitRepositories(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "from-cache", repos[0].Name)
+
+	// Kinds that have not synced are still listed from the API server
+	kustomizations, err := direct.ListKustomizations(t.Context(), "flux-system")
+	require.NoError(t, err)
+	require.Len(t, kustomizations, 1)
+	assert.Equal(t, "from-api", kustomizations[0].Name)
+
+	// A full buffer drops changes instead of blocking the informer
+	for i := 0; i < cap(state.changes)+1; i++ {
+		state.notify(ResourceTypeGitRepository)
+	}
+	assert.Len(t, state.changes, cap(state.changes))
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// GetResourceYAML returns the full manifest of a FluxCD resource as YAML,
+// similar to `kubectl get -o yaml` but without managedFields
+func (c *Client) GetResourceYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	obj, err := c.getObject(ctx, resourceType, name, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return c.marshalYAML(obj)
+}
+
+// GetComparableYAML returns the manifest of a FluxCD resource with server-generated
+// metadata (uid, resourceVersion, timestamps) removed, so the same resource from
+// different clusters can be diffed meaningfully
+func (c *Client) GetComparableYAML(ctx context.Context, resourceType ResourceType, name, namespace string) (string, error) {
+	obj, err := c.getObject(ctx, resourceType, name, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+
+	return c.marshalYAML(obj)
+}
+
+// getObject fetches a single typed FluxCD object
+func (c *Client) getObject(ctx context.Context, resourceType ResourceType, name, namespace string) (client.Object, error) {
+	obj, err := c.fetchObject(ctx, resourceType, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %w", resourceType, name, err)
+	}
+
+	return obj, nil
+}
+
+// fetchObject gets a single object, falling back to the v1 HelmRepository on
+// clusters that no longer serve v1beta2. Errors are returned unwrapped.
+func (c *Client) fetchObject(ctx context.Context, resourceType ResourceType, name, namespace string) (client.Object, error) {
+	obj, err := newObject(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	err = c.Get(ctx, key, obj)
+	if err != nil && resourceType == ResourceTypeHelmRepository && meta.IsNoMatchError(err) {
+		repo := &sourcev1.HelmRepository{}
+		if err := c.Get(ctx, key, repo); err != nil {
+			return nil, err
+		}
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// marshalYAML serializes an object to YAML with its apiVersion and kind populated
+func (c *Client) marshalYAML(obj client.Object) (string, error) {
+	// Typed objects returned by the client have an empty TypeMeta
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return "", fmt.Errorf("failed to determine kind of %s: %w", obj.GetName(), err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s/%s to YAML: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// family is a metric with its help text and type, in exposition order
+type family struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+// sample is a single value of a metric family
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+// compute builds the resource health metric families of a set of resources
+func compute(resources []k8s.Resource) []family {
+	ready := family{name: "fluxcli_resource_ready", help: "Whether the resource reports Ready=True (1) or not (0).", kind: "gauge"}
+	suspended := family{name: "fluxcli_resource_suspended", help: "Whether reconciliation of the resource is suspended (1) or not (0).", kind: "gauge"}
+	overdue := family{name: "fluxcli_resource_overdue", help: "Whether the resource went longer than its interval plus margin without reconciling (1) or not (0).", kind: "gauge"}
+	readySince := family{name: "fluxcli_resource_ready_transition_timestamp_seconds", help: "Unix time of the resource's last Ready condition transition.", kind: "gauge"}
+	totals := family{name: "fluxcli_resources", help: "Number of resources by type and readiness.", kind: "gauge"}
+	suspendedTotals := family{name: "fluxcli_resources_suspended", help: "Number of suspended resources by type.", kind: "gauge"}
+
+	type countKey struct {
+		cluster      string
+		resourceType k8s.ResourceType
+		ready        bool
+	}
+	counts := make(map[countKey]int)
+	type suspendedKey struct {
+		cluster      string
+		resourceType k8s.ResourceType
+	}
+	suspendedCounts := make(map[suspendedKey]int)
+
+	for _, r := range resources {
+		labels := [][2]string{
+			{"cluster", r.Cluster},
+			{"type", string(r.Type)},
+			{"namespace", r.Namespace},
+			{"name", r.Name},
+		}
+		ready.samples = append(ready.samples, sample{labels: labels, value: boolValue(r.Ready)})
+		suspended.samples = append(suspended.samples, sample{labels: labels, value: boolValue(r.Suspended)})
+		overdue.samples = append(overdue.samples, sample{labels: labels, value: boolValue(r.Overdue)})
+		if !r.ReadySince.IsZero() {
+			readySince.samples = append(readySince.samples, sample{labels: labels, value: float64(r.ReadySince.Unix())})
+		}
+		counts[countKey{r.Cluster, r.Type, r.Ready}]++
+		if r.Suspended {
+			suspendedCounts[suspendedKey{r.Cluster, r.Type}]++
+		}
+	}
+
+	for key, count := range counts {
+		totals.samples = append(totals.samples, sample{
+			labels: [][2]string{
+				{"cluster", key.cluster},
+				{"type", string(key.resourceType)},
+				{"ready", fmt.Sprintf("%t", key.ready)},
+			},
+			value: float64(count),
+		})
+	}
+	for key, count := range suspendedCounts {
+		suspendedTotals.samples = append(suspendedTotals.samples, sample{
+			labels: [][2]string{
+				{"cluster", key.cluster},
+				{"type", string(key.resourceType)},
+			},
+			value: float64(count),
+		})
+	}
+	// Map iteration is random, keep the output stable between dumps
+	sortSamples(totals.samples)
+	sortSamples(suspendedTotals.samples)
+
+	return []family{ready, suspended, overdue, readySince, totals, suspendedTotals}
+}
+
+// WriteText writes the metrics of a set of resources in the Prometheus text
+// exposition format
+func WriteText(w io.Writer, resources []k8s.Resource) error {
+	var b strings.Builder
+	for _, f := range compute(resources) {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+		for _, s := range f.samples {
+			fmt.Fprintf(&b, "%s%s %s\n", f.name, labelString(s.labels), strconv.FormatFloat(s.value, 'f', -1, 64))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	return nil
+}
+
+// sortSamples orders samples by their labels
+func sortSamples(samples []sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return labelString(samples[i].labels) < labelString(samples[j].labels)
+	})
+}
+
+// labelString renders a label set as {k="v",...}, omitting empty values
+func labelString(labels [][2]string) string {
+	var parts []string
+	for _, label := range labels {
+		if label[1] == "" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, label[0], escapeLabel(label[1])))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// labelEscaper escapes label values as required by the exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes help text, where quotes are left as is
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+// boolValue converts a boolean to a gauge value
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
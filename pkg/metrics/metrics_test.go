@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+func TestWriteText(t *testing.T) {
+	resources := []k8s.Resource{
+		{Cluster: "prod", Type: k8s.ResourceTypeKustomization, Namespace: "flux-system", Name: "apps", Ready: true, ReadySince: time.Unix(1700000000, 0), LastUpdate: time.Unix(1800000000, 0)},
+		{Cluster: "prod", Type: k8s.ResourceTypeKustomization, Namespace: "flux-system", Name: `we"ird\name`, Suspended: true},
+	}
+
+	var b strings.Builder
+	require.NoError(t, WriteText(&b, resources))
+	out := b.String()
+
+	assert.Contains(t, out, "# HELP fluxcli_resource_ready Whether the resource reports Ready=True (1) or not (0).\n# TYPE fluxcli_resource_ready gauge\n")
+	assert.Contains(t, out, `fluxcli_resource_ready{cluster="prod",type="Kustomization",namespace="flux-system",name="apps"} 1`)
+	assert.Contains(t, out, `fluxcli_resource_suspended{cluster="prod",type="Kustomization",namespace="flux-system",name="we\"ird\\name"} 1`)
+	assert.Contains(t, out, "# HELP fluxcli_resource_ready_transition_timestamp_seconds Unix time of the resource's last Ready condition transition.\n")
+	assert.Contains(t, out, `fluxcli_resource_ready_transition_timestamp_seconds{cluster="prod",type="Kustomization",namespace="flux-system",name="apps"} 1700000000`)
+	assert.Contains(t, out, `fluxcli_resources{cluster="prod",type="Kustomization",ready="false"} 1`)
+	assert.Contains(t, out, `fluxcli_resources{cluster="prod",type="Kustomization",ready="true"} 1`)
+}
+
+func TestEscapeLabel(t *testing.T) {
+	assert.Equal(t, `a\\b\"c\nd`, escapeLabel("a\\b\"c\nd"))
+}
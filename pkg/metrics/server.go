@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// Source provides the resources and health the server reports
+type Source interface {
+	// Snapshot returns the resources of the latest refresh
+	Snapshot() []k8s.Resource
+	// Healthy returns why the source cannot serve metrics, nil when it can
+	Healthy() error
+}
+
+// Handler serves the metrics of a source on /metrics and its health on
+// /healthz
+func Handler(source Source) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WriteText(w, source.Snapshot())
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if err := source.Healthy(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	return mux
+}
+
+// Serve serves the metrics of a source on a listener until ctx is done
+func Serve(ctx context.Context, listener net.Listener, source Source) error {
+	server := &http.Server{
+		Handler:           Handler(source),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	return nil
+}
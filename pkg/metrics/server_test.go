@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// staticSource serves fixed resources and health
+type staticSource struct {
+	resources []k8s.Resource
+	err       error
+}
+
+func (s staticSource) Snapshot() []k8s.Resource { return s.resources }
+
+func (s staticSource) Healthy() error { return s.err }
+
+func TestHandler(t *testing.T) {
+	source := staticSource{resources: []k8s.Resource{
+		{Cluster: "prod", Type: k8s.ResourceTypeHelmRelease, Namespace: "default", Name: "podinfo", Suspended: true},
+	}}
+
+	rec := httptest.NewRecorder()
+	Handler(source).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), `fluxcli_resources_suspended{cluster="prod",type="HelmRelease"} 1`)
+
+	rec = httptest.NewRecorder()
+	Handler(source).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Unhealthy sources fail the health check with the reason
+	source.err = errors.New("no cluster connected")
+	rec = httptest.NewRecorder()
+	Handler(source).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no cluster connected")
+}
@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// actionDescriptions are the menu labels of each action
+var actionDescriptions = map[k8s.Action]string{
+	k8s.ActionReconcile: "Trigger reconciliation",
+	k8s.ActionReconcileWithSource: "Fetch the source, then reconcile",
+	k8s.ActionSuspend:   "Suspend reconciliation",
+	k8s.ActionResume:    "Resume reconciliation",
+	k8s.ActionReset:     "Reset remediation retries",
+	k8s.ActionSnooze:    "Mute in triage views for 1h",
+	k8s.ActionUnsnooze:  "Lift the snooze",
+	k8s.ActionDelete:    "Delete the object",
+}
+
+// defaultSnooze is how long a resource is snoozed when no duration is given
+const defaultSnooze = time.Hour
+
+// actionMenu is the quick actions menu of a single resource
+type actionMenu struct {
+	resource k8s.Resource
+	actions  []k8s.Action
+	cursor   int
+}
+
+// newActionMenu builds the menu of actions valid for a resource, offering
+// only the one of suspend/resume and snooze/unsnooze that applies. Returns nil
+// when there are none.
+func newActionMenu(resource k8s.Resource) *actionMenu {
+	snoozed := resource.Snoozed(time.Now())
+	var actions []k8s.Action
+	for _, action := range k8s.SupportedActions(resource.Type) {
+		if (action == k8s.ActionSuspend && resource.Suspended) || (action == k8s.ActionResume && !resource.Suspended) {
+			continue
+		}
+		if (action == k8s.ActionSnooze && snoozed) || (action == k8s.ActionUnsnooze && !snoozed) {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	return &actionMenu{resource: resource, actions: actions}
+}
+
+// openActionMenu opens the quick actions menu for the selected resource
+func (m *AppModel) openActionMenu() {
+	resource := m.selectedResource()
+	if resource == nil {
+		return
+	}
+
+	m.menu = newActionMenu(*resource)
+	if m.menu == nil {
+		m.statusMessage = fmt.Sprintf("No actions available for %s", resource.Type)
+	}
+}
+
+// handleMenu handles keyboard input while the quick actions menu is open
+func (m *AppModel) handleMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.menu
+
+	switch key := msg.String(); key {
+	case "esc", "a", "q", "ctrl+c":
+		m.menu = nil
+	case "j", "down":
+		menu.cursor = (menu.cursor + 1) % len(menu.actions)
+	case "k", "up":
+		menu.cursor = (menu.cursor - 1 + len(menu.actions)) % len(menu.actions)
+	case "enter":
+		m.menu = nil
+		return m, m.runAction(menu.resource, menu.actions[menu.cursor])
+	default:
+		// Digits pick an item directly
+		if len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < len(menu.actions) {
+			m.menu = nil
+			return m, m.runAction(menu.resource, menu.actions[key[0]-'1'])
+		}
+	}
+
+	return m, nil
+}
+
+// runAction runs an action on a resource, asking for confirmation where
+// configured. The resource carries its own cluster and namespace, so menus
+// opened from the detail or watch view, all namespaces or a fleet row act on
+// the object they were opened for.
+func (m *AppModel) runAction(resource k8s.Resource, action k8s.Action) tea.Cmd {
+	name := resource.Name
+	switch action {
+	case k8s.ActionSuspend:
+		return m.confirmAction(action, resource.Type, name, func() tea.Cmd {
+			if err := m.manager.SuspendResource(resource); err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to suspend %s: %v", name, err)
+			} else {
+				m.statusMessage = fmt.Sprintf("Suspended %s", name)
+			}
+			return nil
+		})
+	case k8s.ActionResume:
+		return m.confirmAction(action, resource.Type, name, func() tea.Cmd {
+			if err := m.manager.ResumeResource(resource); err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to resume %s: %v", name, err)
+			} else {
+				m.statusMessage = fmt.Sprintf("Resumed %s", name)
+			}
+			return nil
+		})
+	case k8s.ActionReconcile:
+		return m.confirmAction(action, resource.Type, name, func() tea.Cmd {
+			m.reconcile(resource)
+			return nil
+		})
+	case k8s.ActionReconcileWithSource:
+		return m.confirmAction(action, resource.Type, name, func() tea.Cmd {
+			return m.reconcileWithSource(resource)
+		})
+	case k8s.ActionReset:
+		m.confirm = &confirmPrompt{
+			message: fmt.Sprintf("Reset remediation retries of %s %s? [y/N]", resource.Type, name),
+			onConfirm: func() tea.Cmd {
+				if err := m.manager.ResetHelmRelease(resource); err != nil {
+					m.errorMessage = fmt.Sprintf("Failed to reset %s: %v", name, err)
+				} else {
+					m.statusMessage = fmt.Sprintf("Reset remediation retries for %s", name)
+				}
+				return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+			},
+		}
+	case k8s.ActionDelete:
+		return m.confirmDelete(resource)
+	case k8s.ActionSnooze:
+		m.snooze(resource, defaultSnooze)
+	case k8s.ActionUnsnooze:
+		if err := m.manager.SnoozeResource(resource, time.Time{}); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to unsnooze %s: %v", name, err)
+		} else {
+			m.statusMessage = fmt.Sprintf("Unsnoozed %s", name)
+		}
+	}
+	return nil
+}
+
+// snooze mutes a resource in triage views for a while
+func (m *AppModel) snooze(resource k8s.Resource, duration time.Duration) {
+	until := time.Now().Add(duration)
+	if err := m.manager.SnoozeResource(resource, until); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to snooze %s: %v", resource.Name, err)
+	} else {
+		m.statusMessage = fmt.Sprintf("Snoozed %s until %s", resource.Name, until.Format("15:04"))
+	}
+}
+
+// needsConfirm reports whether the config asks before running an action
+func (m *AppModel) needsConfirm(action k8s.Action) bool {
+	confirm := m.config.UI.Confirm
+	switch action {
+	case k8s.ActionSuspend:
+		return confirm.Suspend
+	case k8s.ActionResume:
+		return confirm.Resume
+	case k8s.ActionReconcile, k8s.ActionReconcileWithSource:
+		return confirm.Reconcile
+	case k8s.ActionDelete:
+		return confirm.Delete
+	}
+	return false
+}
+
+// confirmAction runs an action on a resource, asking first when configured.
+// Esc and n cancel the prompt, enter and y run the action.
+func (m *AppModel) confirmAction(action k8s.Action, resourceType k8s.ResourceType, name string, run func() tea.Cmd) tea.Cmd {
+	if !m.needsConfirm(action) {
+		return run()
+	}
+
+	message := fmt.Sprintf("Fetch the source of %s %s, then reconcile it? [y/N]", resourceType, name)
+	if action != k8s.ActionReconcileWithSource {
+		verb := string(action)
+		message = fmt.Sprintf("%s %s %s? [y/N]", strings.ToUpper(verb[:1])+verb[1:], resourceType, name)
+	}
+	m.confirm = &confirmPrompt{message: message, onConfirm: run}
+	return nil
+}
+
+// confirmDelete asks for the name of a resource before deleting it, unless
+// delete confirmations are turned off. Only the object is deleted: for a
+// Kustomization with prune enabled the controller then garbage collects the
+// objects it applied.
+func (m *AppModel) confirmDelete(resource k8s.Resource) tea.Cmd {
+	resourceType, name := resource.Type, resource.Name
+	run := func() tea.Cmd {
+		if err := m.manager.DeleteResource(resource); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to delete %s: %v", name, err)
+		} else {
+			m.statusMessage = fmt.Sprintf("Deleted %s", name)
+		}
+		return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+	if !m.needsConfirm(k8s.ActionDelete) {
+		return run()
+	}
+
+	message := fmt.Sprintf("Delete %s %s? Type its name and press enter, or [y/N]:", resourceType, name)
+	if resourceType == k8s.ResourceTypeKustomization {
+		message = fmt.Sprintf("Delete %s %s? Its objects are pruned if prune is enabled. Type its name and press enter, or [y/N]:", resourceType, name)
+	}
+	m.confirm = &confirmPrompt{
+		message:   message,
+		expect:    name,
+		onConfirm: run,
+	}
+	return nil
+}
+
+// renderMenu renders the quick actions menu
+func (m *AppModel) renderMenu() string {
+	menu := m.menu
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	selected := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("226"))
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var b strings.Builder
+	b.WriteString(title.Render(fmt.Sprintf("Actions for %s %s/%s", menu.resource.Type, menu.resource.Namespace, menu.resource.Name)))
+	for i, action := range menu.actions {
+		line := fmt.Sprintf("  %d %-10s %s", i+1, action, actionDescriptions[action])
+		if i == menu.cursor {
+			line = selected.Render("> " + line[2:])
+		}
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+	b.WriteString("\n")
+	b.WriteString(hint.Render("j/k select | enter or 1-9 run | esc close"))
+
+	return asciiSafe(m.config, b.String())
+}
@@ -1,15 +1,24 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/internal/version"
 	"github.com/malagant/fluxcli/pkg/core"
 	"github.com/malagant/fluxcli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // AppModel represents the main application model
@@ -20,13 +29,33 @@ type AppModel struct {
 	currentView     ViewType
 	resourceView    *ResourceView
 	eventView       *EventView
+	diffView        *DiffView
+	detailView      *DetailView
+	watchView       *WatchView
+	yamlView        *YAMLView
+	eventsPane      eventsPane   // Events of the selected resource below the table
+	yamlReturn      ViewType     // View the manifest view returns to on esc
+	yamlResource    k8s.Resource // Resource whose manifest is shown
+	logView         *LogView
+	logReturn       ViewType     // View the log view returns to on esc
+	treeView        *TreeView
+	treeReturn      ViewType     // View the tree view returns to on esc
+	helpOffset      int          // First line of the help overlay shown
 	commandMode     bool
 	commandInput    string
+	confirm         *confirmPrompt
+	menu            *actionMenu
+	picker          *picker
 	statusMessage   string
 	errorMessage    string
 	width           int
 	height          int
+	bodyHeight      int // Rows left for the active view between header and footer
 	ready           bool
+	starting        bool  // The manager is still connecting
+	startErr        error // The manager failed to start
+	spinner         spinner.Model
+	spinning        bool
 }
 
 // AppState represents the application state
@@ -39,6 +68,16 @@ type AppState struct {
 	ShowHelp        bool
 }
 
+// confirmPrompt is a pending yes/no question shown in the footer. With
+// expect set it can also be answered by typing that text and pressing enter.
+type confirmPrompt struct {
+	message       string
+	onConfirm     func() tea.Cmd
+	expect        string
+	input         string
+	enterDeclines bool // Enter cancels, only y confirms
+}
+
 // ViewType represents different view types
 type ViewType int
 
@@ -46,6 +85,12 @@ const (
 	ViewResources ViewType = iota
 	ViewEvents
 	ViewDetails
+	ViewDiff
+	ViewAbout
+	ViewWatch
+	ViewYAML
+	ViewLogs
+	ViewTree
 )
 
 // Event represents a Kubernetes event for display
@@ -54,13 +99,19 @@ type Event struct {
 	Reason    string
 	Object    string
 	Message   string
-	Timestamp string
+	Timestamp time.Time
 	Count     int
+	InvolvedObject corev1.ObjectReference
 }
 
 // NewApp creates a new FluxCLI application
 func NewApp(cfg *config.Config) *AppModel {
-	manager := core.NewManager(cfg)
+	return newAppWithManager(cfg, core.NewManager(cfg))
+}
+
+// newAppWithManager creates an application around an existing manager
+func newAppWithManager(cfg *config.Config, manager *core.Manager) *AppModel {
+	applyAccessibility(cfg)
 	
 	app := &AppModel{
 		config:      cfg,
@@ -75,19 +126,27 @@ func NewApp(cfg *config.Config) *AppModel {
 	}
 
 	app.resourceView = NewResourceView(cfg)
+	app.resourceView.SetAllNamespaces(manager.GetCurrentNamespace() == "")
 	app.eventView = NewEventView(cfg)
+	app.diffView = NewDiffView(cfg)
+	app.detailView = NewDetailView(cfg)
+	app.watchView = NewWatchView(cfg)
+	app.yamlView = NewYAMLView(cfg)
+	app.logView = NewLogView(cfg)
+	app.treeView = NewTreeView(cfg)
+	app.spinner = app.newSpinner()
 
 	return app
 }
 
-// Run starts the TUI application
+// Run starts the TUI application. The manager is started from Init so the UI
+// shows up before the clusters have answered.
 func (m *AppModel) Run() error {
-	if err := m.manager.Start(); err != nil {
-		return fmt.Errorf("failed to start manager: %w", err)
-	}
+	m.starting = true
 	defer m.manager.Stop()
 
-	program := tea.NewProgram(m, tea.WithAltScreen())
+	// OSC 52 copies share the output, see terminalOutput
+	program := tea.NewProgram(m, tea.WithAltScreen(), tea.WithOutput(terminalOutput))
 	
 	// Start background update handlers
 	go m.handleUpdates(program)
@@ -98,8 +157,11 @@ func (m *AppModel) Run() error {
 
 // Init initializes the model
 func (m *AppModel) Init() tea.Cmd {
+	m.spinning = true
 	return tea.Batch(
 		tea.EnterAltScreen,
+		m.startManager(),
+		m.spinner.Tick,
 		m.resourceView.Init(),
 		m.eventView.Init(),
 	)
@@ -107,50 +169,198 @@ func (m *AppModel) Init() tea.Cmd {
 
 // Update handles messages and updates the model
 func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	// Tables are re-listed from many messages, not only resource updates, so
+	// check once here whether any of them set highlights that need to fade
+	return model, tea.Batch(cmd, m.changeHighlightTick())
+}
+
+// update handles a message for Update
+func (m *AppModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	// Header and footer heights change with state, so re-fit the body after every message
+	defer m.layout()
+
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		return m, m.tickSpinner(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
-		
-		// Update child views
-		m.resourceView.SetSize(m.width, m.height-4) // Reserve space for header/footer
-		m.eventView.SetSize(m.width, m.height/3)
+		m.bodyHeight = 0 // Force child views to resize
 		
 	case tea.KeyMsg:
+		if m.confirm != nil {
+			return m.handleConfirm(msg)
+		}
+		if m.menu != nil {
+			return m.handleMenu(msg)
+		}
+		if m.picker != nil {
+			return m.handlePicker(msg)
+		}
+		if m.state.ShowHelp {
+			return m.handleHelp(msg)
+		}
 		if m.commandMode {
 			return m.handleCommandMode(msg)
 		}
-		return m.handleNormalMode(msg)
+		if m.currentView == ViewResources && m.resourceView.Filtering() {
+			return m, tea.Batch(m.updateCurrentView(msg), m.syncEventsPane())
+		}
+		model, cmd := m.handleNormalMode(msg)
+		return model, tea.Batch(cmd, m.syncEventsPane())
 		
 	case ResourceUpdateMsg:
 		m.handleResourceUpdate(msg)
+
+	case ChangeHighlightExpiredMsg:
+		m.resourceView.fadeChanges(time.Now())
 		
 	case EventUpdateMsg:
 		m.handleEventUpdate(msg)
+		if msg.Cluster == m.state.CurrentCluster {
+			cmds = append(cmds, m.refreshEventsPane())
+		}
+		
+	case eventsPaneTickMsg:
+		return m, m.handleEventsPaneTick(msg)
+		
+	case ResourceEventsMsg:
+		m.handleResourceEvents(msg)
+		return m, nil
 		
 	case ErrorUpdateMsg:
 		m.errorMessage = msg.Error
 		
+	case WarningUpdateMsg:
+		m.statusMessage = fmt.Sprintf("Warning: %s", msg.Warning)
+		cmds = append(cmds, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		
+	case ShowDetailsMsg:
+		m.detailView.SetResource(msg.Resource)
+		m.detailView.SetSourceURL(m.chartSourceURL(msg.Resource))
+		m.currentView = ViewDetails
+		return m, m.refreshResource(msg.Resource)
+		
+	case WatchReconcileMsg:
+		resource := msg.Resource
+		return m, m.confirmAction(k8s.ActionReconcile, resource.Type, resource.Name, func() tea.Cmd {
+			m.reconcile(resource)
+			return tea.Batch(m.refreshResource(resource), tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		})
+		
+	case ResourceRefreshMsg:
+		m.handleResourceRefresh(msg)
+		
+	case CheckHealthMsg:
+		return m, m.checkHealth(msg.Resource, msg.Poll)
+		
+	case InventoryHealthMsg:
+		m.detailView.SetHealth(msg)
+		return m, nil
+		
+	case InventoryFilterMsg:
+		m.applyInventoryFilter(msg)
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+		
+	case ManifestMsg:
+		m.handleManifest(msg)
+		return m, nil
+		
+	case LogsMsg:
+		m.handleLogs(msg)
+		return m, nil
+		
+	case TreeMsg:
+		m.handleTree(msg)
+		return m, nil
+		
+	case KustomizationDiffMsg:
+		m.handleKustomizationDiff(msg)
+		return m, nil
+		
+	case CompareMsg:
+		m.handleCompare(msg)
+		return m, nil
+		
+	case NamespacesMsg:
+		m.handleNamespaces(msg)
+		return m, nil
+		
+	case LargeListMsg:
+		return m, m.handleLargeList(msg)
+		
+	case ContextsMsg:
+		m.handleContexts(msg)
+		return m, nil
+		
+	case ContextSwitchedMsg:
+		return m, m.handleContextSwitched(msg)
+		
+	case ReconcileSourceMsg:
+		return m, m.handleReconcileSource(msg)
+		
+	case SourceResolvedMsg:
+		m.handleSourceResolved(msg)
+		return m, nil
+		
+	case BulkActionMsg:
+		return m, m.handleBulkAction(msg)
+		
+	case YankResultMsg:
+		if msg.Err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to copy %s: %v", msg.Resource.Name, msg.Err)
+		} else {
+			m.statusMessage = yankStatus(msg)
+		}
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+		
+	case ManagerStartedMsg:
+		m.handleManagerStarted(msg)
+		
 	case ClearStatusMsg:
 		m.statusMessage = ""
 		m.errorMessage = ""
 	}
+	cmds = append(cmds, m.ensureSpinner())
 
 	// Update current view
+	cmd = m.updateCurrentView(msg)
+	cmds = append(cmds, cmd, m.syncEventsPane())
+
+	return m, tea.Batch(cmds...)
+}
+
+// updateCurrentView forwards a message to the active child view
+func (m *AppModel) updateCurrentView(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
 	switch m.currentView {
 	case ViewResources:
 		m.resourceView, cmd = m.resourceView.Update(msg)
-		cmds = append(cmds, cmd)
 	case ViewEvents:
 		m.eventView, cmd = m.eventView.Update(msg)
-		cmds = append(cmds, cmd)
+	case ViewDiff:
+		m.diffView, cmd = m.diffView.Update(msg)
+	case ViewDetails:
+		m.detailView, cmd = m.detailView.Update(msg)
+	case ViewWatch:
+		m.watchView, cmd = m.watchView.Update(msg)
+	case ViewYAML:
+		m.yamlView, cmd = m.yamlView.Update(msg)
+	case ViewLogs:
+		m.logView, cmd = m.logView.Update(msg)
+	case ViewTree:
+		m.treeView, cmd = m.treeView.Update(msg)
 	}
 
-	return m, tea.Batch(cmds...)
+	return cmd
 }
 
 // View renders the application
@@ -159,50 +369,139 @@ func (m *AppModel) View() string {
 		return "Initializing FluxCLI..."
 	}
 
-	var view strings.Builder
-	
-	// Header
-	view.WriteString(m.renderHeader())
-	view.WriteString("\n")
-	
-	// Main content
+	var body string
 	switch m.currentView {
 	case ViewResources:
-		view.WriteString(m.resourceView.View())
+		body = m.resourceView.View()
+		if m.startErr != nil || m.loading() {
+			body = m.renderStartup()
+		} else if m.eventsPaneShown() {
+			body = lipgloss.JoinVertical(lipgloss.Left, body, m.renderEventsPane())
+		}
 	case ViewEvents:
-		view.WriteString(m.eventView.View())
+		body = m.eventView.View()
+	case ViewDiff:
+		body = m.diffView.View()
+	case ViewDetails:
+		body = m.detailView.View()
+	case ViewAbout:
+		body = m.renderAbout()
+	case ViewWatch:
+		body = m.watchView.View()
+	case ViewYAML:
+		body = m.yamlView.View()
+	case ViewLogs:
+		body = m.logView.View()
+	case ViewTree:
+		body = m.treeView.View()
 	}
-	
-	// Footer
-	view.WriteString("\n")
-	view.WriteString(m.renderFooter())
-	
-	return view.String()
+	if m.picker != nil {
+		body = m.renderPicker()
+	}
+	if m.state.ShowHelp {
+		body = m.scrolledHelp()
+	}
+
+	// Pin the header and footer: only the body region scrolls, and it is
+	// clipped so the frame never outgrows the terminal and pushes the header off
+	body = lipgloss.NewStyle().MaxHeight(m.bodyHeight).Render(body)
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.pinned(m.renderHeader()), body, m.footer())
+}
+
+// footer renders the status bar and the footer, clipped to leave room for
+// the header and at least one body row, since terminals drop the top lines of
+// oversized frames
+func (m *AppModel) footer() string {
+	statusBar := m.pinned(m.renderStatusBar())
+	maxHeight := m.height - lipgloss.Height(m.pinned(m.renderHeader())) - lipgloss.Height(statusBar) - 1
+	if maxHeight < 1 {
+		maxHeight = 1
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, statusBar, lipgloss.NewStyle().MaxHeight(maxHeight).Render(m.pinned(m.renderFooter())))
+}
+
+// pinned truncates a fixed region to the terminal width so it never wraps
+// onto extra lines the layout did not account for
+func (m *AppModel) pinned(s string) string {
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(s)
+}
+
+// layout sizes the child views to the rows left between the header and footer
+func (m *AppModel) layout() {
+	if !m.ready {
+		return
+	}
+
+	bodyHeight := m.height - lipgloss.Height(m.pinned(m.renderHeader())) - lipgloss.Height(m.footer())
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	paneHeight := m.eventsPaneHeight()
+	if bodyHeight == m.bodyHeight && paneHeight == m.eventsPane.height {
+		return
+	}
+	m.bodyHeight = bodyHeight
+	m.eventsPane.height = paneHeight
+
+	tableHeight := bodyHeight - paneHeight
+	if tableHeight < 1 {
+		tableHeight = 1
+	}
+	m.resourceView.SetSize(m.width, tableHeight)
+	m.eventView.SetSize(m.width, bodyHeight)
+	m.diffView.SetSize(m.width, bodyHeight)
+	m.detailView.SetSize(m.width, bodyHeight)
+	m.watchView.SetSize(m.width, bodyHeight)
+	m.yamlView.SetSize(m.width, bodyHeight)
+	m.logView.SetSize(m.width, bodyHeight)
+	m.treeView.SetSize(m.width, bodyHeight)
 }
 
 // handleNormalMode handles keyboard input in normal mode
 func (m *AppModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch {
+	case key.Matches(msg, keys.Quit):
 		return m, tea.Quit
 		
-	case ":":
+	case key.Matches(msg, keys.Back):
+		if m.currentView == ViewYAML {
+			m.currentView = m.yamlReturn
+		} else if m.currentView == ViewLogs {
+			m.currentView = m.logReturn
+		} else if m.currentView == ViewTree {
+			m.currentView = m.treeReturn
+		} else if m.currentView == ViewDiff || m.currentView == ViewDetails || m.currentView == ViewAbout || m.currentView == ViewWatch {
+			m.currentView = ViewResources
+		} else if m.currentView == ViewResources && m.resourceView.FilterQuery() != "" {
+			m.resourceView.ClearFilter()
+		} else if m.currentView == ViewResources && len(m.resourceView.MarkedResources()) > 0 {
+			m.resourceView.ClearMarks()
+		} else if m.currentView == ViewResources && m.resourceView.InventoryOwner() != nil {
+			m.resourceView.ClearInventoryFilter()
+		}
+		return m, nil
+		
+	case key.Matches(msg, keys.Command):
 		m.commandMode = true
 		m.commandInput = ""
 		return m, nil
 		
-	case "?":
-		m.state.ShowHelp = !m.state.ShowHelp
+	case key.Matches(msg, keys.Help):
+		m.state.ShowHelp = true
+		m.helpOffset = 0
 		return m, nil
 		
-	case "/":
-		// TODO: Implement search/filter mode
-		m.statusMessage = "Search mode not yet implemented"
-		return m, tea.Tick(2000, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+	case key.Matches(msg, keys.Filter):
+		// Filter the resource table by name
+		if m.currentView == ViewResources {
+			m.resourceView.StartFilter()
+		}
+		return m, nil
 		
-	case "tab":
+	case key.Matches(msg, keys.SwitchView):
 		// Switch between views
 		switch m.currentView {
 		case ViewResources:
@@ -212,23 +511,17 @@ func (m *AppModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 		
-	case "1":
-		m.state.CurrentResource = k8s.ResourceTypeGitRepository
-		m.resourceView.SetResourceType(k8s.ResourceTypeGitRepository)
-		
-	case "2":
-		m.state.CurrentResource = k8s.ResourceTypeHelmRepository
-		m.resourceView.SetResourceType(k8s.ResourceTypeHelmRepository)
+	case key.Matches(msg, keys.ResourceTypes...):
+		resourceType, _ := keys.resourceTypeFor(msg)
+		m.switchResourceType(resourceType)
 		
-	case "3":
-		m.state.CurrentResource = k8s.ResourceTypeKustomization
-		m.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	case key.Matches(msg, keys.NextType):
+		m.switchResourceType(cycleResourceType(m.state.CurrentResource, 1))
 		
-	case "4":
-		m.state.CurrentResource = k8s.ResourceTypeHelmRelease
-		m.resourceView.SetResourceType(k8s.ResourceTypeHelmRelease)
+	case key.Matches(msg, keys.PrevType):
+		m.switchResourceType(cycleResourceType(m.state.CurrentResource, -1))
 		
-	case "ctrl+k":
+	case key.Matches(msg, keys.PrevCluster):
 		// Previous cluster
 		clusters := m.manager.GetClusters()
 		if len(clusters) > 1 {
@@ -238,12 +531,13 @@ func (m *AppModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					prev := (i - 1 + len(clusters)) % len(clusters)
 					m.state.CurrentCluster = clusters[prev]
 					m.manager.SetCurrentCluster(clusters[prev])
+					m.resourceView.ClearInventoryFilter() // Inventories are per cluster
 					break
 				}
 			}
 		}
 		
-	case "ctrl+j":
+	case key.Matches(msg, keys.NextCluster):
 		// Next cluster
 		clusters := m.manager.GetClusters()
 		if len(clusters) > 1 {
@@ -253,20 +547,205 @@ func (m *AppModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					next := (i + 1) % len(clusters)
 					m.state.CurrentCluster = clusters[next]
 					m.manager.SetCurrentCluster(clusters[next])
+					m.resourceView.ClearInventoryFilter() // Inventories are per cluster
 					break
 				}
 			}
 		}
 		
-	case "r":
+	case key.Matches(msg, keys.Actions):
+		m.openActionMenu()
+		
+	case key.Matches(msg, keys.ReconcileWithSource):
+		// Fetch the source first, like flux reconcile --with-source
+		if resource := m.selectedResource(); resource != nil {
+			if k8s.SupportsAction(resource.Type, k8s.ActionReconcileWithSource) {
+				target := *resource
+				cmds = append(cmds, m.confirmAction(k8s.ActionReconcileWithSource, target.Type, target.Name, func() tea.Cmd {
+					return m.reconcileWithSource(target)
+				}))
+			} else {
+				m.statusMessage = fmt.Sprintf("%s does not support %s", resource.Type, k8s.ActionReconcileWithSource)
+			}
+		}
+		
+	case key.Matches(msg, keys.Watch):
+		// Pin the selected resource into the focused watch screen
+		if resource := m.selectedResource(); resource != nil {
+			m.watchView.Pin(*resource)
+			m.watchView.SetEvents(m.state.Events[m.resourceCluster(*resource)])
+			m.currentView = ViewWatch
+		}
+		
+	case key.Matches(msg, keys.Inventory):
+		// Show what the selected Kustomization manages
+		if m.currentView == ViewResources {
+			cmds = append(cmds, m.filterByInventory())
+		}
+		
+	case key.Matches(msg, keys.Source):
+		// Select the source the resource is built from in the table
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, m.jumpToSource(*resource))
+		}
+		
+	case key.Matches(msg, keys.Events):
+		// Show the selected resource's events below the table
+		if m.currentView == ViewResources {
+			cmds = append(cmds, m.toggleEventsPane())
+		}
+		
+	case key.Matches(msg, keys.Namespace):
+		// Pick the namespace to list resources in
+		cmds = append(cmds, m.openNamespacePicker())
+		
+	case key.Matches(msg, keys.Context):
+		// Pick a kubeconfig context to reconnect to
+		cmds = append(cmds, m.openContextPicker())
+		
+	case key.Matches(msg, keys.Manifest):
+		// Show the full manifest of the selected resource
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, m.showManifest(*resource))
+		}
+		
+	case key.Matches(msg, keys.Tree):
+		// Show everything the selected Kustomization or HelmRelease manages
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, m.showTree(*resource))
+		}
+		
+	case key.Matches(msg, keys.Diff):
+		// Diff what reconciling the selected Kustomization would change
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, m.showKustomizationDiff(*resource))
+		}
+		
+	case key.Matches(msg, keys.Logs):
+		// Show the logs of the controller reconciling the selected resource
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, m.showLogs(*resource))
+		}
+		
+	case key.Matches(msg, keys.Copy):
+		// Copy the manifest of the selected resource, Y redacts it first
+		if resource := m.selectedResource(); resource != nil {
+			m.statusMessage = fmt.Sprintf("Copying %s...", resource.Name)
+			cmds = append(cmds, m.yankYAML(*resource, msg.String() == "Y"))
+		}
+		
+	case key.Matches(msg, keys.CopyName):
+		// Copy namespace/name for pasting into kubectl and friends
+		if resource := m.selectedResource(); resource != nil {
+			cmds = append(cmds, yankName(*resource))
+		}
+		
+	case key.Matches(msg, keys.UTC):
+		// Flip absolute timestamps between UTC and local time
+		m.config.UI.ToggleUTC()
+		m.detailView.refresh()
+		m.eventView.updateTable()
+		m.statusMessage = fmt.Sprintf("Showing times in %s", m.config.UI.TimeZoneLabel())
+		cmds = append(cmds, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		
+	case key.Matches(msg, keys.ShowNamespace):
+		m.resourceView.ToggleShowNamespace()
+		m.statusMessage = "Hiding namespaces"
+		if m.config.UI.ShowNamespace {
+			m.statusMessage = "Showing namespaces"
+		}
+		m.saveUIPreferences()
+		cmds = append(cmds, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		
+	case key.Matches(msg, keys.NameWidth):
+		delta := nameWidthStep
+		if msg.String() == "<" {
+			delta = -nameWidthStep
+		}
+		m.statusMessage = fmt.Sprintf("Name column width %d", m.resourceView.AdjustNameWidth(delta))
+		m.saveUIPreferences()
+		cmds = append(cmds, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		
+	case key.Matches(msg, keys.Refresh):
 		// Manual refresh
 		m.statusMessage = "Refreshing resources..."
 		cmds = append(cmds, tea.Tick(2000, func(time.Time) tea.Msg { return ClearStatusMsg{} }))
+		
+	default:
+		// Let the active view handle navigation keys
+		cmds = append(cmds, m.updateCurrentView(msg))
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// handleConfirm handles keyboard input while a confirmation prompt is shown
+func (m *AppModel) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.confirm
+	if prompt.expect != "" {
+		return m.handleTypedConfirm(msg)
+	}
+
+	switch msg.String() {
+	case "enter":
+		if prompt.enterDeclines {
+			return m, m.cancelConfirm()
+		}
+		m.confirm = nil
+		return m, prompt.onConfirm()
+	case "y", "Y":
+		m.confirm = nil
+		return m, prompt.onConfirm()
+	case "n", "N", "esc", "ctrl+c":
+		return m, m.cancelConfirm()
+	}
+	return m, nil
+}
+
+// handleTypedConfirm handles a prompt answered by typing the expected text.
+// Until something is typed, y and n answer it as well.
+func (m *AppModel) handleTypedConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.confirm
+
+	switch key := msg.String(); key {
+	case "esc", "ctrl+c":
+		return m, m.cancelConfirm()
+	case "enter":
+		if prompt.input != prompt.expect {
+			return m, nil
+		}
+		m.confirm = nil
+		return m, prompt.onConfirm()
+	case "backspace":
+		// Drop a whole rune, names may be typed with multibyte characters
+		_, size := utf8.DecodeLastRuneInString(prompt.input)
+		prompt.input = prompt.input[:len(prompt.input)-size]
+	case "y", "Y":
+		if prompt.input == "" {
+			m.confirm = nil
+			return m, prompt.onConfirm()
+		}
+		prompt.input += key
+	case "n", "N":
+		if prompt.input == "" {
+			return m, m.cancelConfirm()
+		}
+		prompt.input += key
+	default:
+		if msg.Type == tea.KeyRunes {
+			prompt.input += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// cancelConfirm dismisses the pending prompt
+func (m *AppModel) cancelConfirm() tea.Cmd {
+	m.confirm = nil
+	m.statusMessage = "Cancelled"
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+}
+
 // handleCommandMode handles keyboard input in command mode
 func (m *AppModel) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -310,34 +789,145 @@ func (m *AppModel) executeCommand(command string) tea.Cmd {
 		return tea.Quit
 		
 	case "suspend", "s":
-		if len(args) > 0 {
-			resourceName := args[0]
-			if err := m.manager.SuspendResource(m.state.CurrentResource, resourceName); err != nil {
-				m.errorMessage = fmt.Sprintf("Failed to suspend %s: %v", resourceName, err)
-			} else {
-				m.statusMessage = fmt.Sprintf("Suspended %s", resourceName)
-			}
+		// Without a name, act on the rows selected with space
+		if marked := m.resourceView.MarkedResources(); len(args) == 0 && len(marked) > 0 && !m.actionUnsupported(k8s.ActionSuspend) {
+			m.confirmBulk(k8s.ActionSuspend, marked)
+			return nil
+		}
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionSuspend) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionSuspend)
 		}
 		
 	case "resume", "r":
-		if len(args) > 0 {
-			resourceName := args[0]
-			if err := m.manager.ResumeResource(m.state.CurrentResource, resourceName); err != nil {
-				m.errorMessage = fmt.Sprintf("Failed to resume %s: %v", resourceName, err)
-			} else {
-				m.statusMessage = fmt.Sprintf("Resumed %s", resourceName)
-			}
+		if marked := m.resourceView.MarkedResources(); len(args) == 0 && len(marked) > 0 && !m.actionUnsupported(k8s.ActionResume) {
+			m.confirmBulk(k8s.ActionResume, marked)
+			return nil
+		}
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionResume) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionResume)
 		}
 		
 	case "reconcile", "rec":
+		// --all kicks every displayed row, --all-types every listed resource
+		if len(args) == 1 && (args[0] == "--all" || args[0] == "--all-types") {
+			resources := m.reconcilable()
+			if args[0] == "--all" {
+				if m.actionUnsupported(k8s.ActionReconcile) {
+					return nil
+				}
+				resources = nil
+				for _, resource := range m.resourceView.DisplayedResources() {
+					if !isUnreachableRow(resource) {
+						resources = append(resources, resource)
+					}
+				}
+			}
+			if len(resources) == 0 {
+				m.statusMessage = "No resources to reconcile"
+				return nil
+			}
+			m.confirmBulk(k8s.ActionReconcile, resources)
+			return nil
+		}
+		if marked := m.resourceView.MarkedResources(); len(args) == 0 && len(marked) > 0 && !m.actionUnsupported(k8s.ActionReconcile) {
+			m.confirmBulk(k8s.ActionReconcile, marked)
+			return nil
+		}
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionReconcile) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionReconcile)
+		}
+		
+	case "reconcile-source", "rs":
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionReconcileWithSource) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionReconcileWithSource)
+		}
+		
+	case "reset":
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionReset) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionReset)
+		}
+		
+	case "export":
 		if len(args) > 0 {
-			resourceName := args[0]
-			if err := m.manager.ReconcileResource(m.state.CurrentResource, resourceName); err != nil {
-				m.errorMessage = fmt.Sprintf("Failed to reconcile %s: %v", resourceName, err)
-			} else {
-				m.statusMessage = fmt.Sprintf("Triggered reconciliation for %s", resourceName)
+			m.exportResources(args[0])
+		}
+
+	case "delete":
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionDelete) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionDelete)
+		}
+
+	case "snooze":
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionSnooze) {
+			duration := defaultSnooze
+			if len(args) > 1 {
+				d, err := time.ParseDuration(args[1])
+				if err != nil || d <= 0 {
+					m.errorMessage = fmt.Sprintf("Invalid snooze duration %q", args[1])
+					break
+				}
+				duration = d
 			}
+			m.snooze(m.commandTarget(args[0]), duration)
 		}
+
+	case "unsnooze":
+		if len(args) > 0 && !m.actionUnsupported(k8s.ActionUnsnooze) {
+			return m.runAction(m.commandTarget(args[0]), k8s.ActionUnsnooze)
+		}
+
+	case "about", "version":
+		m.currentView = ViewAbout
+		return nil
+		
+	case "namespace", "ns":
+		// Without a name, pick one from the cluster's namespaces
+		if len(args) == 0 {
+			return m.openNamespacePicker()
+		}
+		target := args[0]
+		if target == "all" || target == "*" {
+			target = ""
+		}
+		return m.selectNamespace(target)
+		
+	case "label", "selector":
+		// label team=payments narrows every list, label alone clears it
+		m.selectLabels(strings.Join(args, " "))
+		
+	case "context", "ctx":
+		// Without a name, pick one from the kubeconfig's contexts
+		if len(args) == 0 {
+			return m.openContextPicker()
+		}
+		return m.switchContext(args[0])
+		
+	case "type", "t":
+		// Reaches the types beyond the number keys, e.g. type alerts
+		if len(args) == 0 {
+			m.errorMessage = "Usage: type <resource-type>"
+			break
+		}
+		resourceType, err := k8s.ParseResourceType(args[0])
+		if err != nil {
+			m.errorMessage = err.Error()
+			break
+		}
+		m.switchResourceType(resourceType)
+		
+	case "compare", "diff":
+		// compare <name> <cluster> compares against the current cluster,
+		// compare <name> <clusterA> <clusterB> compares two arbitrary clusters
+		if len(args) < 2 {
+			m.errorMessage = "Usage: compare <name> <cluster> [other-cluster]"
+			break
+		}
+		resourceName := args[0]
+		clusterA, clusterB := m.state.CurrentCluster, args[1]
+		if len(args) > 2 {
+			clusterA, clusterB = args[1], args[2]
+		}
+		return m.compareResource(m.state.CurrentResource, resourceName, clusterA, clusterB)
 		
 	default:
 		m.errorMessage = fmt.Sprintf("Unknown command: %s", cmd)
@@ -346,6 +936,127 @@ func (m *AppModel) executeCommand(command string) tea.Cmd {
 	return tea.Tick(3000, func(time.Time) tea.Msg { return ClearStatusMsg{} })
 }
 
+// nameWidthStep is how much one key press widens or narrows the Name column
+const nameWidthStep = 5
+
+// saveUIPreferences writes the layout adjusted in the session to the config
+// file so it survives a restart
+func (m *AppModel) saveUIPreferences() {
+	if err := m.config.SaveUIPreferences(); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save preferences: %v", err)
+	}
+}
+
+// switchNamespace changes the active namespace and drops resources cached for the old one
+func (m *AppModel) switchNamespace(namespace string) {
+	m.manager.SetCurrentNamespace(namespace)
+	m.state.Resources = make(map[string]map[k8s.ResourceType][]k8s.Resource)
+	m.resourceView.SetResources(nil)
+	m.resourceView.SetAllNamespaces(namespace == "")
+	m.statusMessage = fmt.Sprintf("Switched to namespace %s", displayNamespace(namespace))
+	if err := m.config.SaveLastNamespace(namespace); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save namespace: %v", err)
+	}
+}
+
+// selectLabels narrows the lists to a label selector and drops the resources
+// listed without it
+func (m *AppModel) selectLabels(selector string) {
+	if err := m.manager.SetLabelSelector(selector); err != nil {
+		m.errorMessage = err.Error()
+		return
+	}
+	m.state.Resources = make(map[string]map[k8s.ResourceType][]k8s.Resource)
+	m.resourceView.SetResources(nil)
+	if selector == "" {
+		m.statusMessage = "Cleared the label selector"
+	} else {
+		m.statusMessage = fmt.Sprintf("Showing resources matching %s", m.manager.GetLabelSelector())
+	}
+}
+
+// largeListWarning returns a warning when listing all namespaces would return
+// more objects than threshold, or "" when it looks safe
+func (m *AppModel) largeListWarning(threshold int64) string {
+	resourceTypes := k8s.ResourceTypes()
+
+	var large []string
+	for _, resourceType := range resourceTypes {
+		count, err := m.manager.CountResources(resourceType, "")
+		if err != nil || count <= threshold {
+			continue
+		}
+		large = append(large, fmt.Sprintf("~%s %s", formatCount(count), pluralize(resourceType)))
+	}
+
+	if len(large) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s across all namespaces — this may be slow. Proceed? [y/N]", strings.Join(large, ", "))
+}
+
+// displayNamespace renders an empty namespace as "all"
+func displayNamespace(namespace string) string {
+	if namespace == "" {
+		return "all"
+	}
+	return namespace
+}
+
+// pluralize returns the plural form of a resource type name
+func pluralize(resourceType k8s.ResourceType) string {
+	name := string(resourceType)
+	if strings.HasSuffix(name, "y") {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
+// formatCount formats a count with thousands separators
+func formatCount(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// resourceLabel renders the header resource type indicator
+func (m *AppModel) resourceLabel() string {
+	label := fmt.Sprintf("Resource: %s", m.state.CurrentResource)
+	if owner := m.resourceView.InventoryOwner(); owner != nil {
+		label = fmt.Sprintf("Resource: %s managed by %s/%s", m.state.CurrentResource, owner.Namespace, owner.Name)
+	}
+	if readiness := m.resourceView.ReadinessLabel(); readiness != "" {
+		label += fmt.Sprintf(" (%s only)", readiness)
+	}
+	if marked := len(m.resourceView.MarkedResources()); marked > 0 {
+		label += fmt.Sprintf(" [%d selected]", marked)
+	}
+	if m.resourceView.Filtering() {
+		label += fmt.Sprintf(" filter: /%s_", m.resourceView.FilterQuery())
+	} else if query := m.resourceView.FilterQuery(); query != "" {
+		label += fmt.Sprintf(" filter: /%s", query)
+	}
+	return label
+}
+
+// contextLabel renders the kubeconfig context for the status bar
+func (m *AppModel) contextLabel() string {
+	if kubeContext := m.manager.GetCurrentContext(); kubeContext != "" && !m.config.Fleet {
+		return fmt.Sprintf("context: %s | ", kubeContext)
+	}
+	return ""
+}
+
+// clusterLabel renders the header cluster indicator
+func (m *AppModel) clusterLabel() string {
+	if m.config.Fleet {
+		return fmt.Sprintf("Fleet: %d clusters", len(m.manager.GetClusters()))
+	}
+	return fmt.Sprintf("Cluster: %s", m.state.CurrentCluster)
+}
+
 // renderHeader renders the application header
 func (m *AppModel) renderHeader() string {
 	title := lipgloss.NewStyle().
@@ -356,34 +1067,64 @@ func (m *AppModel) renderHeader() string {
 	cluster := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("86")).
-		Render(fmt.Sprintf("Cluster: %s", m.state.CurrentCluster))
+		Render(m.clusterLabel())
 		
 	resource := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("81")).
-		Render(fmt.Sprintf("Resource: %s", m.state.CurrentResource))
+		Render(m.resourceLabel())
 		
 	namespace := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("226")).
-		Render(fmt.Sprintf("Namespace: %s", m.manager.GetCurrentNamespace()))
+		Render(fmt.Sprintf("Namespace: %s", displayNamespace(m.manager.GetCurrentNamespace())))
+	if selector := m.manager.GetLabelSelector(); selector != "" {
+		namespace += " | " + lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("226")).
+			Render(fmt.Sprintf("Labels: %s", selector))
+	}
+
+	// Overdue resources are behind without failing, so call them out
+	if overdue := m.resourceView.OverdueCount(); overdue > 0 {
+		namespace += " | " + lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("214")).
+			Render(fmt.Sprintf("%d overdue", overdue))
+	}
 	
+	header := fmt.Sprintf("%s | %s | %s | %s", title, cluster, resource, namespace)
 	if m.commandMode {
 		commandPrompt := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("196")).
 			Render(fmt.Sprintf(":%s", m.commandInput))
-		return fmt.Sprintf("%s | %s | %s | %s | %s", title, cluster, resource, namespace, commandPrompt)
+		header += " | " + commandPrompt
 	}
-	
-	return fmt.Sprintf("%s | %s | %s | %s", title, cluster, resource, namespace)
+
+	// A stopped controller explains resources stuck not ready
+	if banner := m.renderControllerBanner(); banner != "" {
+		header += "\n" + banner
+	}
+	return header
 }
 
 // renderFooter renders the application footer
 func (m *AppModel) renderFooter() string {
 	var footer strings.Builder
 	
-	if m.errorMessage != "" {
+	if m.confirm != nil {
+		prompt := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("226")).
+			Render(asciiSafe(m.config, m.confirm.message))
+		footer.WriteString(prompt)
+		if m.confirm.expect != "" {
+			footer.WriteString(" " + m.confirm.input + "_")
+		}
+	} else if m.menu != nil {
+		footer.WriteString(m.renderMenu())
+	} else if m.errorMessage != "" {
 		error := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("196")).
 			Render(fmt.Sprintf("Error: %s", m.errorMessage))
@@ -393,56 +1134,50 @@ func (m *AppModel) renderFooter() string {
 			Foreground(lipgloss.Color("86")).
 			Render(m.statusMessage)
 		footer.WriteString(status)
-	} else if m.state.ShowHelp {
-		help := m.renderHelp()
-		footer.WriteString(help)
 	} else {
 		shortcuts := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
-			Render("? help | ↑↓←→/jk navigation | 1-4 resource types | tab switch views | : command mode | ctrl+k/j clusters | q quit")
+			Render(asciiSafe(m.config, "? help | ↑↓←→/jk navigation | 1-9 resource types | tab switch views | : command mode | ctrl+k/j clusters | q quit | "+m.contextLabel()+"time: "+m.config.UI.TimeZoneLabel()))
 		footer.WriteString(shortcuts)
 	}
 	
 	return footer.String()
 }
 
-// renderHelp renders the help text
-func (m *AppModel) renderHelp() string {
-	helpText := `
-Navigation:
-  j/k or ↓/↑       Navigate up/down
-  ←/→              Horizontal navigation (in tables)
-  ctrl+d/u         Page down/up (half screen)
-  PageUp/PageDown  Page navigation
-  Home/End         Go to first/last item
-  g/G              Go to top/bottom
-  H/M/L            Top/Middle/Bottom of view
-  enter/space      View details
-  tab              Switch between views
-  
-Resource Types:
-  1                GitRepositories
-  2                HelmRepositories  
-  3                Kustomizations
-  4                HelmReleases
-  
-Clusters:
-  ctrl+k/j         Previous/Next cluster
-  
-Commands (: to enter command mode):
-  suspend <n>      Suspend resource
-  resume <n>       Resume resource
-  reconcile <n>    Trigger reconciliation
-  
-Other:
-  /                Search/Filter (coming soon)
-  r                Manual refresh
-  ?                Toggle this help
-  q                Quit
-`
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Render(strings.TrimSpace(helpText))
+// actionUnsupported reports whether the current resource type lacks an action,
+// showing a notice instead of letting the update fail against the API
+func (m *AppModel) actionUnsupported(action k8s.Action) bool {
+	if k8s.SupportsAction(m.state.CurrentResource, action) {
+		return false
+	}
+	m.statusMessage = fmt.Sprintf("%s does not support %s", m.state.CurrentResource, action)
+	return true
+}
+
+// renderAbout renders the build information screen
+func (m *AppModel) renderAbout() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205")).
+		Render("About FluxCLI")
+		
+	body := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252")).
+		Render(strings.TrimSpace(version.Get().String()))
+		
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Render("Include this information when reporting bugs. esc to return")
+	
+	return fmt.Sprintf("%s\n\n%s\n\n%s", title, body, hint)
+}
+
+// unsupportedNote marks an action in the help as unavailable for the current type
+func (m *AppModel) unsupportedNote(action k8s.Action) string {
+	if k8s.SupportsAction(m.state.CurrentResource, action) {
+		return ""
+	}
+	return fmt.Sprintf(" (n/a for %s)", m.state.CurrentResource)
 }
 
 // Message types for updates
@@ -450,6 +1185,10 @@ type ResourceUpdateMsg struct {
 	Cluster   string
 	Resources []k8s.Resource
 	Type      k8s.ResourceType
+	Err       error
+	NotInstalled bool
+	Truncated    bool
+	Controller   *k8s.ControllerStatus
 }
 
 type EventUpdateMsg struct {
@@ -461,6 +1200,10 @@ type ErrorUpdateMsg struct {
 	Error string
 }
 
+type WarningUpdateMsg struct {
+	Warning string
+}
+
 type ClearStatusMsg struct{}
 
 // handleUpdates handles background updates from the manager
@@ -472,29 +1215,27 @@ func (m *AppModel) handleUpdates(program *tea.Program) {
 				Cluster:   update.Cluster,
 				Resources: update.Resources,
 				Type:      update.Type,
+				Err:       update.Err,
+				NotInstalled: update.NotInstalled,
+				Truncated:    update.Truncated,
+				Controller:   update.Controller,
 			})
 			
 		case update := <-m.manager.GetEventUpdates():
-			events := make([]Event, len(update.Events))
-			for i, event := range update.Events {
-				events[i] = Event{
-					Type:      event.Type,
-					Reason:    event.Reason,
-					Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-					Message:   event.Message,
-					Timestamp: event.FirstTimestamp.Format("15:04:05"),
-					Count:     int(event.Count),
-				}
-			}
 			program.Send(EventUpdateMsg{
 				Cluster: update.Cluster,
-				Events:  events,
+				Events:  toEvents(m.config, update.Events),
 			})
 			
 		case update := <-m.manager.GetErrorUpdates():
 			program.Send(ErrorUpdateMsg{
 				Error: fmt.Sprintf("[%s] %v", update.Cluster, update.Error),
 			})
+			
+		case update := <-m.manager.GetWarningUpdates():
+			program.Send(WarningUpdateMsg{
+				Warning: fmt.Sprintf("[%s] %s", update.Cluster, update.Message),
+			})
 		}
 	}
 }
@@ -504,17 +1245,303 @@ func (m *AppModel) handleResourceUpdate(msg ResourceUpdateMsg) {
 	if m.state.Resources[msg.Cluster] == nil {
 		m.state.Resources[msg.Cluster] = make(map[k8s.ResourceType][]k8s.Resource)
 	}
-	m.state.Resources[msg.Cluster][msg.Type] = msg.Resources
+	resources := msg.Resources
+	if msg.Err != nil {
+		resources = []k8s.Resource{unreachableRow(msg.Cluster, msg.Type, msg.Err)}
+	}
+	m.state.Resources[msg.Cluster][msg.Type] = resources
+	m.markDrift(msg.Cluster)
+	if !m.config.Fleet && msg.Cluster == m.state.CurrentCluster && msg.Err == nil {
+		m.resourceView.SetNotInstalled(msg.Type, msg.NotInstalled)
+		m.resourceView.SetTruncated(msg.Type, msg.Truncated)
+		m.resourceView.SetController(msg.Type, msg.Controller)
+	}
+	
+	// Update resource view if it matches current view, or its drift may have
+	// changed with a source
+	current := msg.Type == m.state.CurrentResource || m.state.CurrentResource == k8s.ResourceTypeKustomization
+	if (m.config.Fleet || msg.Cluster == m.state.CurrentCluster) && current {
+		m.resourceView.SetResources(m.currentResources())
+	}
 	
-	// Update resource view if it matches current view
-	if msg.Cluster == m.state.CurrentCluster && msg.Type == m.state.CurrentResource {
-		m.resourceView.SetResources(msg.Resources)
+	// Keep the detail view live while it is open
+	if current := m.detailView.GetResource(); current != nil && msg.Cluster == m.resourceCluster(*current) && msg.Type == current.Type {
+		for _, resource := range m.state.Resources[msg.Cluster][msg.Type] {
+			if resource.Name == current.Name && resource.Namespace == current.Namespace {
+				m.detailView.SetResource(resource)
+				break
+			}
+		}
+	}
+
+	// And the pinned resource of the watch screen
+	if pinned := m.watchView.GetResource(); pinned != nil && msg.Cluster == m.resourceCluster(*pinned) && msg.Type == pinned.Type {
+		for _, resource := range m.state.Resources[msg.Cluster][msg.Type] {
+			if resource.Name == pinned.Name && resource.Namespace == pinned.Namespace {
+				m.watchView.SetResource(resource)
+				break
+			}
+		}
+	}
+}
+
+// reconcile triggers reconciliation of a resource and reports the outcome
+func (m *AppModel) reconcile(resource k8s.Resource) {
+	name := resource.Name
+	if err := m.manager.ReconcileResource(resource); errors.Is(err, k8s.ErrReconcilePending) {
+		m.statusMessage = fmt.Sprintf("Reconcile already pending for %s", name)
+	} else if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to reconcile %s: %v", name, err)
+	} else {
+		m.statusMessage = fmt.Sprintf("Triggered reconciliation for %s", name)
+	}
+}
+
+// ReconcileSourceMsg carries the outcome of a reconcile with source
+type ReconcileSourceMsg struct {
+	Name     string
+	Revision string
+	Err      error
+}
+
+// reconcileWithSource fetches a resource's source and then reconciles the
+// resource in the background, since the fetch can take a while
+func (m *AppModel) reconcileWithSource(resource k8s.Resource) tea.Cmd {
+	m.statusMessage = fmt.Sprintf("Fetching the source of %s...", resource.Name)
+	return func() tea.Msg {
+		revision, err := m.manager.ReconcileWithSource(resource)
+		return ReconcileSourceMsg{Name: resource.Name, Revision: revision, Err: err}
+	}
+}
+
+// handleReconcileSource reports the outcome of a reconcile with source
+func (m *AppModel) handleReconcileSource(msg ReconcileSourceMsg) tea.Cmd {
+	m.statusMessage = ""
+	if errors.Is(msg.Err, k8s.ErrReconcilePending) {
+		m.statusMessage = fmt.Sprintf("Source fetched, reconcile already pending for %s", msg.Name)
+	} else if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to reconcile %s with source: %v", msg.Name, msg.Err)
+	} else {
+		m.statusMessage = fmt.Sprintf("Source fetched at %s, triggered reconciliation for %s", msg.Revision, msg.Name)
+	}
+	return tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+}
+
+// commandTarget resolves the resource a command names. The selected row wins
+// so that all-namespaces and fleet views act on the object under the cursor,
+// then a listed row of the current type by that name; anything else is taken
+// to be in the current cluster and namespace.
+func (m *AppModel) commandTarget(name string) k8s.Resource {
+	if selected := m.selectedResource(); selected != nil && selected.Type == m.state.CurrentResource && selected.Name == name && !isUnreachableRow(*selected) {
+		return *selected
 	}
+	for _, resource := range m.resourceView.DisplayedResources() {
+		if resource.Type == m.state.CurrentResource && resource.Name == name && !isUnreachableRow(resource) {
+			return resource
+		}
+	}
+	return k8s.Resource{Cluster: m.state.CurrentCluster, Type: m.state.CurrentResource, Name: name, Namespace: m.manager.GetCurrentNamespace()}
+}
+
+// selectedResource returns the resource under the cursor or shown in the
+// detail view, or nil
+func (m *AppModel) selectedResource() *k8s.Resource {
+	switch m.currentView {
+	case ViewResources:
+		return m.resourceView.GetSelectedResource()
+	case ViewDetails:
+		return m.detailView.GetResource()
+	case ViewWatch:
+		return m.watchView.GetResource()
+	case ViewYAML:
+		return &m.yamlResource
+	case ViewLogs:
+		return m.logView.GetResource()
+	case ViewTree:
+		return m.treeView.GetResource()
+	}
+	return nil
+}
+
+// chartSourceURL returns the URL of a HelmRelease's chart source when the
+// source has been listed, or "" otherwise
+func (m *AppModel) chartSourceURL(resource k8s.Resource) string {
+	src := resource.ChartSource
+	if src == nil {
+		return ""
+	}
+
+	var sourceType k8s.ResourceType
+	switch src.Kind {
+	case "HelmRepository":
+		sourceType = k8s.ResourceTypeHelmRepository
+	case "GitRepository":
+		sourceType = k8s.ResourceTypeGitRepository
+	case "OCIRepository":
+		sourceType = k8s.ResourceTypeOCIRepository
+	default:
+		return ""
+	}
+
+	for _, source := range m.state.Resources[m.resourceCluster(resource)][sourceType] {
+		if source.Name == src.Name && source.Namespace == src.Namespace {
+			return source.URL
+		}
+	}
+	return ""
+}
+
+// checkHealth polls a Kustomization's inventory in the background
+func (m *AppModel) checkHealth(resource k8s.Resource, poll int) tea.Cmd {
+	return func() tea.Msg {
+		health, err := m.manager.GetInventoryHealth(resource)
+		return InventoryHealthMsg{Resource: resource, Poll: poll, Health: health, Err: err}
+	}
+}
+
+// ResourceRefreshMsg carries a single re-fetched resource
+type ResourceRefreshMsg struct {
+	Resource k8s.Resource
+	Err      error
+}
+
+// refreshResource re-fetches one resource instead of re-listing its whole
+// namespace. Fleet rows of other clusters wait for the next periodic list.
+func (m *AppModel) refreshResource(resource k8s.Resource) tea.Cmd {
+	if m.resourceCluster(resource) != m.state.CurrentCluster {
+		return nil
+	}
+	return func() tea.Msg {
+		fresh, err := m.manager.GetResource(resource)
+		if err != nil {
+			return ResourceRefreshMsg{Resource: resource, Err: err}
+		}
+		return ResourceRefreshMsg{Resource: *fresh}
+	}
+}
+
+// handleResourceRefresh swaps a re-fetched resource into the cached list and
+// the views showing it
+func (m *AppModel) handleResourceRefresh(msg ResourceRefreshMsg) {
+	resource := msg.Resource
+	if msg.Err != nil {
+		// Other failures are left to the periodic list to report
+		if apierrors.IsNotFound(msg.Err) {
+			m.statusMessage = fmt.Sprintf("%s %s/%s no longer exists", resource.Type, resource.Namespace, resource.Name)
+		}
+		return
+	}
+
+	cached := m.state.Resources[m.resourceCluster(resource)][resource.Type]
+	for i := range cached {
+		if sameResource(cached[i], resource) {
+			cached[i] = resource
+			break
+		}
+	}
+	if resource.Type == m.state.CurrentResource {
+		m.resourceView.SetResources(m.currentResources())
+	}
+
+	if current := m.detailView.GetResource(); current != nil && sameResource(*current, resource) {
+		m.detailView.SetResource(resource)
+	}
+	if pinned := m.watchView.GetResource(); pinned != nil && sameResource(*pinned, resource) {
+		m.watchView.SetResource(resource)
+	}
+}
+
+// switchResourceType shows another resource type from the top and lists it
+// right away rather than at the next refresh
+func (m *AppModel) switchResourceType(resourceType k8s.ResourceType) {
+	m.state.CurrentResource = resourceType
+	m.resourceView.SetResourceType(resourceType)
+	m.resourceView.SetResources(m.currentResources())
+	m.manager.RequestRefresh()
+}
+
+// cycleResourceType returns the type delta steps from current in the order
+// of k8s.ResourceTypes, wrapping around at either end
+func cycleResourceType(current k8s.ResourceType, delta int) k8s.ResourceType {
+	resourceTypes := k8s.ResourceTypes()
+	for i, resourceType := range resourceTypes {
+		if resourceType == current {
+			return resourceTypes[(i+delta+len(resourceTypes))%len(resourceTypes)]
+		}
+	}
+	return resourceTypes[0]
+}
+
+// currentResources returns the resources of the current type to display: those
+// of the current cluster, or of every cluster in fleet mode
+func (m *AppModel) currentResources() []k8s.Resource {
+	if !m.config.Fleet {
+		return m.state.Resources[m.state.CurrentCluster][m.state.CurrentResource]
+	}
+
+	clusters := make([]string, 0, len(m.state.Resources))
+	for cluster := range m.state.Resources {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	var resources []k8s.Resource
+	for _, cluster := range clusters {
+		resources = append(resources, m.state.Resources[cluster][m.state.CurrentResource]...)
+	}
+	return resources
+}
+
+// markDrift flags the cluster's Kustomizations whose applied revision lags
+// their source. Either list may arrive first, so it runs on every update.
+func (m *AppModel) markDrift(cluster string) {
+	lists := m.state.Resources[cluster]
+	if len(lists[k8s.ResourceTypeKustomization]) == 0 {
+		return
+	}
+	var sources []k8s.Resource
+	for resourceType, list := range lists {
+		if resourceType != k8s.ResourceTypeKustomization {
+			sources = append(sources, list...)
+		}
+	}
+	// The lists are shared with the manager, so mark a copy
+	kustomizations := slices.Clone(lists[k8s.ResourceTypeKustomization])
+	k8s.MarkDrift(kustomizations, sources)
+	lists[k8s.ResourceTypeKustomization] = kustomizations
+}
+
+// resourceCluster returns the cluster a resource was listed from
+func (m *AppModel) resourceCluster(resource k8s.Resource) string {
+	if resource.Cluster != "" {
+		return resource.Cluster
+	}
+	return m.state.CurrentCluster
+}
+
+// unreachableRow is the placeholder row shown for a fleet cluster that could not be listed
+func unreachableRow(cluster string, resourceType k8s.ResourceType, err error) k8s.Resource {
+	return k8s.Resource{
+		Cluster: cluster,
+		Type:    resourceType,
+		Name:    "-",
+		Status:  "Unreachable",
+		Message: err.Error(),
+	}
+}
+
+// isUnreachableRow reports whether a row is the placeholder of an
+// unreachable fleet cluster rather than a resource
+func isUnreachableRow(resource k8s.Resource) bool {
+	return resource.Name == "-" && resource.Status == "Unreachable"
 }
 
 // handleEventUpdate handles event updates  
 func (m *AppModel) handleEventUpdate(msg EventUpdateMsg) {
 	m.state.Events[msg.Cluster] = msg.Events
+	if pinned := m.watchView.GetResource(); pinned != nil && msg.Cluster == m.resourceCluster(*pinned) {
+		m.watchView.SetEvents(msg.Events)
+	}
 	
 	// Update event view if it matches current cluster
 	if msg.Cluster == m.state.CurrentCluster {
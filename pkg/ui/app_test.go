@@ -0,0 +1,1294 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/core"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/k8s/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestApp creates an app whose manager is backed by a fake client
+func newTestApp(t *testing.T, client *fake.Client) *AppModel {
+	// Keep namespace switches from saving into the real config file
+	t.Setenv("HOME", t.TempDir())
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	manager := core.NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(manager.Stop)
+
+	return newAppWithManager(cfg, manager)
+}
+
+func TestApp_ExecuteSuspendCommand(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	app.executeCommand("suspend apps")
+
+	// Suspends ask first by default, enter confirms
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "Suspend Kustomization apps? [y/N]", app.confirm.message)
+	assert.Empty(t, client.Actions)
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "suspend", client.Actions[0].Verb)
+	assert.Equal(t, k8s.ResourceTypeKustomization, client.Actions[0].Type)
+	assert.Equal(t, "Suspended apps", app.statusMessage)
+}
+
+func TestApp_ConfirmActions(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	// Reconciles and resumes run straight away by default
+	app.executeCommand("reconcile apps")
+	app.executeCommand("resume apps")
+	assert.Nil(t, app.confirm)
+	require.Len(t, client.Actions, 2)
+
+	// Esc cancels a prompt
+	app.config.UI.Confirm.Reconcile = true
+	app.executeCommand("reconcile apps")
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "Reconcile Kustomization apps? [y/N]", app.confirm.message)
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, app.confirm)
+	assert.Len(t, client.Actions, 2)
+
+	app.executeCommand("rs apps")
+	require.NotNil(t, app.confirm)
+	assert.Contains(t, app.confirm.message, "Fetch the source of Kustomization apps")
+	app.confirm = nil
+
+	// Suspends and deletes can be turned off as well
+	app.config.UI.Confirm.Suspend = false
+	app.config.UI.Confirm.Delete = false
+	app.executeCommand("suspend apps")
+	app.executeCommand("delete apps")
+	assert.Nil(t, app.confirm)
+	require.Len(t, client.Actions, 4)
+	assert.Equal(t, "suspend", client.Actions[2].Verb)
+	assert.Equal(t, "delete", client.Actions[3].Verb)
+}
+
+func TestApp_CommandTargetsRow(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+
+	// Rows listed from another namespace are acted on where they live
+	row := createTestResource("apps", "team-a", k8s.ResourceTypeKustomization)
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{row}})
+	app.executeCommand("reconcile apps")
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "team-a", client.Actions[0].Namespace)
+
+	// Names that aren't listed go to the current namespace
+	app.executeCommand("reconcile other")
+	require.Len(t, client.Actions, 2)
+	assert.Equal(t, app.manager.GetCurrentNamespace(), client.Actions[1].Namespace)
+}
+
+func TestApp_ExecuteSnoozeCommand(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeHelmRelease
+
+	app.executeCommand("snooze podinfo 30m")
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "snooze", client.Actions[0].Verb)
+	assert.Contains(t, app.statusMessage, "Snoozed podinfo until")
+
+	app.executeCommand("snooze podinfo soon")
+	assert.Len(t, client.Actions, 1)
+	assert.Contains(t, app.errorMessage, "Invalid snooze duration")
+
+	app.executeCommand("unsnooze podinfo")
+	require.Len(t, client.Actions, 2)
+	assert.Equal(t, "unsnooze", client.Actions[1].Verb)
+}
+
+func TestApp_ExecuteUnknownCommand(t *testing.T) {
+	app := newTestApp(t, fake.NewClient())
+
+	app.executeCommand("bogus")
+
+	assert.Contains(t, app.errorMessage, "Unknown command")
+}
+
+func TestApp_ExecuteUnsupportedSuspend(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	// A type that cannot be suspended must not reach the client
+	app.state.CurrentResource = k8s.ResourceTypeImagePolicy
+
+	app.executeCommand("suspend latest")
+
+	assert.Empty(t, client.Actions)
+	assert.Empty(t, app.errorMessage)
+	assert.Equal(t, "ImagePolicy does not support suspend", app.statusMessage)
+	assert.Contains(t, app.renderHelp(), "(n/a for ImagePolicy)")
+}
+
+func TestApp_HelpOverlay(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 60})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	require.True(t, app.state.ShowHelp)
+
+	// Every binding of the key map is listed under its category
+	view := app.View()
+	for _, group := range keys.groups() {
+		assert.Contains(t, view, group.title)
+		for _, binding := range group.bindings {
+			assert.Contains(t, view, binding.Help().Desc)
+		}
+	}
+	assert.Contains(t, view, "delete <n>")
+
+	// Overlays taller than the terminal scroll
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+	assert.Contains(t, app.scrolledHelp(), "Navigation")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	assert.NotContains(t, app.scrolledHelp(), "Navigation")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	assert.Contains(t, app.scrolledHelp(), "Navigation")
+
+	// Keys don't reach the screen below while the overlay is open
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	assert.Equal(t, k8s.ResourceTypeGitRepository, app.state.CurrentResource)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.state.ShowHelp)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	assert.Equal(t, k8s.ResourceTypeKustomization, app.state.CurrentResource)
+}
+
+func TestApp_LayoutPreferencesPersist(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	require.True(t, app.config.UI.ShowNamespace)
+	width := app.config.UI.ColumnsName
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.False(t, app.config.UI.ShowNamespace)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	assert.Equal(t, width+nameWidthStep, app.resourceView.table.Columns()[0].Width)
+	assert.Empty(t, app.errorMessage)
+
+	// A restart loads the adjusted layout from the config file
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	assert.False(t, cfg.UI.ShowNamespace)
+	assert.Equal(t, width+nameWidthStep, cfg.UI.ColumnsName)
+}
+
+func TestApp_ExecuteTypeCommand(t *testing.T) {
+	client := fake.NewClient()
+	client.Resources[k8s.ResourceTypeReceiver] = []k8s.Resource{{Type: k8s.ResourceTypeReceiver, Name: "github", Namespace: "flux-system"}}
+	app := newTestApp(t, client)
+
+	app.executeCommand("type receivers")
+	assert.Equal(t, k8s.ResourceTypeReceiver, app.state.CurrentResource)
+	assert.Empty(t, app.errorMessage)
+
+	app.executeCommand("type webhooks")
+	assert.Equal(t, k8s.ResourceTypeReceiver, app.state.CurrentResource)
+	assert.Equal(t, "unknown resource type: webhooks", app.errorMessage)
+}
+
+func TestApp_CycleResourceTypes(t *testing.T) {
+	client := fake.NewClient()
+	client.Resources[k8s.ResourceTypeKustomization] = []k8s.Resource{
+		{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	}
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 60})
+	app.state.Resources[app.state.CurrentCluster] = map[k8s.ResourceType][]k8s.Resource{
+		k8s.ResourceTypeKustomization: client.Resources[k8s.ResourceTypeKustomization],
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	require.Equal(t, k8s.ResourceTypeKustomization, app.state.CurrentResource)
+	app.resourceView.table.SetCursor(1)
+
+	// Switching starts the new type at the top
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, k8s.ResourceTypeHelmRelease, app.state.CurrentResource)
+	assert.Equal(t, 0, app.resourceView.table.Cursor())
+	app.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, k8s.ResourceTypeKustomization, app.state.CurrentResource)
+
+	// Cycling wraps around and reaches the types without a number key
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	app.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, k8s.ResourceTypeReceiver, app.state.CurrentResource)
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, k8s.ResourceTypeGitRepository, app.state.CurrentResource)
+
+	// The events screen moved off tab
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	assert.Equal(t, ViewEvents, app.currentView)
+}
+
+func TestApp_ExecuteResetCommand(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+
+	// Only HelmReleases can be reset
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.executeCommand("reset apps")
+	assert.Nil(t, app.confirm)
+	assert.Equal(t, "Kustomization does not support reset", app.statusMessage)
+
+	// The reset fires only after confirmation
+	app.state.CurrentResource = k8s.ResourceTypeHelmRelease
+	app.executeCommand("reset podinfo")
+	require.NotNil(t, app.confirm)
+	assert.Empty(t, client.Actions)
+
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "reset", client.Actions[0].Verb)
+	assert.Equal(t, "podinfo", client.Actions[0].Name)
+}
+
+func TestApp_PinnedLayout(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+
+	resources := make([]k8s.Resource, 50)
+	for i := range resources {
+		resources[i] = createTestResource(fmt.Sprintf("app-%02d", i), "flux-system", k8s.ResourceTypeGitRepository)
+	}
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeGitRepository, Resources: resources})
+
+	// The frame fits the terminal with the header on the first line
+	lines := strings.Split(app.View(), "\n")
+	assert.LessOrEqual(t, len(lines), 20)
+	assert.Contains(t, lines[0], "FluxCLI")
+
+	// Taller footers shrink the body instead of pushing the header away
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	lines = strings.Split(app.View(), "\n")
+	assert.LessOrEqual(t, len(lines), 20)
+	assert.Contains(t, lines[0], "FluxCLI")
+
+	// Tiny terminals degrade without panicking
+	app.Update(tea.WindowSizeMsg{Width: 20, Height: 2})
+	assert.NotPanics(t, func() { app.View() })
+}
+
+func TestApp_StatusBar(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	healthy := createTestResource("podinfo", "default", k8s.ResourceTypeKustomization)
+	paused := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	paused.Ready, paused.Suspended = false, true
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{healthy, paused}})
+
+	// Suspended resources are not counted as failing
+	assert.Equal(t, resourceSummary{Total: 2, Ready: 1, Suspended: 1}, app.resourceView.Summary())
+	assert.Contains(t, app.View(), "2 resources | 1 ready | 1 suspended | 0 failing")
+
+	broken := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	broken.Ready = false
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{healthy, paused, broken}})
+	assert.Equal(t, resourceSummary{Total: 3, Ready: 1, Suspended: 1, Failing: 1}, app.resourceView.Summary())
+	assert.Contains(t, app.renderStatusBar(), "1 failing")
+
+	// A suspended resource that is still Ready counts toward both
+	pausedReady := createTestResource("monitoring", "default", k8s.ResourceTypeKustomization)
+	pausedReady.Suspended = true
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{healthy, paused, broken, pausedReady}})
+	assert.Equal(t, resourceSummary{Total: 4, Ready: 2, Suspended: 2, Failing: 1}, app.resourceView.Summary())
+	assert.Contains(t, app.renderStatusBar(), "4 resources | 2 ready | 2 suspended | ")
+
+	// Lists cut short at the list limit say so
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{healthy, paused, broken}, Truncated: true})
+	assert.Contains(t, app.renderStatusBar(), "showing 3 of many | 1 ready")
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{healthy, paused, broken}})
+	assert.Contains(t, app.renderStatusBar(), "3 resources | 1 ready")
+
+	// The bar counts toward the layout, so the frame still fits
+	assert.LessOrEqual(t, len(strings.Split(app.View(), "\n")), 20)
+}
+
+func TestApp_ControllerBanner(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeGitRepository
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 20})
+
+	// A stopped controller explains why its resources are stuck
+	stopped := &k8s.ControllerStatus{Name: "source-controller", Detail: "0/1 replicas ready"}
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeGitRepository, Controller: stopped})
+	assert.Contains(t, app.renderHeader(), "source-controller is not ready (0/1 replicas ready)")
+	assert.LessOrEqual(t, len(strings.Split(app.View(), "\n")), 20)
+
+	// Other types and running controllers show no banner
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	assert.NotContains(t, app.renderHeader(), "not ready")
+	app.state.CurrentResource = k8s.ResourceTypeGitRepository
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeGitRepository, Controller: &k8s.ControllerStatus{Name: "source-controller", Ready: true}})
+	assert.NotContains(t, app.renderHeader(), "not ready")
+}
+
+func TestApp_ActionMenu(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeHelmRelease
+
+	// Menus offer the registry's actions, with suspend or resume as applicable
+	menu := newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"})
+	require.NotNil(t, menu)
+	assert.Equal(t, []k8s.Action{k8s.ActionReconcile, k8s.ActionReconcileWithSource, k8s.ActionSuspend, k8s.ActionReset, k8s.ActionSnooze, k8s.ActionDelete}, menu.actions)
+
+	menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Suspended: true})
+	require.NotNil(t, menu)
+	assert.Equal(t, []k8s.Action{k8s.ActionReconcile, k8s.ActionReconcileWithSource, k8s.ActionResume, k8s.ActionSnooze, k8s.ActionDelete}, menu.actions)
+
+	// Snoozed resources offer unsnooze instead
+	menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", SnoozedUntil: time.Now().Add(time.Hour)})
+	require.NotNil(t, menu)
+	assert.Equal(t, []k8s.Action{k8s.ActionReconcile, k8s.ActionReconcileWithSource, k8s.ActionSuspend, k8s.ActionUnsnooze, k8s.ActionDelete}, menu.actions)
+
+	// ImagePolicies have no suspend and ignore reconcile requests
+	menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeImagePolicy, Name: "podinfo"})
+	require.NotNil(t, menu)
+	assert.Equal(t, []k8s.Action{k8s.ActionSnooze, k8s.ActionDelete}, menu.actions)
+
+	assert.Nil(t, newActionMenu(k8s.Resource{Type: k8s.ResourceType("Unknown")}))
+
+	// Reconcile runs straight away
+	app.menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"})
+	assert.Contains(t, app.renderFooter(), "Actions for HelmRelease default/podinfo")
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, app.menu)
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, fake.Action{Verb: "reconcile", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"}, client.Actions[0])
+
+	// The menu's resource is acted on even when another type is listed, as
+	// when the menu is opened from the watch view
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps"})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Len(t, client.Actions, 2)
+	assert.Equal(t, fake.Action{Verb: "reconcile", Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "apps"}, client.Actions[1])
+	client.Actions = client.Actions[:1]
+	app.state.CurrentResource = k8s.ResourceTypeHelmRelease
+
+	// Suspend goes through the confirmation prompt
+	app.menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyDown})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyDown})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, app.confirm)
+	assert.Len(t, client.Actions, 1)
+
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.Len(t, client.Actions, 2)
+	assert.Equal(t, "suspend", client.Actions[1].Verb)
+
+	// Digits pick items directly
+	app.menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	require.NotNil(t, app.confirm)
+	assert.Contains(t, app.confirm.message, "Reset remediation retries")
+	app.confirm = nil
+
+	// Esc closes without running anything
+	app.menu = newActionMenu(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"})
+	app.handleMenu(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, app.menu)
+	assert.Len(t, client.Actions, 2)
+}
+
+func TestApp_DeleteConfirm(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	app.executeCommand("delete apps")
+	require.NotNil(t, app.confirm)
+	assert.Contains(t, app.confirm.message, "pruned if prune is enabled")
+
+	// Enter only confirms once the name is typed in full
+	for _, r := range "app" {
+		app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Contains(t, app.renderFooter(), "app_")
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, app.confirm)
+	assert.Empty(t, client.Actions)
+
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, app.confirm)
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, fake.Action{Verb: "delete", Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: app.manager.GetCurrentNamespace()}, client.Actions[0])
+
+	// n cancels before anything is typed
+	app.executeCommand("delete apps")
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Nil(t, app.confirm)
+	assert.Len(t, client.Actions, 1)
+
+	// y confirms as well
+	app.executeCommand("delete apps")
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.Len(t, client.Actions, 2)
+
+	// Backspace removes a whole multibyte rune
+	app.executeCommand("delete café")
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("café")})
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyBackspace})
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "caf", app.confirm.input)
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("é")})
+	app.handleConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, app.confirm)
+	require.Len(t, client.Actions, 3)
+}
+
+func TestApp_Compare(t *testing.T) {
+	app := newTestApp(t, fake.NewClient())
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	// The fetch runs as a command, so Update never blocks on a cluster
+	cmd := app.executeCommand("compare apps prod")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "Comparing apps between "+app.state.CurrentCluster+" and prod...", app.statusMessage)
+	assert.Empty(t, app.errorMessage)
+
+	msg := cmd()
+	require.IsType(t, CompareMsg{}, msg)
+	app.Update(msg)
+	assert.Contains(t, app.errorMessage, "cluster prod not connected")
+	assert.Equal(t, ViewResources, app.currentView)
+}
+
+func TestApp_Export(t *testing.T) {
+	// No --context, so the kubeconfig's current context names the export
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\ncurrent-context: staging\n"), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	require.Empty(t, app.config.CurrentContext)
+	app.resourceView.SetResources([]k8s.Resource{
+		{Type: k8s.ResourceTypeGitRepository, Name: "flux-system", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeGitRepository, Name: "podinfo", Namespace: "default"},
+	})
+
+	path := filepath.Join(t.TempDir(), "flux.json")
+	app.executeCommand("export " + path)
+	assert.Empty(t, app.errorMessage)
+	assert.Equal(t, "Exported 2 resources to "+path, app.statusMessage)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var export k8s.Export
+	require.NoError(t, json.Unmarshal(data, &export))
+	assert.Equal(t, "staging", export.Context)
+	assert.False(t, export.Timestamp.IsZero())
+	assert.Len(t, export.Resources, 2)
+
+	app.executeCommand("export -")
+	assert.Contains(t, app.errorMessage, "needs a file path")
+}
+
+func TestApp_FilterByInventory(t *testing.T) {
+	client := fake.NewClient()
+	client.Inventories["flux-system/apps"] = []k8s.ObjectRef{
+		{Kind: "HelmRelease", Namespace: "default", Name: "podinfo", Group: "helm.toolkit.fluxcd.io"},
+		{Kind: "Deployment", Namespace: "default", Name: "podinfo", Group: "apps"},
+	}
+	app := newTestApp(t, client)
+
+	apps := k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeHelmRelease, Resources: []k8s.Resource{
+		{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"},
+		{Type: k8s.ResourceTypeHelmRelease, Name: "other", Namespace: "default"},
+	}})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	app.resourceView.SetResources(app.currentResources())
+
+	cmd := app.filterByInventory()
+	require.NotNil(t, cmd)
+	app.applyInventoryFilter(cmd().(InventoryFilterMsg))
+
+	// The view switches to the type the Kustomization manages
+	assert.Equal(t, k8s.ResourceTypeHelmRelease, app.state.CurrentResource)
+	assert.Equal(t, "flux-system/apps manages 1 HelmRelease (esc to clear)", app.statusMessage)
+	require.Len(t, app.resourceView.resources, 1)
+	assert.Equal(t, "podinfo", app.resourceView.resources[0].Name)
+	assert.Contains(t, app.resourceLabel(), "managed by flux-system/apps")
+
+	// esc clears the filter
+	app.handleNormalMode(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, app.resourceView.InventoryOwner())
+	assert.Len(t, app.resourceView.resources, 2)
+
+	// Other types have no inventory
+	app.state.CurrentResource = k8s.ResourceTypeHelmRelease
+	assert.Nil(t, app.filterByInventory())
+	assert.Equal(t, "Only Kustomizations have an inventory", app.statusMessage)
+}
+
+func TestApp_AsyncStartup(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	client := fake.NewClient()
+	manager := core.NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return client, nil
+	})
+	t.Cleanup(manager.Stop)
+	app := newAppWithManager(cfg, manager)
+	app.starting = true
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+
+	// The shell renders before the manager has connected
+	assert.Contains(t, app.View(), "Connecting to cluster...")
+
+	app.Update(app.startManager()())
+	assert.False(t, app.starting)
+	assert.Contains(t, app.View(), "Loading GitRepository resources...")
+
+	// The first list replaces the spinner
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeGitRepository})
+	assert.False(t, app.loading())
+	assert.Contains(t, app.View(), "No GitRepository resources found")
+}
+
+func TestApp_StartupError(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	manager := core.NewManagerWithClientFactory(cfg, func(kubeconfig, context, namespace string) (k8s.FluxClient, error) {
+		return nil, fmt.Errorf("connection refused")
+	})
+	t.Cleanup(manager.Stop)
+	app := newAppWithManager(cfg, manager)
+	app.starting = true
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+
+	// Startup failures are shown inline instead of exiting
+	app.Update(app.startManager()())
+	assert.False(t, app.loading())
+	assert.Contains(t, app.View(), "connection refused")
+}
+
+func TestApp_ToggleUTC(t *testing.T) {
+	// Registered before newTestApp, so the manager is stopped before the
+	// zone is restored
+	local := config.LocalZone
+	config.LocalZone = time.FixedZone("CEST", 2*60*60)
+	t.Cleanup(func() { config.LocalZone = local })
+
+	app := newTestApp(t, fake.NewClient())
+	app.config.UI.TimeFormat = "15:04"
+	app.detailView.SetResource(k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", LastUpdate: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)})
+	events := []Event{{Type: "Normal", Reason: "Applied", Message: "ok", Timestamp: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)}}
+	app.eventView.SetEvents(events)
+	app.eventsPane.resource = &k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps"}
+	app.eventsPane.events = events
+	assert.Equal(t, "14:30:00", app.eventView.table.Rows()[0][4])
+	assert.Contains(t, app.renderEventsPane(), "14:30:00")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	assert.Equal(t, "UTC", app.config.UI.TimeZoneLabel())
+	assert.Contains(t, app.detailView.renderContent(), "12:30")
+
+	// Event times are formatted on render, so they follow the switch too
+	assert.Equal(t, "12:30:00", app.eventView.table.Rows()[0][4])
+	assert.Contains(t, app.renderEventsPane(), "12:30:00")
+
+	app.statusMessage = ""
+	assert.Contains(t, app.renderFooter(), "time: UTC")
+}
+
+func TestApp_ChangeHighlightFadesAfterRefresh(t *testing.T) {
+	app := newTestApp(t, fake.NewClient())
+	app.config.UI.ChangeHighlightDuration = time.Millisecond
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Ready: true}
+	app.Update(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+
+	// A single re-fetched resource highlights its row too, and schedules the fade
+	apps.Ready = false
+	_, cmd := app.Update(ResourceRefreshMsg{Resource: apps})
+	require.NotNil(t, cmd)
+	assert.IsType(t, ChangeHighlightExpiredMsg{}, cmd())
+	assert.False(t, app.resourceView.fadePending)
+}
+
+func TestApp_RefreshResource(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Status: "Progressing"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+
+	// Opening the details re-fetches just that object
+	fresh := apps
+	fresh.Cluster, fresh.Ready, fresh.Status = "", true, "ReconciliationSucceeded"
+	client.Resources[k8s.ResourceTypeKustomization] = []k8s.Resource{fresh}
+	_, cmd := app.Update(ShowDetailsMsg{Resource: apps})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+
+	require.NotNil(t, app.detailView.GetResource())
+	assert.Equal(t, "ReconciliationSucceeded", app.detailView.GetResource().Status)
+	assert.True(t, app.state.Resources[app.state.CurrentCluster][k8s.ResourceTypeKustomization][0].Ready)
+
+	// A deleted object is reported instead of erroring
+	client.Resources[k8s.ResourceTypeKustomization] = nil
+	app.Update(app.refreshResource(apps)())
+	assert.Equal(t, "Kustomization flux-system/apps no longer exists", app.statusMessage)
+	assert.Empty(t, app.errorMessage)
+}
+
+func TestApp_WatchScreen(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	now := time.Now()
+	apps := k8s.Resource{
+		Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", UID: "uid-apps",
+		Status: "ReconciliationFailed", Message: "kustomize build failed",
+		Conditions: []k8s.Condition{
+			{Type: "Healthy", Status: "True", Reason: "Succeeded", LastTransitionTime: now.Add(-time.Hour)},
+			{Type: "Ready", Status: "False", Reason: "BuildFailed", LastTransitionTime: now.Add(-time.Minute)},
+		},
+	}
+	app.resourceView.SetResources([]k8s.Resource{apps})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	require.Equal(t, ViewWatch, app.currentView)
+
+	// Events are narrowed to the pinned resource
+	app.handleEventUpdate(EventUpdateMsg{Cluster: app.state.CurrentCluster, Events: []Event{
+		{Type: "Warning", Reason: "BuildFailed", Message: "missing resource", InvolvedObject: corev1.ObjectReference{UID: "uid-apps"}},
+		{Type: "Normal", Reason: "Progressing", Message: "other object", InvolvedObject: corev1.ObjectReference{UID: "uid-infra"}},
+	}})
+
+	view := app.watchView.renderContent()
+	assert.Contains(t, view, "Watching Kustomization flux-system/apps")
+	assert.Contains(t, view, "missing resource")
+	assert.NotContains(t, view, "other object")
+	// Newest transition first
+	assert.Less(t, strings.Index(view, "Ready=False"), strings.Index(view, "Healthy=True"))
+
+	// Live updates reach the pinned resource
+	apps.Status = "ReconciliationSucceeded"
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	assert.Contains(t, app.watchView.renderContent(), "ReconciliationSucceeded")
+
+	// R reconciles the pinned resource
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	require.NotNil(t, cmd)
+	app.Update(WatchReconcileMsg{Resource: apps})
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "reconcile", client.Actions[0].Verb)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewResources, app.currentView)
+}
+
+func TestApp_ManifestView(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	client.Manifests[fake.ManifestKey(k8s.ResourceTypeKustomization, "apps", "flux-system")] = "apiVersion: kustomize.toolkit.fluxcd.io/v1\nkind: Kustomization\n"
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+
+	assert.Equal(t, ViewYAML, app.currentView)
+	assert.Contains(t, app.View(), "kind")
+	assert.Contains(t, app.View(), "Kustomization flux-system/apps")
+	assert.Equal(t, "apps", app.selectedResource().Name)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewResources, app.currentView)
+
+	// A failed fetch stays on the current view
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	client.Manifests = map[string]string{}
+	app.Update(cmd())
+	assert.Equal(t, ViewResources, app.currentView)
+	assert.Contains(t, app.errorMessage, "Failed to fetch manifest of apps")
+}
+
+func TestApp_NameFilterCapturesKeys(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{
+		{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	// q is part of the query rather than quitting
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.Nil(t, cmd)
+	assert.Contains(t, app.renderHeader(), "filter: /q_")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	for _, r := range "inf" {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, app.renderHeader(), "filter: /inf")
+	assert.Equal(t, "infra", app.selectedResource().Name)
+
+	// esc clears the filter before anything else
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, app.resourceView.FilterQuery())
+	assert.Len(t, app.resourceView.resources, 2)
+}
+
+func TestApp_NamespacePicker(t *testing.T) {
+	client := fake.NewClient()
+	client.Namespaces = []string{"flux-system", "team-a", "team-b"}
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.config.Defaults.LargeListWarning = 0
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	require.NotNil(t, app.picker)
+
+	// The picker starts at the current namespace, after "all"
+	view := app.View()
+	assert.Contains(t, view, "Select namespace (3)")
+	assert.Contains(t, view, "> flux-system (current)")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, app.picker)
+	assert.Equal(t, "team-a", app.manager.GetCurrentNamespace())
+	assert.Equal(t, "team-a", app.config.LastNamespace)
+
+	// The choice is restored on the next launch
+	restored, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", restored.CurrentNamespace)
+
+	// "all" is the first entry, and esc leaves the namespace alone
+	app.handleNamespaces(NamespacesMsg{Namespaces: client.Namespaces})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, "team-a", app.manager.GetCurrentNamespace())
+
+	app.handleNamespaces(NamespacesMsg{Namespaces: client.Namespaces})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "", app.manager.GetCurrentNamespace())
+	assert.Equal(t, config.AllNamespaces, app.config.LastNamespace)
+}
+
+func TestApp_LargeListWarning(t *testing.T) {
+	client := fake.NewClient(
+		createTestResource("apps", "flux-system", k8s.ResourceTypeKustomization),
+		createTestResource("tenant-a", "team-a", k8s.ResourceTypeKustomization),
+		createTestResource("tenant-b", "team-b", k8s.ResourceTypeKustomization),
+	)
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.config.Defaults.LargeListWarning = 2
+
+	// Counting runs as a command, the namespace stays put until it's in
+	cmd := app.selectNamespace("")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "flux-system", app.manager.GetCurrentNamespace())
+	assert.Equal(t, "Counting resources across all namespaces...", app.statusMessage)
+
+	msg := cmd()
+	require.IsType(t, LargeListMsg{}, msg)
+	assert.Equal(t, "~3 Kustomizations across all namespaces — this may be slow. Proceed? [y/N]", msg.(LargeListMsg).Warning)
+
+	app.Update(msg)
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "flux-system", app.manager.GetCurrentNamespace())
+
+	// Enter takes the N default and keeps the namespace
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, app.confirm)
+	assert.Equal(t, "flux-system", app.manager.GetCurrentNamespace())
+
+	app.Update(msg)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Equal(t, "", app.manager.GetCurrentNamespace())
+
+	// Small lists switch without asking
+	app.manager.SetCurrentNamespace("flux-system")
+	app.config.Defaults.LargeListWarning = 10
+	app.Update(app.selectNamespace("")())
+	assert.Nil(t, app.confirm)
+	assert.Equal(t, "", app.manager.GetCurrentNamespace())
+}
+
+func TestApp_LabelSelector(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	app.executeCommand("label team=payments,tier in (web, api)")
+	assert.Equal(t, "team=payments,tier in (api,web)", app.manager.GetLabelSelector())
+	assert.Equal(t, "Showing resources matching team=payments,tier in (api,web)", app.statusMessage)
+	assert.Contains(t, app.renderHeader(), "Labels: team=payments,tier in (api,web)")
+
+	app.executeCommand("label team in")
+	assert.Contains(t, app.errorMessage, "invalid label selector")
+	assert.Equal(t, "team=payments,tier in (api,web)", app.manager.GetLabelSelector())
+
+	app.executeCommand("label")
+	assert.Empty(t, app.manager.GetLabelSelector())
+	assert.Equal(t, "Cleared the label selector", app.statusMessage)
+	assert.NotContains(t, app.renderHeader(), "Labels:")
+}
+
+func TestApp_ContextSwitcher(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	app.state.Resources[app.state.CurrentCluster] = map[k8s.ResourceType][]k8s.Resource{
+		k8s.ResourceTypeKustomization: {{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}},
+	}
+
+	app.Update(ContextsMsg{Contexts: []string{"production", "staging"}})
+	require.NotNil(t, app.picker)
+	assert.Contains(t, app.View(), "Select context (2)")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "Connecting to staging...", app.statusMessage)
+	app.Update(cmd())
+
+	assert.Equal(t, "staging", app.state.CurrentCluster)
+	assert.Equal(t, "staging", app.manager.GetCurrentContext())
+	assert.Empty(t, app.state.Resources)
+	assert.Equal(t, "Switched to context staging", app.statusMessage)
+
+	app.statusMessage = ""
+	assert.Contains(t, app.renderFooter(), "context: staging")
+
+	// Failures keep the current context
+	app.Update(ContextSwitchedMsg{Context: "broken", Err: fmt.Errorf("failed to connect to context broken")})
+	assert.Equal(t, "staging", app.state.CurrentCluster)
+	assert.Equal(t, "failed to connect to context broken", app.errorMessage)
+}
+
+func TestApp_BulkSuspend(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	app.resourceView.SetResources([]k8s.Resource{
+		{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "remote", Namespace: "flux-system", Cluster: "gone"},
+	})
+
+	// Space selects the row and moves on to the next
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	require.Len(t, app.resourceView.MarkedResources(), 3)
+	assert.Contains(t, app.resourceLabel(), "[3 selected]")
+	assert.Contains(t, app.resourceView.View(), markMarker+"flux-system/apps")
+
+	app.executeCommand("suspend")
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "Suspend 3 Kustomization resources? [y/N]", app.confirm.message)
+	assert.Empty(t, client.Actions)
+
+	_, cmd := app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+
+	// Every resource is attempted and the failures reported together
+	assert.Len(t, client.Actions, 2)
+	assert.Equal(t, "Suspended 2 of 3 resources, failed: remote (cluster gone not connected)", app.errorMessage)
+	marked := app.resourceView.MarkedResources()
+	require.Len(t, marked, 1)
+	assert.Equal(t, "remote", marked[0].Name)
+
+	// esc clears the selection
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, app.resourceView.MarkedResources())
+}
+
+func TestApp_BulkReconcile(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	kustomizations := []k8s.Resource{
+		{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+		{Type: k8s.ResourceTypeKustomization, Name: "remote", Namespace: "flux-system", Cluster: "gone"},
+	}
+	app.state.Resources[app.state.CurrentCluster] = map[k8s.ResourceType][]k8s.Resource{
+		k8s.ResourceTypeKustomization: kustomizations,
+		k8s.ResourceTypeGitRepository: {{Type: k8s.ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system"}},
+		// Providers can't be reconciled and are left out
+		k8s.ResourceTypeProvider: {{Type: k8s.ResourceTypeProvider, Name: "slack", Namespace: "flux-system"}},
+	}
+	app.resourceView.SetResources(kustomizations)
+
+	// Every displayed row of the active type
+	app.executeCommand("reconcile --all")
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "Reconcile 3 Kustomization resources? [y/N]", app.confirm.message)
+	_, cmd := app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	assert.Len(t, client.Actions, 2)
+	assert.Equal(t, "Reconciled 2 of 3 resources, failed: remote (cluster gone not connected)", app.errorMessage)
+
+	// Every listed resource that supports reconciling
+	client.Actions = nil
+	app.resourceView.ClearMarks()
+	app.errorMessage = ""
+	app.executeCommand("reconcile --all-types")
+	require.NotNil(t, app.confirm)
+	assert.Equal(t, "Reconcile 4 resources of all types? [y/N]", app.confirm.message)
+	_, cmd = app.handleConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	app.Update(cmd())
+	assert.ElementsMatch(t, []fake.Action{
+		{Verb: "reconcile", Type: k8s.ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system"},
+		{Verb: "reconcile", Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Verb: "reconcile", Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	}, client.Actions)
+}
+
+func TestApp_EventsPane(t *testing.T) {
+	client := fake.NewClient()
+	now := time.Now()
+	client.Events = []corev1.Event{
+		{
+			InvolvedObject: corev1.ObjectReference{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"},
+			Type:           "Warning", Reason: "BuildFailed", Message: "missing resource", LastTimestamp: metav1.NewTime(now),
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "infra", Namespace: "flux-system"},
+			Type:           "Normal", Reason: "ReconciliationSucceeded", Message: "applied revision", LastTimestamp: metav1.NewTime(now),
+		},
+	}
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	app.resourceView.SetResourceType(k8s.ResourceTypeKustomization)
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{
+		{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"},
+		{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"},
+	}})
+
+	// fetch runs the debounced fetch a selection change schedules
+	fetch := func(cmd tea.Cmd) {
+		t.Helper()
+		require.NotNil(t, cmd)
+		tick := cmd()
+		if batch, ok := tick.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				if cmd == nil {
+					continue
+				}
+				if msg, ok := cmd().(eventsPaneTickMsg); ok {
+					tick = msg
+				}
+			}
+		}
+		require.IsType(t, eventsPaneTickMsg{}, tick)
+		_, cmd = app.Update(tick)
+		require.NotNil(t, cmd)
+		app.Update(cmd())
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.Contains(t, app.View(), "Loading events")
+	fetch(cmd)
+	view := app.View()
+	assert.Contains(t, view, "Events of Kustomization flux-system/apps")
+	assert.Contains(t, view, "missing resource")
+	assert.NotContains(t, view, "applied revision")
+
+	// The pane follows the cursor
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	fetch(cmd)
+	view = app.View()
+	assert.Contains(t, view, "Events of Kustomization flux-system/infra")
+	assert.Contains(t, view, "applied revision")
+	assert.NotContains(t, view, "missing resource")
+
+	// A fetch for a row the cursor already left is dropped
+	app.Update(ResourceEventsMsg{Resource: k8s.Resource{Name: "apps"}, Events: []Event{{Message: "stale"}}, seq: app.eventsPane.seq - 1})
+	assert.NotContains(t, app.View(), "stale")
+
+	// Long and multi-line messages keep to one row each, so the frame fits
+	app.Update(tea.WindowSizeMsg{Width: 60, Height: 40})
+	app.eventsPane.events = []Event{{Type: "Warning", Reason: "BuildFailed", Message: "kustomize build failed:\n" + strings.Repeat("accumulating resources ", 10)}}
+	pane := strings.Split(app.renderEventsPane(), "\n")
+	require.Len(t, pane, 2)
+	for _, line := range pane {
+		assert.LessOrEqual(t, lipgloss.Width(line), 60)
+	}
+	assert.LessOrEqual(t, len(strings.Split(app.View(), "\n")), 40)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.NotContains(t, app.View(), "Events of")
+}
+
+func TestApp_EventsScreen(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	require.Equal(t, ViewEvents, app.currentView)
+
+	app.Update(EventUpdateMsg{Cluster: app.state.CurrentCluster, Events: []Event{
+		{Type: "Normal", Reason: "ReconciliationSucceeded", Object: "Kustomization/apps", Message: "applied"},
+		{Type: "Warning", Reason: "HealthCheckFailed", Object: "Kustomization/infra", Message: "timeout"},
+		{Type: "Normal", Reason: "ReconciliationSucceeded", Object: "Kustomization/infra", Message: "applied"},
+	}})
+	view := app.View()
+	assert.Contains(t, view, "reason: all")
+	assert.Contains(t, view, "HealthChe…")
+	assert.Contains(t, view, "Kustomization/apps")
+
+	// f cycles the reason filter through the reasons present
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	view = app.View()
+	assert.Contains(t, view, "reason: HealthCheckFailed")
+	assert.NotContains(t, view, "Kustomization/apps")
+	assert.Contains(t, view, "Kustomization/infra")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Contains(t, app.View(), "reason: ReconciliationSucceeded")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Contains(t, app.View(), "reason: all")
+
+	// The screen still fits the terminal
+	assert.LessOrEqual(t, len(strings.Split(app.View(), "\n")), 30)
+}
+
+func TestApp_JumpToSource(t *testing.T) {
+	client := fake.NewClient()
+	apps := k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Source: "GitRepository/fleet", SourceRef: &k8s.SourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}}
+	client.SetResources(k8s.ResourceTypeGitRepository,
+		k8s.Resource{Type: k8s.ResourceTypeGitRepository, Name: "charts", Namespace: "flux-system"},
+		k8s.Resource{Type: k8s.ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system"},
+	)
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.Resources[app.state.CurrentCluster] = map[k8s.ResourceType][]k8s.Resource{
+		k8s.ResourceTypeGitRepository: client.Resources[k8s.ResourceTypeGitRepository],
+		k8s.ResourceTypeKustomization: {apps},
+	}
+
+	// From the detail view of a Kustomization
+	app.detailView.SetResource(apps)
+	app.currentView = ViewDetails
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+
+	assert.Equal(t, ViewResources, app.currentView)
+	assert.Equal(t, k8s.ResourceTypeGitRepository, app.state.CurrentResource)
+	selected := app.resourceView.GetSelectedResource()
+	require.NotNil(t, selected)
+	assert.Equal(t, "fleet", selected.Name)
+
+	// Sources have no source of their own
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	assert.Nil(t, cmd)
+	assert.Contains(t, app.statusMessage, "Only Kustomizations and HelmReleases have a source")
+}
+
+func TestApp_Drift(t *testing.T) {
+	client := fake.NewClient()
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+	cluster := app.state.CurrentCluster
+
+	apps := k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system", Source: "GitRepository/fleet", SourceRef: &k8s.SourceReference{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}, Revision: "main@sha1:old"}
+	app.Update(ResourceUpdateMsg{Cluster: cluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	app.detailView.SetResource(apps)
+	assert.False(t, app.state.Resources[cluster][k8s.ResourceTypeKustomization][0].Drift)
+
+	// The source arriving later marks the Kustomization behind it
+	fleet := k8s.Resource{Type: k8s.ResourceTypeGitRepository, Name: "fleet", Namespace: "flux-system", Revision: "main@sha1:new"}
+	app.Update(ResourceUpdateMsg{Cluster: cluster, Type: k8s.ResourceTypeGitRepository, Resources: []k8s.Resource{fleet}})
+	assert.True(t, app.state.Resources[cluster][k8s.ResourceTypeKustomization][0].Drift)
+	selected := app.resourceView.GetSelectedResource()
+	require.NotNil(t, selected)
+	assert.True(t, selected.Drift)
+
+	// And so does the detail view once the Kustomization refreshes
+	app.Update(ResourceUpdateMsg{Cluster: cluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	assert.Contains(t, app.detailView.View(), "main@sha1:old (behind source)")
+
+	// Catching up clears it
+	apps.Revision = fleet.Revision
+	app.Update(ResourceUpdateMsg{Cluster: cluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+	assert.False(t, app.state.Resources[cluster][k8s.ResourceTypeKustomization][0].Drift)
+}
+
+func TestApp_ControllerLogs(t *testing.T) {
+	client := fake.NewClient()
+	client.Logs["kustomize-controller"] = "reconciling apps\nreconciling infra\napps failed: missing resource\n"
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	require.Equal(t, ViewLogs, app.currentView)
+
+	// Only the lines mentioning the resource, until f shows them all
+	view := app.View()
+	assert.Contains(t, view, "kustomize-controller logs")
+	assert.Contains(t, view, "apps failed: missing resource")
+	assert.NotContains(t, view, "reconciling infra")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Contains(t, app.View(), "reconciling infra")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewResources, app.currentView)
+
+	// A controller without a pod stays on the current view
+	delete(client.Logs, "kustomize-controller")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	assert.Equal(t, ViewResources, app.currentView)
+	assert.Contains(t, app.errorMessage, "Failed to fetch controller logs for apps")
+}
+
+func TestApp_DependencyTree(t *testing.T) {
+	client := fake.NewClient()
+	podinfo := &k8s.TreeNode{
+		Object: k8s.ObjectRef{Kind: "HelmRelease", Namespace: "apps", Name: "podinfo", Group: "helm.toolkit.fluxcd.io"},
+		Children: []*k8s.TreeNode{
+			{Object: k8s.ObjectRef{Kind: "Deployment", Namespace: "apps", Name: "podinfo"}},
+			{Object: k8s.ObjectRef{Kind: "Service", Namespace: "apps", Name: "podinfo"}},
+		},
+	}
+	client.Trees[fake.ManifestKey(k8s.ResourceTypeKustomization, "apps", "flux-system")] = &k8s.TreeNode{
+		Object:   k8s.ObjectRef{Kind: "Kustomization", Namespace: "flux-system", Name: "apps"},
+		Children: []*k8s.TreeNode{podinfo, {Object: k8s.ObjectRef{Kind: "Namespace", Name: "apps"}}},
+	}
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	require.Equal(t, ViewTree, app.currentView)
+
+	view := app.View()
+	assert.Contains(t, view, "├── ▾ HelmRelease/apps/podinfo")
+	assert.Contains(t, view, "│   ├── Deployment/apps/podinfo")
+	assert.Contains(t, view, "└── Namespace/apps")
+
+	// Collapsing the HelmRelease hides what it rendered
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = app.View()
+	assert.Contains(t, view, "▸ HelmRelease/apps/podinfo (2)")
+	assert.NotContains(t, view, "Deployment/apps/podinfo")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewResources, app.currentView)
+}
+
+func TestApp_KustomizationDiff(t *testing.T) {
+	client := fake.NewClient()
+	client.Diffs["flux-system/apps"] = "--- live/ConfigMap/apps/podinfo\n+++ merged/ConfigMap/apps/podinfo\n@@ -1 +1 @@\n-  color: blue\n+  color: green\n"
+	app := newTestApp(t, client)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.state.CurrentResource = k8s.ResourceTypeKustomization
+
+	apps := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+	infra := k8s.Resource{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Name: "infra", Namespace: "flux-system"}
+	app.handleResourceUpdate(ResourceUpdateMsg{Cluster: app.state.CurrentCluster, Type: k8s.ResourceTypeKustomization, Resources: []k8s.Resource{apps, infra}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	require.Equal(t, ViewDiff, app.currentView)
+	view := app.View()
+	assert.Contains(t, view, "Kustomization flux-system/apps: cluster")
+	assert.Contains(t, view, "+  color: green")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewResources, app.currentView)
+
+	// Sources that haven't fetched report why
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	app.Update(cmd())
+	assert.Equal(t, ViewResources, app.currentView)
+	assert.Contains(t, app.errorMessage, "Failed to diff infra")
+	assert.Contains(t, app.errorMessage, k8s.ErrNoArtifact.Error())
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// Markers prefixing the name of rows whose health changed in a recent refresh:
+// recovered rows became Ready, regressed rows stopped being Ready and other
+// rows changed their Status or Revision
+const (
+	changeMarker    = "• "
+	recoveredMarker = "▲ "
+	regressedMarker = "▼ "
+)
+
+// rowChange is a highlighted change of a row
+type rowChange struct {
+	until  time.Time // When the highlight fades
+	marker string
+}
+
+// ChangeHighlightExpiredMsg re-renders the table once change highlights fade
+type ChangeHighlightExpiredMsg struct{}
+
+// rowKey identifies a resource across refreshes
+func rowKey(r k8s.Resource) string {
+	return r.Cluster + "/" + string(r.Type) + "/" + r.Namespace + "/" + r.Name
+}
+
+// resourceChanged reports whether a refresh changed the health a row shows
+func resourceChanged(before, after k8s.Resource) bool {
+	return before.Ready != after.Ready || before.Status != after.Status || before.Revision != after.Revision
+}
+
+// changeMarkerFor returns the marker of a change, telling recoveries and
+// regressions apart from other changes
+func changeMarkerFor(before, after k8s.Resource) string {
+	switch {
+	case !before.Ready && after.Ready:
+		return recoveredMarker
+	case before.Ready && !after.Ready:
+		return regressedMarker
+	}
+	return changeMarker
+}
+
+// trackChanges marks resources whose Ready, Status or Revision differ from the
+// previous snapshot and replaces the snapshot of the received types. Resources
+// seen for the first time are not marked.
+func (v *ResourceView) trackChanges(resources []k8s.Resource, now time.Time) {
+	if !v.config.UI.ChangeHighlight {
+		return
+	}
+	if v.previous == nil {
+		v.previous = make(map[string]k8s.Resource)
+		v.changed = make(map[string]rowChange)
+	}
+
+	types := make(map[k8s.ResourceType]bool)
+	for _, resource := range resources {
+		types[resource.Type] = true
+		if before, ok := v.previous[rowKey(resource)]; ok && resourceChanged(before, resource) {
+			v.changed[rowKey(resource)] = rowChange{
+				until:  now.Add(v.config.UI.ChangeHighlightDuration),
+				marker: changeMarkerFor(before, resource),
+			}
+			v.fadePending = true
+		}
+	}
+
+	// Other types keep their snapshot for when the user switches back to them
+	for key, resource := range v.previous {
+		if types[resource.Type] {
+			delete(v.previous, key)
+		}
+	}
+	for _, resource := range resources {
+		v.previous[rowKey(resource)] = resource
+	}
+}
+
+// highlight returns the marker of a resource's row if it recently changed
+func (v *ResourceView) highlight(resource k8s.Resource, now time.Time) (string, bool) {
+	change, ok := v.changed[rowKey(resource)]
+	if !ok || !now.Before(change.until) {
+		return "", false
+	}
+	return change.marker, true
+}
+
+// fadeChanges drops expired highlights and re-renders if any were dropped
+func (v *ResourceView) fadeChanges(now time.Time) {
+	faded := false
+	for key, change := range v.changed {
+		if !now.Before(change.until) {
+			delete(v.changed, key)
+			faded = true
+		}
+	}
+	if faded {
+		v.updateTable()
+	}
+}
+
+// changeHighlightTick schedules fading the highlights set by the last refresh
+func (m *AppModel) changeHighlightTick() tea.Cmd {
+	if !m.resourceView.fadePending {
+		return nil
+	}
+	m.resourceView.fadePending = false
+	return tea.Tick(m.config.UI.ChangeHighlightDuration, func(time.Time) tea.Msg { return ChangeHighlightExpiredMsg{} })
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// maxClipboardBytes is the largest manifest copied to the clipboard; larger
+// ones are saved to a file since clipboard tools and terminals truncate them
+const maxClipboardBytes = 1 << 20
+
+// maxOSC52Bytes is the most text copied through the terminal, since many
+// terminals drop longer OSC 52 sequences
+const maxOSC52Bytes = 64 << 10
+
+// viaOSC52 is the YankResultMsg.Via of text copied through the terminal
+const viaOSC52 = "OSC 52"
+
+// writeClipboard copies text to the system clipboard, swapped out in tests
+var writeClipboard = clipboard.WriteAll
+
+// clipboardUnsupported reports whether no clipboard tool is available
+var clipboardUnsupported = func() bool { return clipboard.Unsupported }
+
+// terminalOutput is the output the program renders to. Writes to it are
+// serialized, and the renderer writes each frame in one Write, so a sequence
+// written from a command lands between frames rather than inside one.
+var terminalOutput = &lockedFile{File: os.Stdout}
+
+// lockedFile is a file whose writes don't interleave
+type lockedFile struct {
+	*os.File
+	mu sync.Mutex
+}
+
+// Write writes p to the file in one piece
+func (f *lockedFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.Write(p)
+}
+
+// writeOSC52 asks the terminal to put text on its clipboard, swapped out in
+// tests. It reaches the clipboard of the machine the terminal runs on, which
+// is the one that matters over SSH.
+var writeOSC52 = func(text string) error {
+	if !term.IsTerminal(terminalOutput.Fd()) {
+		return errors.New("output is not a terminal")
+	}
+	sequence := osc52.New(text)
+	if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		sequence = sequence.Screen()
+	}
+	_, err := sequence.WriteTo(terminalOutput)
+	return err
+}
+
+// remoteSession reports whether fluxcli runs over SSH, where the system
+// clipboard belongs to the remote host rather than the user
+func remoteSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyToClipboard copies text to the system clipboard, falling back to OSC 52
+// when no clipboard tool works or the session is remote. It returns viaOSC52
+// when the terminal was asked to copy.
+func copyToClipboard(text string) (string, error) {
+	if !remoteSession() && !clipboardUnsupported() {
+		if err := writeClipboard(text); err == nil {
+			return "", nil
+		}
+	}
+	if len(text) > maxOSC52Bytes {
+		return "", fmt.Errorf("%s exceeds the terminal clipboard limit", formatBytes(int64(len(text))))
+	}
+	if err := writeOSC52(text); err != nil {
+		return "", fmt.Errorf("failed to copy through the terminal: %w", err)
+	}
+	return viaOSC52, nil
+}
+
+// YankResultMsg reports the outcome of copying a manifest or name
+type YankResultMsg struct {
+	Resource k8s.Resource
+	Name     bool // Set when the namespace/name was copied instead of the manifest
+	Redacted bool
+	Bytes    int
+	Via      string // viaOSC52 when the terminal was asked to copy
+	Path     string // Set when the manifest was saved to a file instead
+	Reason   string // Why the clipboard was bypassed
+	Err      error
+}
+
+// yankName copies the namespace/name of a resource, ready to paste into
+// kubectl or flux
+func yankName(resource k8s.Resource) tea.Cmd {
+	return func() tea.Msg {
+		result := YankResultMsg{Resource: resource, Name: true}
+		result.Via, result.Err = copyToClipboard(resource.Namespace + "/" + resource.Name)
+		return result
+	}
+}
+
+// yankYAML fetches a resource's manifest, optionally redacts it and copies it
+// to the clipboard, falling back to a file when the clipboard is unavailable
+func (m *AppModel) yankYAML(resource k8s.Resource, redacted bool) tea.Cmd {
+	return func() tea.Msg {
+		result := YankResultMsg{Resource: resource, Redacted: redacted}
+
+		manifest, err := m.manager.GetResourceYAML(resource)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if redacted {
+			if manifest, err = k8s.RedactYAML(manifest); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+		result.Bytes = len(manifest)
+
+		if len(manifest) > maxClipboardBytes {
+			result.Reason = "Manifest too large for clipboard"
+		} else if result.Via, err = copyToClipboard(manifest); err == nil {
+			return result
+		} else {
+			result.Reason = "Clipboard unavailable"
+		}
+
+		result.Path, result.Err = saveManifest(resource, manifest)
+		return result
+	}
+}
+
+// saveManifest writes a manifest to a file in the temp directory
+func saveManifest(resource k8s.Resource, manifest string) (string, error) {
+	name := fmt.Sprintf("fluxcli-%s-%s-%s.yaml", resource.Type, resource.Namespace, resource.Name)
+	path := filepath.Join(os.TempDir(), name)
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		return "", fmt.Errorf("failed to save manifest to %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// yankStatus renders the status line for a yank result
+func yankStatus(msg YankResultMsg) string {
+	what := "manifest"
+	if msg.Redacted {
+		what = "redacted manifest"
+	}
+	target := fmt.Sprintf("%s/%s", msg.Resource.Namespace, msg.Resource.Name)
+	via := ""
+	if msg.Via != "" {
+		via = " via " + msg.Via
+	}
+
+	if msg.Name {
+		return fmt.Sprintf("Copied %s%s", target, via)
+	}
+	if msg.Path != "" {
+		return fmt.Sprintf("%s, saved %s of %s to %s", msg.Reason, what, target, msg.Path)
+	}
+	return fmt.Sprintf("Copied %s of %s (%s)%s", what, target, formatBytes(int64(msg.Bytes)), via)
+}
+
+// formatBytes renders a byte count in KiB above one kibibyte and in MiB
+// above one mebibyte
+func formatBytes(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KiB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%.1f MiB", float64(n)/(1024*1024))
+}
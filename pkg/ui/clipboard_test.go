@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+	"github.com/malagant/fluxcli/pkg/k8s/fake"
+)
+
+// stubClipboard replaces the system clipboard for the duration of a test,
+// with a terminal that doesn't support OSC 52 and a local session
+func stubClipboard(t *testing.T, write func(string) error) {
+	origWrite, origUnsupported, origOSC52 := writeClipboard, clipboardUnsupported, writeOSC52
+	writeClipboard = write
+	clipboardUnsupported = func() bool { return false }
+	writeOSC52 = func(string) error { return errors.New("output is not a terminal") }
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+	t.Cleanup(func() {
+		writeClipboard, clipboardUnsupported, writeOSC52 = origWrite, origUnsupported, origOSC52
+	})
+}
+
+func TestApp_YankYAML(t *testing.T) {
+	client := fake.NewClient()
+	client.Manifests[fake.ManifestKey(k8s.ResourceTypeHelmRelease, "podinfo", "default")] = "kind: HelmRelease\nspec:\n  values:\n    password: hunter2\n"
+	app := newTestApp(t, client)
+	resource := k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "podinfo", Namespace: "default"}
+
+	var copied string
+	stubClipboard(t, func(text string) error {
+		copied = text
+		return nil
+	})
+
+	msg := app.yankYAML(resource, false)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	assert.Contains(t, copied, "hunter2")
+	assert.Contains(t, yankStatus(msg), "Copied manifest of default/podinfo")
+
+	// The redacted variant masks values
+	msg = app.yankYAML(resource, true)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	assert.NotContains(t, copied, "hunter2")
+	assert.Contains(t, copied, "password: REDACTED")
+
+	// Without a clipboard the manifest is saved to a file instead
+	stubClipboard(t, func(string) error { return errors.New("no clipboard tool") })
+	msg = app.yankYAML(resource, false)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	require.NotEmpty(t, msg.Path)
+	t.Cleanup(func() { os.Remove(msg.Path) })
+	saved, err := os.ReadFile(msg.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "hunter2")
+	assert.Contains(t, yankStatus(msg), "Clipboard unavailable")
+
+	// Fetch errors are surfaced
+	msg = app.yankYAML(k8s.Resource{Type: k8s.ResourceTypeHelmRelease, Name: "missing", Namespace: "default"}, false)().(YankResultMsg)
+	assert.Error(t, msg.Err)
+}
+
+func TestApp_YankName(t *testing.T) {
+	resource := k8s.Resource{Type: k8s.ResourceTypeKustomization, Name: "apps", Namespace: "flux-system"}
+
+	var copied, sent string
+	stubClipboard(t, func(text string) error {
+		copied = text
+		return nil
+	})
+	writeOSC52 = func(text string) error {
+		sent = text
+		return nil
+	}
+
+	msg := yankName(resource)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	assert.Equal(t, "flux-system/apps", copied)
+	assert.Empty(t, sent)
+	assert.Equal(t, "Copied flux-system/apps", yankStatus(msg))
+
+	// Without a clipboard tool the terminal is asked to copy
+	copied = ""
+	clipboardUnsupported = func() bool { return true }
+	msg = yankName(resource)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	assert.Equal(t, "flux-system/apps", sent)
+	assert.Equal(t, "Copied flux-system/apps via OSC 52", yankStatus(msg))
+
+	// Over SSH the local clipboard is skipped for the terminal's
+	clipboardUnsupported = func() bool { return false }
+	sent = ""
+	t.Setenv("SSH_TTY", "/dev/pts/0")
+	msg = yankName(resource)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	assert.Empty(t, copied)
+	assert.Equal(t, "flux-system/apps", sent)
+
+	// Manifests too long for the terminal still go to a file
+	client := fake.NewClient()
+	client.Manifests[fake.ManifestKey(k8s.ResourceTypeKustomization, "apps", "flux-system")] = "data: " + strings.Repeat("x", maxOSC52Bytes)
+	app := newTestApp(t, client)
+	t.Setenv("SSH_TTY", "/dev/pts/0")
+	msg = app.yankYAML(resource, false)().(YankResultMsg)
+	require.NoError(t, msg.Err)
+	require.NotEmpty(t, msg.Path)
+	t.Cleanup(func() { os.Remove(msg.Path) })
+	assert.Contains(t, yankStatus(msg), "Clipboard unavailable")
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// ContextsMsg carries the kubeconfig contexts for the context picker
+type ContextsMsg struct {
+	Contexts []string
+	Err      error
+}
+
+// ContextSwitchedMsg reports the outcome of switching kubeconfig contexts
+type ContextSwitchedMsg struct {
+	Context string
+	Err     error
+}
+
+// openContextPicker lists the kubeconfig contexts for the picker
+func (m *AppModel) openContextPicker() tea.Cmd {
+	if m.config.Fleet {
+		m.statusMessage = "Fleet mode already shows every context"
+		return nil
+	}
+	return func() tea.Msg {
+		contexts, err := m.manager.ListContexts()
+		return ContextsMsg{Contexts: contexts, Err: err}
+	}
+}
+
+// handleContexts opens the picker on the listed contexts
+func (m *AppModel) handleContexts(msg ContextsMsg) {
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to list contexts: %v", msg.Err)
+		return
+	}
+	if len(msg.Contexts) == 0 {
+		m.statusMessage = "No contexts in the kubeconfig"
+		return
+	}
+	title := fmt.Sprintf("Select context (%d)", len(msg.Contexts))
+	m.picker = newPicker(title, msg.Contexts, msg.Contexts, m.manager.GetCurrentContext(), m.switchContext)
+}
+
+// switchContext reconnects to another kubeconfig context in the background
+func (m *AppModel) switchContext(kubeContext string) tea.Cmd {
+	if kubeContext == m.manager.GetCurrentContext() {
+		return nil
+	}
+	m.statusMessage = fmt.Sprintf("Connecting to %s...", kubeContext)
+	return func() tea.Msg {
+		return ContextSwitchedMsg{Context: kubeContext, Err: m.manager.SwitchContext(kubeContext)}
+	}
+}
+
+// handleContextSwitched shows the resources of the new context, which are
+// listed from scratch
+func (m *AppModel) handleContextSwitched(msg ContextSwitchedMsg) tea.Cmd {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = msg.Err.Error()
+		return nil
+	}
+
+	m.state.CurrentCluster = m.manager.GetCurrentCluster()
+	m.state.Resources = make(map[string]map[k8s.ResourceType][]k8s.Resource)
+	m.resourceView.ClearInventoryFilter() // Inventories are per cluster
+	m.resourceView.SetResources(nil)
+	m.currentView = ViewResources
+	m.errorMessage = ""
+	m.statusMessage = fmt.Sprintf("Switched to context %s", msg.Context)
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+}
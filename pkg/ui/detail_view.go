@@ -0,0 +1,487 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// conditionStatusFilters is the cycle order of the condition status filter
+var conditionStatusFilters = []string{"", "False", "True", "Unknown"}
+
+// DetailView displays a single resource and its conditions
+type DetailView struct {
+	config       *config.Config
+	viewport     viewport.Model
+	resource     *k8s.Resource
+	statusFilter string // "" shows all statuses
+	typeFilter   string // "" shows all types
+	health       *k8s.InventoryHealth
+	healthErr    error
+	healthBusy   bool
+	healthPoll   int    // Numbers the latest poll, so results of earlier ones are dropped
+	sourceURL    string // URL of a HelmRelease's chart source, when known
+	width        int
+	height       int
+}
+
+// ShowDetailsMsg requests the detail view for a resource
+type ShowDetailsMsg struct {
+	Resource k8s.Resource
+}
+
+// CheckHealthMsg requests the inventory health of a Kustomization
+type CheckHealthMsg struct {
+	Resource k8s.Resource
+	Poll     int
+}
+
+// InventoryHealthMsg carries the computed inventory health of a Kustomization
+// and the poll it answers
+type InventoryHealthMsg struct {
+	Resource k8s.Resource
+	Poll     int
+	Health   *k8s.InventoryHealth
+	Err      error
+}
+
+// NewDetailView creates a new detail view
+func NewDetailView(cfg *config.Config) *DetailView {
+	return &DetailView{
+		config:   cfg,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// Init initializes the detail view
+func (v *DetailView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the detail view
+func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "f":
+			// Cycle condition status filter
+			v.statusFilter = nextFilter(conditionStatusFilters, v.statusFilter)
+			v.refresh()
+		case "t":
+			// Cycle condition type filter through the types present
+			v.typeFilter = nextFilter(v.conditionTypes(), v.typeFilter)
+			v.refresh()
+		case "w":
+			// Poll the inventory for workload readiness beyond the Ready condition
+			if v.resource != nil && v.resource.Type == k8s.ResourceTypeKustomization && !v.healthBusy {
+				v.healthBusy = true
+				v.healthPoll++
+				v.refresh()
+				resource, poll := *v.resource, v.healthPoll
+				cmd = func() tea.Msg { return CheckHealthMsg{Resource: resource, Poll: poll} }
+			}
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		default:
+			v.viewport, cmd = v.viewport.Update(msg)
+		}
+	}
+
+	return v, cmd
+}
+
+// View renders the detail view
+func (v *DetailView) View() string {
+	if v.resource == nil {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("244")).
+			Render("No resource selected")
+	}
+
+	return v.viewport.View()
+}
+
+// SetResource sets the resource to display, resetting filters and the
+// inventory health when the resource changes
+func (v *DetailView) SetResource(resource k8s.Resource) {
+	if v.resource == nil || !sameResource(*v.resource, resource) {
+		v.statusFilter = ""
+		v.typeFilter = ""
+		v.sourceURL = ""
+		v.health = nil
+		v.healthErr = nil
+		v.healthBusy = false
+		v.healthPoll++ // A poll still running is for the previous resource
+		v.viewport.GotoTop()
+	}
+	v.resource = &resource
+	v.refresh()
+}
+
+// SetHealth sets the inventory health of the displayed Kustomization,
+// ignoring results for any other resource or an earlier poll
+func (v *DetailView) SetHealth(msg InventoryHealthMsg) {
+	if v.resource == nil || !sameResource(*v.resource, msg.Resource) || msg.Poll != v.healthPoll {
+		return
+	}
+	v.health = msg.Health
+	v.healthErr = msg.Err
+	v.healthBusy = false
+	v.refresh()
+}
+
+// SetSourceURL sets the URL of the displayed HelmRelease's chart source
+func (v *DetailView) SetSourceURL(url string) {
+	v.sourceURL = url
+	v.refresh()
+}
+
+// sameResource reports whether two resources are the same object
+func sameResource(a, b k8s.Resource) bool {
+	return a.Cluster == b.Cluster && a.Type == b.Type && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// GetResource returns the displayed resource
+func (v *DetailView) GetResource() *k8s.Resource {
+	return v.resource
+}
+
+// SetSize sets the view dimensions
+func (v *DetailView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height
+	v.refresh()
+}
+
+// refresh re-renders the viewport content
+func (v *DetailView) refresh() {
+	if v.resource == nil {
+		return
+	}
+	v.viewport.SetContent(v.renderContent())
+}
+
+// shortDigestLength is how many checksum characters shortDigest keeps
+const shortDigestLength = 12
+
+// shortDigest abbreviates an artifact digest like sha256:0123456789ab, enough
+// to tell fetches apart
+func shortDigest(digest string) string {
+	algorithm, checksum, ok := strings.Cut(digest, ":")
+	if !ok || len(checksum) <= shortDigestLength {
+		return digest
+	}
+	return algorithm + ":" + checksum[:shortDigestLength]
+}
+
+// renderContent renders the resource details
+func (v *DetailView) renderContent() string {
+	r := v.resource
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var b strings.Builder
+	b.WriteString(styledTypeLabel(v.config, r.Type))
+	b.WriteString(" ")
+	b.WriteString(title.Render(fmt.Sprintf("%s %s/%s", r.Type, r.Namespace, r.Name)))
+	b.WriteString("\n\n")
+
+	ready := "False"
+	if r.Ready {
+		ready = "True"
+	}
+	if !r.ReadySince.IsZero() {
+		ready += fmt.Sprintf(" for %s, since %s", formatAge(time.Since(r.ReadySince)), formatTimestamp(v.config, r.ReadySince))
+	}
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Ready:  "), ready)
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Status: "), r.Status)
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Message:"), r.Message)
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Updated:"), formatTimestamp(v.config, r.LastUpdate))
+	suspended := "False"
+	if r.Suspended {
+		suspended = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render("True")
+	}
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Suspended:"), suspended)
+	if r.Stalled {
+		stalled := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Stalled:"), stalled.Render("True (retries stopped, needs manual intervention)"))
+	}
+	if r.DecryptFailed {
+		decrypt := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Decryption:"), decrypt.Render("failed, check the SOPS keys in spec.decryption"))
+	}
+	// HelmReleases show their source and revision in the chart section
+	if r.Type != k8s.ResourceTypeHelmRelease {
+		if r.Source != "" {
+			fmt.Fprintf(&b, "%s %s\n", label.Render("Source: "), r.Source)
+		}
+		revision := valueOrDash(r.Revision)
+		if r.Drift {
+			revision = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(revision + " (behind source)")
+		}
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Revision:"), revision)
+		if r.Digest != "" {
+			fmt.Fprintf(&b, "%s %s (%s)\n", label.Render("Artifact:"), shortDigest(r.Digest), formatBytes(r.Size))
+		}
+		if r.InventoryCount > 0 {
+			fmt.Fprintf(&b, "%s manages %d objects\n", label.Render("Inventory:"), r.InventoryCount)
+		}
+	}
+	if r.FetchError != "" {
+		fetchError := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Fetch:  "), fetchError.Render(r.FetchError))
+	}
+	if r.SecretRef != "" || r.AuthFailed {
+		credentials := "secret " + r.SecretRef
+		if r.SecretRef == "" {
+			credentials = "none"
+		}
+		if r.AuthFailed {
+			credentials = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")).Render(credentials + " (authentication failed)")
+		}
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Credentials:"), credentials)
+	}
+	if r.Interval > 0 {
+		interval := fmt.Sprintf("%s, last reconcile %s", formatAge(r.Interval), formatTimestamp(v.config, r.LastReconcile))
+		if !r.NextReconcile.IsZero() {
+			interval += ", next sync " + formatNextSync(*r, time.Now())
+		}
+		if r.Overdue {
+			interval = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render(interval + " (overdue)")
+		}
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Interval:"), interval)
+	}
+	if r.Snoozed(time.Now()) {
+		fmt.Fprintf(&b, "%s 💤 until %s (still reconciling)\n", label.Render("Snoozed:"), formatTimestamp(v.config, r.SnoozedUntil))
+	}
+	b.WriteString("\n")
+
+	conditions := v.filteredConditions()
+	b.WriteString(title.Render(fmt.Sprintf("Conditions (%d/%d)", len(conditions), len(r.Conditions))))
+	b.WriteString(label.Render(fmt.Sprintf("  status: %s  type: %s  (f/t to cycle)", filterLabel(v.statusFilter), filterLabel(v.typeFilter))))
+	b.WriteString("\n")
+
+	if len(conditions) == 0 {
+		b.WriteString(label.Render("  No matching conditions"))
+		b.WriteString("\n")
+	}
+	for _, cond := range conditions {
+		fmt.Fprintf(&b, "  %-20s %-8s %-28s %s\n", cond.Type, cond.Status, cond.Reason, formatTimestamp(v.config, cond.LastTransitionTime))
+		if cond.Message != "" {
+			fmt.Fprintf(&b, "    %s\n", cond.Message)
+		}
+	}
+
+	if r.Type == k8s.ResourceTypeHelmRelease && r.ChartSource != nil {
+		b.WriteString("\n")
+		b.WriteString(v.renderChart(title, label))
+	}
+	if r.Type == k8s.ResourceTypeHelmRelease && len(r.History) > 0 {
+		b.WriteString("\n")
+		b.WriteString(v.renderHistory(title, label))
+	}
+
+	if r.Type == k8s.ResourceTypeKustomization {
+		b.WriteString("\n")
+		b.WriteString(v.renderHealth(title, label))
+	}
+
+	b.WriteString("\n")
+	if r.Type == k8s.ResourceTypeKustomization || r.Type == k8s.ResourceTypeHelmRelease {
+		b.WriteString(label.Render("u select source | esc back"))
+	} else {
+		b.WriteString(label.Render("esc back"))
+	}
+
+	return asciiSafe(v.config, b.String())
+}
+
+// renderChart renders where a HelmRelease's chart comes from and which
+// versions were requested, applied and attempted
+func (v *DetailView) renderChart(title, label lipgloss.Style) string {
+	r := v.resource
+	src := r.ChartSource
+
+	var b strings.Builder
+	b.WriteString(title.Render("Chart"))
+	b.WriteString("\n")
+
+	source := fmt.Sprintf("%s %s/%s", src.Kind, src.Namespace, src.Name)
+	if src.Kind == "" {
+		source = "-"
+	}
+	if isOCIChart(src, v.sourceURL) {
+		source += " (OCI)"
+	}
+	fmt.Fprintf(&b, "  %s %s\n", label.Render("Source:   "), source)
+	if v.sourceURL != "" {
+		fmt.Fprintf(&b, "  %s %s\n", label.Render("URL:      "), v.sourceURL)
+	}
+
+	if src.ChartRef {
+		fmt.Fprintf(&b, "  %s %s\n", label.Render("Ref:      "), "spec.chartRef")
+	} else {
+		fmt.Fprintf(&b, "  %s %s\n", label.Render("Chart:    "), valueOrDash(r.Chart))
+		fmt.Fprintf(&b, "  %s %s\n", label.Render("Requested:"), valueOrDash(r.Version))
+		if src.HelmChart != "" {
+			fmt.Fprintf(&b, "  %s %s\n", label.Render("HelmChart:"), src.HelmChart)
+		}
+	}
+
+	fmt.Fprintf(&b, "  %s %s\n", label.Render("Applied:  "), valueOrDash(r.Revision))
+	attempted := valueOrDash(src.AttemptedRevision)
+	if src.AttemptedRevision != "" && src.AttemptedRevision != r.Revision {
+		attempted = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(attempted)
+	}
+	fmt.Fprintf(&b, "  %s %s\n", label.Render("Attempted:"), attempted)
+	if r.HelmRevision > 0 {
+		fmt.Fprintf(&b, "  %s %d\n", label.Render("Release:  "), r.HelmRevision)
+	}
+
+	return b.String()
+}
+
+// renderHistory renders the Helm release revisions of a HelmRelease, newest
+// first, so failed upgrades and rollbacks stand out
+func (v *DetailView) renderHistory(title, label lipgloss.Style) string {
+	failed := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	rollback := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	var b strings.Builder
+	b.WriteString(title.Render(fmt.Sprintf("History (%d)", len(v.resource.History))))
+	b.WriteString("\n")
+	for _, snapshot := range v.resource.History {
+		status := fmt.Sprintf("%-12s", snapshot.Status)
+		if snapshot.Status == "failed" {
+			status = failed.Render(status)
+		}
+		line := fmt.Sprintf("  %-4s %s %-14s %s", fmt.Sprintf("#%d", snapshot.Revision), status, valueOrDash(snapshot.ChartVersion), formatTimestamp(v.config, snapshot.Deployed))
+		if snapshot.Rollback {
+			line += rollback.Render("  rollback")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// isOCIChart reports whether a chart is pulled from an OCI registry
+func isOCIChart(src *k8s.ChartSource, url string) bool {
+	return src.Kind == "OCIRepository" || strings.HasPrefix(url, "oci://")
+}
+
+// valueOrDash renders an empty value as "-"
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// renderHealth renders the enhanced readiness section of a Kustomization
+func (v *DetailView) renderHealth(title, label lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(title.Render("Workloads"))
+
+	switch {
+	case v.healthBusy:
+		b.WriteString(label.Render("  polling inventory..."))
+		b.WriteString("\n")
+		return b.String()
+	case v.healthErr != nil:
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  Error: %v\n", v.healthErr)
+		return b.String()
+	case v.health == nil:
+		b.WriteString(label.Render("  (w to check workload readiness)"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	h := v.health
+	b.WriteString(label.Render(fmt.Sprintf("  %d/%d current, %d in progress, %d failed  (w to refresh)", h.Current, len(h.Objects), h.InProgress, h.Failed)))
+	b.WriteString("\n")
+	if h.Truncated {
+		b.WriteString(label.Render("  Inventory truncated, only the first objects were polled"))
+		b.WriteString("\n")
+	}
+	if len(h.Objects) == 0 {
+		b.WriteString(label.Render("  Inventory is empty"))
+		b.WriteString("\n")
+	}
+
+	// Only list objects that are not up, those are what the user is after
+	for _, obj := range h.Objects {
+		if obj.Status == k8s.WorkloadCurrent {
+			continue
+		}
+		fmt.Fprintf(&b, "  %-12s %s\n", obj.Status, obj.Object)
+		if obj.Message != "" {
+			fmt.Fprintf(&b, "    %s\n", obj.Message)
+		}
+	}
+
+	return b.String()
+}
+
+// filteredConditions returns the conditions matching the active filters
+func (v *DetailView) filteredConditions() []k8s.Condition {
+	conditions := make([]k8s.Condition, 0, len(v.resource.Conditions))
+	for _, cond := range v.resource.Conditions {
+		if v.statusFilter != "" && cond.Status != v.statusFilter {
+			continue
+		}
+		if v.typeFilter != "" && cond.Type != v.typeFilter {
+			continue
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions
+}
+
+// conditionTypes returns the type filter cycle: all, then each distinct type
+func (v *DetailView) conditionTypes() []string {
+	types := []string{""}
+	if v.resource == nil {
+		return types
+	}
+
+	seen := make(map[string]bool)
+	for _, cond := range v.resource.Conditions {
+		if !seen[cond.Type] {
+			seen[cond.Type] = true
+			types = append(types, cond.Type)
+		}
+	}
+	return types
+}
+
+// nextFilter returns the filter value following current in the cycle
+func nextFilter(cycle []string, current string) string {
+	for i, value := range cycle {
+		if value == current {
+			return cycle[(i+1)%len(cycle)]
+		}
+	}
+	return ""
+}
+
+// filterLabel renders an empty filter as "all"
+func filterLabel(filter string) string {
+	if filter == "" {
+		return "all"
+	}
+	return filter
+}
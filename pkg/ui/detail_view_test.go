@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+func TestDetailView_ConditionFilters(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	dv := NewDetailView(cfg)
+	dv.SetSize(120, 40)
+
+	resource := createTestResource("podinfo", "default", k8s.ResourceTypeHelmRelease)
+	resource.Conditions = append(resource.Conditions,
+		k8s.Condition{Type: "Released", Status: "False", Reason: "UpgradeFailed", LastTransitionTime: time.Now()},
+		k8s.Condition{Type: "Remediated", Status: "False", Reason: "RollbackSucceeded", LastTransitionTime: time.Now()},
+	)
+	dv.SetResource(resource)
+
+	assert.Len(t, dv.filteredConditions(), 3)
+	assert.Contains(t, dv.renderContent(), "Conditions (3/3)")
+
+	// f cycles the status filter: all -> False
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Equal(t, "False", dv.statusFilter)
+	assert.Len(t, dv.filteredConditions(), 2)
+	assert.Contains(t, dv.renderContent(), "Conditions (2/3)")
+
+	// t cycles the type filter through the types present
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Equal(t, "Ready", dv.typeFilter)
+	assert.Empty(t, dv.filteredConditions())
+
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Equal(t, "Released", dv.typeFilter)
+	assert.Len(t, dv.filteredConditions(), 1)
+
+	// Selecting another resource resets the filters
+	dv.SetResource(createTestResource("other", "default", k8s.ResourceTypeHelmRelease))
+	assert.Equal(t, "", dv.statusFilter)
+	assert.Equal(t, "", dv.typeFilter)
+}
+
+func TestDetailView_HelmReleaseChart(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	dv := NewDetailView(cfg)
+	dv.SetSize(120, 40)
+
+	resource := createTestResource("podinfo", "default", k8s.ResourceTypeHelmRelease)
+	resource.Chart = "podinfo"
+	resource.Version = ">=6.0.0"
+	resource.Revision = "6.5.0"
+	resource.ChartSource = &k8s.ChartSource{
+		Kind:              "HelmRepository",
+		Name:              "podinfo",
+		Namespace:         "flux-system",
+		AttemptedRevision: "6.5.1",
+		HelmChart:         "flux-system/default-podinfo",
+	}
+	dv.SetResource(resource)
+	dv.SetSourceURL("oci://ghcr.io/stefanprodan/charts")
+
+	content := dv.renderContent()
+	assert.Contains(t, content, "HelmRepository flux-system/podinfo (OCI)")
+	assert.Contains(t, content, ">=6.0.0")
+	assert.Contains(t, content, "6.5.1")
+	assert.Contains(t, content, "flux-system/default-podinfo")
+	assert.NotContains(t, content, "History")
+
+	// The release history lists failures and rollbacks
+	resource.HelmRevision = 4
+	resource.History = []k8s.HelmSnapshot{
+		{Revision: 4, Status: "deployed", ChartVersion: "6.5.0", Rollback: true},
+		{Revision: 3, Status: "failed", ChartVersion: "6.5.1"},
+	}
+	dv.SetResource(resource)
+	content = dv.renderContent()
+	assert.Contains(t, content, "Release:   4")
+	assert.Contains(t, content, "History (2)")
+	assert.Regexp(t, `#4 +deployed +6\.5\.0 .*rollback`, content)
+	assert.Regexp(t, `#3 +failed +6\.5\.1`, content)
+	resource.HelmRevision, resource.History = 0, nil
+
+	// Releases using spec.chartRef have no chart template
+	resource.ChartSource = &k8s.ChartSource{Kind: "OCIRepository", Name: "podinfo", Namespace: "default", ChartRef: true}
+	dv.SetResource(resource)
+	dv.SetSourceURL("")
+	content = dv.renderContent()
+	assert.Contains(t, content, "OCIRepository default/podinfo (OCI)")
+	assert.Contains(t, content, "spec.chartRef")
+}
+
+func TestDetailView_SourceAndSuspension(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	dv := NewDetailView(cfg)
+	dv.SetSize(120, 40)
+
+	resource := createTestResource("apps", "flux-system", k8s.ResourceTypeKustomization)
+	resource.Source = "fleet"
+	resource.Revision = "main@sha1:abc123"
+	resource.Suspended = true
+	resource.Conditions[0].Message = "Applied revision: main@sha1:abc123"
+	dv.SetResource(resource)
+
+	content := dv.renderContent()
+	assert.Contains(t, content, "fleet")
+	assert.Contains(t, content, "main@sha1:abc123")
+	assert.Regexp(t, `Suspended:.*True`, content)
+	assert.Regexp(t, `Ready:.* True\n`, content)
+
+	resource.ReadySince = time.Now().Add(-3 * time.Hour)
+	dv.SetResource(resource)
+	assert.Regexp(t, `Ready:.* True for 3h, since \d{4}-`, dv.renderContent())
+	assert.Contains(t, content, "Applied revision: main@sha1:abc123")
+	assert.NotContains(t, content, "Artifact:")
+
+	source := createTestResource("fleet", "flux-system", k8s.ResourceTypeGitRepository)
+	source.Digest = "sha256:0123456789abcdef0123456789abcdef"
+	source.Size = 3 * 1024 * 1024 / 2
+	dv.SetResource(source)
+	assert.Regexp(t, `Artifact:.* sha256:0123456789ab \(1\.5 MiB\)`, dv.renderContent())
+	assert.NotContains(t, dv.renderContent(), "Credentials:")
+
+	source.SecretRef = "fleet-auth"
+	source.AuthFailed = true
+	dv.SetResource(source)
+	assert.Regexp(t, `Credentials:.* secret fleet-auth \(authentication failed\)`, dv.renderContent())
+}
+
+func TestDetailView_DecryptFailed(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	dv := NewDetailView(cfg)
+	dv.SetSize(120, 40)
+
+	resource := createTestResource("secrets", "flux-system", k8s.ResourceTypeKustomization)
+	dv.SetResource(resource)
+	assert.NotContains(t, dv.renderContent(), "Decryption:")
+
+	resource.DecryptFailed = true
+	resource.Message = "decryption failed for 'db': no matching keys"
+	dv.SetResource(resource)
+	content := dv.renderContent()
+	assert.Regexp(t, `Decryption:.*failed`, content)
+	assert.Equal(t, 1, strings.Count(content, "decryption failed for 'db': no matching keys"), "the message shows once")
+}
+
+func TestDetailView_HealthFollowsResource(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	dv := NewDetailView(cfg)
+	dv.SetSize(120, 40)
+	apps := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	infra := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+
+	dv.SetResource(apps)
+	dv, cmd := dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	require.NotNil(t, cmd)
+	poll := cmd().(CheckHealthMsg)
+	assert.Contains(t, dv.renderContent(), "polling inventory...")
+
+	// Switching resources mid-poll drops the poll and its late result
+	dv.SetResource(infra)
+	assert.Contains(t, dv.renderContent(), "w to check workload readiness")
+	dv.SetHealth(InventoryHealthMsg{Resource: apps, Poll: poll.Poll, Health: &k8s.InventoryHealth{Failed: 1}})
+	assert.Nil(t, dv.health)
+
+	// Results of an earlier poll of the same resource are dropped as well
+	dv.SetResource(apps)
+	dv.SetHealth(InventoryHealthMsg{Resource: apps, Poll: poll.Poll, Health: &k8s.InventoryHealth{Failed: 1}})
+	assert.Nil(t, dv.health)
+
+	dv, cmd = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	poll = cmd().(CheckHealthMsg)
+	dv.SetHealth(InventoryHealthMsg{Resource: apps, Poll: poll.Poll, Health: &k8s.InventoryHealth{}})
+	assert.NotNil(t, dv.health)
+
+	// The next resource starts without the previous one's health
+	dv.SetResource(infra)
+	assert.Nil(t, dv.health)
+	assert.NotContains(t, dv.renderContent(), "polling inventory...")
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// DiffView displays a unified diff in a scrollable viewport
+type DiffView struct {
+	config   *config.Config
+	viewport viewport.Model
+	title    string
+	width    int
+	height   int
+}
+
+// NewDiffView creates a new diff view
+func NewDiffView(cfg *config.Config) *DiffView {
+	return &DiffView{
+		config:   cfg,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// Init initializes the diff view
+func (v *DiffView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the diff view
+func (v *DiffView) Update(msg tea.Msg) (*DiffView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		default:
+			v.viewport, cmd = v.viewport.Update(msg)
+		}
+	}
+
+	return v, cmd
+}
+
+// View renders the diff view
+func (v *DiffView) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("81")).
+		Render(v.title)
+
+	return title + "\n" + v.viewport.View()
+}
+
+// SetDiff sets the diff to display
+func (v *DiffView) SetDiff(title, diff string) {
+	v.title = title
+	if strings.TrimSpace(diff) == "" {
+		diff = "No differences"
+	}
+	v.viewport.SetContent(colorizeDiff(diff))
+	v.viewport.GotoTop()
+}
+
+// SetSize sets the view dimensions
+func (v *DiffView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height - 1 // Reserve space for the title
+}
+
+// colorizeDiff colors added, removed and hunk header lines of a unified diff
+func colorizeDiff(diff string) string {
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	hunk := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = lipgloss.NewStyle().Bold(true).Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removed.Render(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// KustomizationDiffMsg carries the diff of a Kustomization against the cluster
+type KustomizationDiffMsg struct {
+	Resource k8s.Resource
+	Diff     string
+	Err      error
+}
+
+// showKustomizationDiff builds a Kustomization from its source and diffs it
+// against the cluster, which can take a while for large trees
+func (m *AppModel) showKustomizationDiff(resource k8s.Resource) tea.Cmd {
+	if resource.Type != k8s.ResourceTypeKustomization {
+		m.statusMessage = "Only Kustomizations can be diffed against their source"
+		return nil
+	}
+
+	m.statusMessage = fmt.Sprintf("Building %s and diffing it against the cluster...", resource.Name)
+	return func() tea.Msg {
+		diff, err := m.manager.DiffKustomization(resource)
+		return KustomizationDiffMsg{Resource: resource, Diff: diff, Err: err}
+	}
+}
+
+// handleKustomizationDiff opens the diff view
+func (m *AppModel) handleKustomizationDiff(msg KustomizationDiffMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to diff %s: %v", msg.Resource.Name, msg.Err)
+		return
+	}
+
+	title := fmt.Sprintf("Kustomization %s/%s: cluster ↔ source (esc to return)", msg.Resource.Namespace, msg.Resource.Name)
+	m.diffView.SetDiff(asciiSafe(m.config, title), msg.Diff)
+	m.currentView = ViewDiff
+}
+
+// CompareMsg carries the diff of a resource between two clusters
+type CompareMsg struct {
+	Type     k8s.ResourceType
+	Name     string
+	ClusterA string
+	ClusterB string
+	Diff     string
+	Err      error
+}
+
+// compareResource fetches a resource from two clusters and diffs them, off
+// the update loop since each fetch may wait on a slow cluster
+func (m *AppModel) compareResource(resourceType k8s.ResourceType, name, clusterA, clusterB string) tea.Cmd {
+	m.statusMessage = fmt.Sprintf("Comparing %s between %s and %s...", name, clusterA, clusterB)
+	return func() tea.Msg {
+		diff, err := m.manager.CompareResource(resourceType, name, clusterA, clusterB)
+		return CompareMsg{Type: resourceType, Name: name, ClusterA: clusterA, ClusterB: clusterB, Diff: diff, Err: err}
+	}
+}
+
+// handleCompare opens the diff view
+func (m *AppModel) handleCompare(msg CompareMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to compare %s: %v", msg.Name, msg.Err)
+		return
+	}
+
+	title := fmt.Sprintf("%s %s: %s ↔ %s (esc to return)", msg.Type, msg.Name, msg.ClusterA, msg.ClusterB)
+	m.diffView.SetDiff(asciiSafe(m.config, title), msg.Diff)
+	m.currentView = ViewDiff
+}
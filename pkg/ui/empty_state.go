@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// emptyStateHints guide users towards creating the first resource of a type
+var emptyStateHints = map[k8s.ResourceType]string{
+	k8s.ResourceTypeGitRepository:  "Create one with: flux create source git <name> --url=<repo-url> --branch=main",
+	k8s.ResourceTypeHelmRepository: "Create one with: flux create source helm <name> --url=<chart-repo-url>",
+	k8s.ResourceTypeKustomization:  "Kustomizations apply manifests from a source, so create a GitRepository first, then: flux create kustomization <name> --source=GitRepository/<name> --path=./",
+	k8s.ResourceTypeHelmRelease:    "HelmReleases install charts from a source, so create a HelmRepository first, then: flux create helmrelease <name> --source=HelmRepository/<name> --chart=<chart>",
+	k8s.ResourceTypeOCIRepository:  "Create one with: flux create source oci <name> --url=oci://<registry>/<repo> --tag=latest",
+	k8s.ResourceTypeBucket:         "Create one with: flux create source bucket <name> --bucket-name=<bucket> --endpoint=<host> --provider=generic",
+	k8s.ResourceTypeImageRepository:       "Image automation needs the image-reflector-controller (flux install --components-extra=image-reflector-controller), then: flux create image repository <name> --image=<registry>/<repo>",
+	k8s.ResourceTypeImagePolicy:           "ImagePolicies select tags from an ImageRepository, so create one first, then: flux create image policy <name> --image-ref=<repository> --select-semver='>=1.0.0'",
+	k8s.ResourceTypeImageUpdateAutomation: "Image updates need the image-automation-controller (flux install --components-extra=image-automation-controller), then: flux create image update <name> --git-repo-ref=<repository> --checkout-branch=main --author-name=flux --author-email=flux@example.com",
+	k8s.ResourceTypeAlert:             "Alerts forward events to a Provider, so create one first, then: flux create alert <name> --provider-ref=<provider> --event-source=Kustomization/*",
+	k8s.ResourceTypeProvider:          "Create one with: flux create alert-provider <name> --type=slack --channel=<channel> --secret-ref=<webhook-secret>",
+	k8s.ResourceTypeReceiver:          "Create one with: flux create receiver <name> --type=github --event=ping --event=push --secret-ref=<token-secret> --resource=GitRepository/<name>",
+}
+
+// Empty state styles
+var (
+	emptyTitleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	emptyHintStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	emptyWarnStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// SetNotInstalled records whether the CRD of a resource type is missing
+func (v *ResourceView) SetNotInstalled(resourceType k8s.ResourceType, notInstalled bool) {
+	if v.notInstalled == nil {
+		v.notInstalled = make(map[k8s.ResourceType]bool)
+	}
+	v.notInstalled[resourceType] = notInstalled
+}
+
+// renderEmptyState explains why the table is empty and what to do about it.
+// A missing CRD takes precedence over the per-type hints.
+func (v *ResourceView) renderEmptyState() string {
+	if v.notInstalled[v.resourceType] {
+		return asciiSafe(v.config, fmt.Sprintf("%s\n%s",
+			emptyWarnStyle.Render(v.fit(fmt.Sprintf("The %s CRD is not installed on this cluster", v.resourceType))),
+			emptyHintStyle.Render(v.fit("Install the Flux controllers with: flux install"))))
+	}
+
+	if label := v.ReadinessLabel(); label != "" {
+		return emptyTitleStyle.Render(v.fit(fmt.Sprintf("No %s %s resources (R to show all)", label, v.resourceType)))
+	}
+
+	if v.query != "" {
+		return emptyTitleStyle.Render(v.fit(fmt.Sprintf("No %s match /%s (esc to clear)", v.resourceType, v.query)))
+	}
+
+	if v.managedBy != nil {
+		owner := v.managedBy.owner
+		return emptyTitleStyle.Render(v.fit(fmt.Sprintf("No %s managed by %s %s/%s (esc to clear)", v.resourceType, owner.Type, owner.Namespace, owner.Name)))
+	}
+
+	title := emptyTitleStyle.Render(v.fit(fmt.Sprintf("No %s resources found", v.resourceType)))
+	hint, exists := emptyStateHints[v.resourceType]
+	if !exists {
+		return title
+	}
+	return asciiSafe(v.config, fmt.Sprintf("%s\n%s", title, emptyHintStyle.Render(v.fit(hint))))
+}
+
+// fit truncates an empty state line to the view width, so long hints don't
+// wrap on narrow terminals and push the layout past the screen
+func (v *ResourceView) fit(line string) string {
+	if v.width <= 0 {
+		return line
+	}
+	return truncate(v.config, line, v.width)
+}
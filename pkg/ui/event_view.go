@@ -2,11 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/malagant/fluxcli/internal/config"
+	"github.com/mattn/go-runewidth"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // EventView displays Kubernetes events in a table
@@ -14,6 +18,7 @@ type EventView struct {
 	config *config.Config
 	table  table.Model
 	events []Event
+	reasonFilter string // "" shows all reasons
 	width  int
 	height int
 }
@@ -35,17 +40,7 @@ func NewEventView(cfg *config.Config) *EventView {
 		table.WithHeight(cfg.UI.PaneEventsHeight),
 	)
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
-	t.SetStyles(s)
+	t.SetStyles(tableStyles(cfg))
 
 	return &EventView{
 		config: cfg,
@@ -92,6 +87,11 @@ func (v *EventView) Update(msg tea.Msg) (*EventView, tea.Cmd) {
 		default:
 			// Handle string-based keys
 			switch msg.String() {
+			case "f":
+				// Cycle the reason filter through the reasons present
+				v.reasonFilter = nextFilter(v.reasons(), v.reasonFilter)
+				v.updateTable()
+
 			// Vertical navigation - j/k for vim users
 			case "j":
 				v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyDown})
@@ -158,7 +158,9 @@ func (v *EventView) View() string {
 		return box
 	}
 	
-	return v.table.View()
+	filter := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).
+		Render(fmt.Sprintf("reason: %s  (f to cycle)", filterLabel(v.reasonFilter)))
+	return filter + "\n" + colorCells(tableView(v.table, v.height-1))
 }
 
 // SetEvents sets the events to display
@@ -167,11 +169,24 @@ func (v *EventView) SetEvents(events []Event) {
 	v.updateTable()
 }
 
+// reasons returns the reason filter cycle: all, then the distinct reasons of
+// the events, sorted
+func (v *EventView) reasons() []string {
+	var reasons []string
+	for _, event := range v.events {
+		if !slices.Contains(reasons, event.Reason) {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	slices.Sort(reasons)
+	return append([]string{""}, reasons...)
+}
+
 // SetSize sets the view dimensions
 func (v *EventView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.table.SetHeight(height - 2) // Reserve space for borders
+	sizeTable(&v.table, v.config, height-1) // Less the reason filter line
 	v.updateTableColumns()
 }
 
@@ -183,44 +198,60 @@ func (v *EventView) SetFocused(focused bool) {
 	}
 }
 
-// updateTable updates the table with current events
+// updateTable updates the table with the events matching the reason filter,
+// newest first as listed
 func (v *EventView) updateTable() {
 	rows := make([]table.Row, 0, len(v.events))
-	
-	// Sort events by timestamp (most recent first) and limit to recent events
-	maxEvents := v.config.UI.PaneEventsHeight * 5 // Show more events than visible
-	if maxEvents > len(v.events) {
-		maxEvents = len(v.events)
-	}
-	
-	for i := 0; i < maxEvents; i++ {
-		event := v.events[i]
-		row := v.createTableRow(event)
-		rows = append(rows, row)
+	for _, event := range v.events {
+		if v.reasonFilter != "" && event.Reason != v.reasonFilter {
+			continue
+		}
+		rows = append(rows, v.createTableRow(event))
 	}
 	
 	v.table.SetRows(rows)
 }
 
+// formatEventTime renders an event timestamp compactly for the events table,
+// honouring the configured time zone and relative format
+func formatEventTime(cfg *config.Config, t time.Time) string {
+	if cfg.UI.TimeFormat == config.TimeFormatRelative {
+		return formatTimestamp(cfg, t)
+	}
+	return t.In(cfg.UI.Location()).Format("15:04:05")
+}
+
+// toEvents converts Kubernetes events for display
+func toEvents(cfg *config.Config, kubeEvents []corev1.Event) []Event {
+	events := make([]Event, len(kubeEvents))
+	for i, event := range kubeEvents {
+		events[i] = Event{
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Object:    objectLabel(cfg, event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Message:   event.Message,
+			Timestamp: event.FirstTimestamp.Time,
+			Count:     int(event.Count),
+			InvolvedObject: event.InvolvedObject,
+		}
+	}
+	return events
+}
+
 // createTableRow creates a table row for an event
 func (v *EventView) createTableRow(event Event) table.Row {
-	// Use plain text without color styling to avoid display corruption
-	eventType := event.Type
-	if len(eventType) > 6 {
-		eventType = eventType[:6]
-	}
+	eventType := runewidth.Truncate(event.Type, 6, "")
 	
 	// Format reason
-	reason := event.Reason
-	if len(reason) > 10 {
-		reason = reason[:9] + "…"
+	reason := truncate(v.config, event.Reason, 10)
+	if event.Type == corev1.EventTypeWarning {
+		// Colored through markers, escape sequences would break the layout
+		eventType = colorCell(v.config, eventType, cellColorWarning)
+		reason = colorCell(v.config, reason, cellColorWarning)
 	}
 	
 	// Format object
-	object := event.Object
-	if len(object) > 20 {
-		object = object[:19] + "…"
-	}
+	object := truncate(v.config, event.Object, 20)
 	
 	// Format message (truncate if too long)
 	message := event.Message
@@ -234,12 +265,10 @@ func (v *EventView) createTableRow(event Event) table.Row {
 		}
 	}
 	
-	if len(message) > maxMessageLength {
-		message = message[:maxMessageLength-3] + "…"
-	}
+	message = truncate(v.config, message, maxMessageLength)
 	
-	// Format timestamp
-	timeFormatted := event.Timestamp
+	// Format timestamp, here so a time zone switch re-renders it
+	timeFormatted := formatEventTime(v.config, event.Timestamp)
 	
 	// Format count
 	countText := ""
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// eventsPaneDebounce is how long the cursor has to rest on a row before its
+// events are fetched, so scrolling through the table doesn't list events for
+// every row passed
+const eventsPaneDebounce = 150 * time.Millisecond
+
+// eventsPane shows the events of the selected resource below the table
+type eventsPane struct {
+	visible  bool
+	resource *k8s.Resource // Resource the events belong to
+	events   []Event       // Newest first
+	err      error
+	seq      int // Bumped on every selection change to drop stale fetches
+	height   int // Rows the resource table was last sized around
+}
+
+// eventsPaneTickMsg fires once the cursor has rested on a row
+type eventsPaneTickMsg struct {
+	seq int
+}
+
+// ResourceEventsMsg carries the fetched events of the selected resource
+type ResourceEventsMsg struct {
+	Resource k8s.Resource
+	Events   []Event
+	Err      error
+	seq      int
+}
+
+// toggleEventsPane shows or hides the events pane
+func (m *AppModel) toggleEventsPane() tea.Cmd {
+	m.eventsPane.visible = !m.eventsPane.visible
+	m.eventsPane.resource = nil
+	m.eventsPane.events = nil
+	return m.syncEventsPane()
+}
+
+// eventsPaneShown reports whether the events pane takes up rows of the body
+func (m *AppModel) eventsPaneShown() bool {
+	return m.eventsPane.visible && m.currentView == ViewResources
+}
+
+// eventsPaneHeight is the rows the pane takes: a title and the events
+func (m *AppModel) eventsPaneHeight() int {
+	if !m.eventsPaneShown() {
+		return 0
+	}
+	return m.config.UI.PaneEventsHeight + 1
+}
+
+// syncEventsPane schedules a fetch when the selected resource has changed
+func (m *AppModel) syncEventsPane() tea.Cmd {
+	if !m.eventsPaneShown() {
+		return nil
+	}
+
+	selected := m.resourceView.GetSelectedResource()
+	if selected == nil {
+		m.eventsPane.resource = nil
+		m.eventsPane.events = nil
+		m.eventsPane.err = nil
+		return nil
+	}
+	if m.eventsPane.resource != nil && sameResource(*m.eventsPane.resource, *selected) {
+		return nil
+	}
+
+	resource := *selected
+	m.eventsPane.resource = &resource
+	m.eventsPane.events = nil
+	m.eventsPane.err = nil
+	m.eventsPane.seq++
+	seq := m.eventsPane.seq
+	return tea.Tick(eventsPaneDebounce, func(time.Time) tea.Msg { return eventsPaneTickMsg{seq: seq} })
+}
+
+// refreshEventsPane refetches the events of the resource in the pane
+func (m *AppModel) refreshEventsPane() tea.Cmd {
+	if !m.eventsPaneShown() || m.eventsPane.resource == nil {
+		return nil
+	}
+	m.eventsPane.seq++
+	return m.fetchResourceEvents(*m.eventsPane.resource, m.eventsPane.seq)
+}
+
+// handleEventsPaneTick fetches the events once the cursor has rested
+func (m *AppModel) handleEventsPaneTick(msg eventsPaneTickMsg) tea.Cmd {
+	if msg.seq != m.eventsPane.seq || m.eventsPane.resource == nil {
+		return nil
+	}
+	return m.fetchResourceEvents(*m.eventsPane.resource, msg.seq)
+}
+
+// fetchResourceEvents lists the events of a resource for the pane
+func (m *AppModel) fetchResourceEvents(resource k8s.Resource, seq int) tea.Cmd {
+	return func() tea.Msg {
+		kubeEvents, err := m.manager.GetResourceEvents(resource)
+		events := toEvents(m.config, kubeEvents)
+		// The pane is ordered by last occurrence, so show that time
+		for i := range events {
+			events[i].Timestamp = kubeEvents[i].LastTimestamp.Time
+		}
+		return ResourceEventsMsg{Resource: resource, Events: events, Err: err, seq: seq}
+	}
+}
+
+// handleResourceEvents shows fetched events unless the selection moved on
+func (m *AppModel) handleResourceEvents(msg ResourceEventsMsg) {
+	if msg.seq != m.eventsPane.seq {
+		return
+	}
+	m.eventsPane.events = msg.Events
+	m.eventsPane.err = msg.Err
+}
+
+// renderEventsPane renders the events of the selected resource
+func (m *AppModel) renderEventsPane() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	// Each event takes one row: messages are flattened and cut to the width,
+	// since wrapped lines would push the frame past the screen
+	fit := func(line string) string {
+		line = strings.ReplaceAll(line, "\n", " ")
+		if m.width <= 0 {
+			return line
+		}
+		return truncate(m.config, line, m.width)
+	}
+
+	pane := m.eventsPane
+	var b strings.Builder
+	if pane.resource == nil {
+		b.WriteString(title.Render("Events"))
+	} else {
+		b.WriteString(title.Render(fit(fmt.Sprintf("Events of %s %s/%s", pane.resource.Type, pane.resource.Namespace, pane.resource.Name))))
+	}
+
+	lines := make([]string, 0, m.config.UI.PaneEventsHeight)
+	switch {
+	case pane.err != nil:
+		lines = append(lines, label.Render(fit(fmt.Sprintf("  Failed to get events: %v", pane.err))))
+	case pane.resource != nil && pane.events == nil:
+		lines = append(lines, label.Render("  Loading events..."))
+	case len(pane.events) == 0:
+		lines = append(lines, label.Render("  No events"))
+	}
+	for _, event := range pane.events {
+		if len(lines) == m.config.UI.PaneEventsHeight {
+			break
+		}
+		line := fit(fmt.Sprintf("  %s  %-8s %-24s %s", formatEventTime(m.config, event.Timestamp), event.Type, event.Reason, event.Message))
+		if event.Type == corev1.EventTypeWarning {
+			line = warning.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	for _, line := range lines {
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+	return asciiSafe(m.config, b.String())
+}
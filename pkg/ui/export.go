@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// exportResources writes the resources listed in the table to a file
+func (m *AppModel) exportResources(path string) {
+	// Stdout is the terminal the UI draws on
+	if path == "-" {
+		m.errorMessage = "Export needs a file path, use --export - to write to stdout"
+		return
+	}
+	format, err := k8s.ParseExportFormat("", path)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return
+	}
+
+	resources := m.resourceView.DisplayedResources()
+	// The context is resolved from the kubeconfig when --context wasn't given,
+	// replayed clusters have no context and go by their recorded name
+	kubeContext := m.manager.GetCurrentContext()
+	if kubeContext == "" {
+		kubeContext = m.manager.GetCurrentCluster()
+	}
+	export := k8s.NewExport(kubeContext, resources)
+	if err := k8s.WriteExportFile(path, export, format); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to export: %v", err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Exported %d resources to %s", len(resources), path)
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// inventoryTypes are the Flux types an inventory filter can match, in tab order
+var inventoryTypes = k8s.ResourceTypes()
+
+// InventoryFilterMsg carries the inventory of a Kustomization to filter by
+type InventoryFilterMsg struct {
+	Owner   k8s.Resource
+	Objects []k8s.ObjectRef
+	Err     error
+}
+
+// filterByInventory fetches the inventory of the selected Kustomization
+func (m *AppModel) filterByInventory() tea.Cmd {
+	resource := m.resourceView.GetSelectedResource()
+	if resource == nil {
+		return nil
+	}
+	if resource.Type != k8s.ResourceTypeKustomization {
+		m.statusMessage = "Only Kustomizations have an inventory"
+		return nil
+	}
+
+	owner := *resource
+	return func() tea.Msg {
+		objects, err := m.manager.GetInventory(owner)
+		return InventoryFilterMsg{Owner: owner, Objects: objects, Err: err}
+	}
+}
+
+// applyInventoryFilter restricts the type tables to the Flux objects in a
+// Kustomization's inventory, switching to a type with matches if the current
+// one has none
+func (m *AppModel) applyInventoryFilter(msg InventoryFilterMsg) {
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to get inventory of %s: %v", msg.Owner.Name, msg.Err)
+		return
+	}
+
+	m.resourceView.SetInventoryFilter(msg.Owner, msg.Objects)
+
+	counts := make(map[k8s.ResourceType]int)
+	for _, resourceType := range inventoryTypes {
+		for _, resource := range m.state.Resources[m.resourceCluster(msg.Owner)][resourceType] {
+			if m.resourceView.managed(resource) {
+				counts[resourceType]++
+			}
+		}
+	}
+
+	var summary []string
+	for _, resourceType := range inventoryTypes {
+		if counts[resourceType] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[resourceType], resourceType))
+		}
+	}
+	target := fmt.Sprintf("%s/%s", msg.Owner.Namespace, msg.Owner.Name)
+	if len(summary) == 0 {
+		m.statusMessage = fmt.Sprintf("%s manages no Flux resources (esc to clear)", target)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("%s manages %s (esc to clear)", target, strings.Join(summary, ", "))
+
+	if counts[m.state.CurrentResource] == 0 {
+		for _, resourceType := range inventoryTypes {
+			if counts[resourceType] > 0 {
+				m.state.CurrentResource = resourceType
+				m.resourceView.SetResourceType(resourceType)
+				m.resourceView.SetResources(m.currentResources())
+				break
+			}
+		}
+	}
+}
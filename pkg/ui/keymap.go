@@ -0,0 +1,301 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// resourceTypeKeys are the types the number keys switch to, in key order
+var resourceTypeKeys = []k8s.ResourceType{
+	k8s.ResourceTypeGitRepository,
+	k8s.ResourceTypeHelmRepository,
+	k8s.ResourceTypeKustomization,
+	k8s.ResourceTypeHelmRelease,
+	k8s.ResourceTypeOCIRepository,
+	k8s.ResourceTypeBucket,
+	k8s.ResourceTypeImageRepository,
+	k8s.ResourceTypeImagePolicy,
+	k8s.ResourceTypeImageUpdateAutomation,
+}
+
+// keyMap holds the key bindings of the resource screen. The handlers match
+// keys against it and the help overlay lists it, so the help cannot drift
+// from what the keys do.
+type keyMap struct {
+	// Navigation
+	Up           key.Binding
+	Down         key.Binding
+	Columns      key.Binding
+	HalfPageDown key.Binding
+	HalfPageUp   key.Binding
+	PageDown     key.Binding
+	PageUp       key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	ViewTop      key.Binding
+	ViewMiddle   key.Binding
+	ViewBottom   key.Binding
+	Details      key.Binding
+	SwitchView   key.Binding
+
+	// Resource types and clusters
+	ResourceTypes []key.Binding // One per resourceTypeKeys entry
+	NextType      key.Binding
+	PrevType      key.Binding
+	PrevCluster   key.Binding
+	NextCluster   key.Binding
+	Namespace     key.Binding
+	Context       key.Binding
+
+	// Filter and sort
+	Filter    key.Binding
+	Readiness key.Binding
+	Sort      key.Binding
+	SortOrder key.Binding
+	Tenant    key.Binding
+	GroupNamespace key.Binding
+	Inventory key.Binding
+
+	// Layout
+	ShowNamespace key.Binding
+	NameWidth     key.Binding
+
+	// Actions
+	Actions             key.Binding
+	Mark                key.Binding
+	ReconcileWithSource key.Binding
+	Command             key.Binding
+	Refresh             key.Binding
+
+	// Inspect
+	Source   key.Binding
+	Events   key.Binding
+	Watch    key.Binding
+	Manifest key.Binding
+	Tree     key.Binding
+	Diff     key.Binding
+	Logs     key.Binding
+	Copy     key.Binding
+	CopyName key.Binding
+	UTC      key.Binding
+
+	// General
+	Help key.Binding
+	Back key.Binding
+	Quit key.Binding
+}
+
+// keys are the bindings in use
+var keys = newKeyMap()
+
+// newKeyMap returns the default key bindings
+func newKeyMap() keyMap {
+	km := keyMap{
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "Move up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "Move down")),
+		Columns:      key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "Horizontal navigation")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "Half page down")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "Half page up")),
+		PageDown:     key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("PageDown", "Page down")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup"), key.WithHelp("PageUp", "Page up")),
+		Top:          key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g/Home", "Go to top")),
+		Bottom:       key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G/End", "Go to bottom")),
+		ViewTop:      key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "Top of view")),
+		ViewMiddle:   key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "Middle of view")),
+		ViewBottom:   key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "Bottom of view")),
+		Details:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "View details (f/t filter conditions, w workload readiness)")),
+		SwitchView:   key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "Switch between resources and events")),
+
+		NextType: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "Next resource type, including those without a number")),
+		PrevType: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "Previous resource type")),
+
+		PrevCluster: key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "Previous cluster")),
+		NextCluster: key.NewBinding(key.WithKeys("ctrl+j"), key.WithHelp("ctrl+j", "Next cluster")),
+		Namespace:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "Pick the namespace to list (remembered)")),
+		Context:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "Pick a kubeconfig context to reconnect to")),
+
+		Filter:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "Filter by name (enter keeps, esc clears)")),
+		Readiness: key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "Cycle all, not ready (unsnoozed) and ready")),
+		Sort:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "Cycle sort column (name, ready, status, age)")),
+		SortOrder: key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "Flip sort order")),
+		Tenant:    key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "Group by tenant label")),
+		GroupNamespace: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "Group all namespaces by namespace (enter on a header collapses)")),
+		Inventory: key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "Show what the selected Kustomization manages")),
+
+		ShowNamespace: key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("ctrl+n", "Toggle namespaces in the Name column (saved)")),
+		NameWidth:     key.NewBinding(key.WithKeys("<", ">"), key.WithHelp("</>", "Narrow or widen the Name column (saved)")),
+
+		Actions:             key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "Suspend, resume, reconcile, delete and more")),
+		Mark:                key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "Select the row for bulk suspend/resume")),
+		ReconcileWithSource: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "Fetch the source, then reconcile")),
+		Command:             key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "Command mode, see below")),
+		Refresh:             key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "Manual refresh")),
+
+		Source:   key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "Select the source of the resource")),
+		Events:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "Toggle the events pane")),
+		Watch:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "Pin into a live watch screen (R reconcile)")),
+		Manifest: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "View the manifest YAML (g/G top/bottom)")),
+		Tree:     key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "Tree of all managed objects (enter collapses)")),
+		Diff:     key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "Diff a Kustomization's source against the cluster")),
+		Logs:     key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "Controller logs mentioning the resource (f all)")),
+		Copy:     key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y/Y", "Copy manifest YAML (Y redacts credentials)")),
+		CopyName: key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "Copy namespace/name")),
+		UTC:      key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "Toggle timestamps between UTC and local time")),
+
+		Help: key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "Toggle this help")),
+		Back: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "Back, or clear filter, selection and inventory")),
+		Quit: key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "Quit")),
+	}
+
+	for i, resourceType := range resourceTypeKeys {
+		digit := fmt.Sprint(i + 1)
+		km.ResourceTypes = append(km.ResourceTypes, key.NewBinding(key.WithKeys(digit), key.WithHelp(digit, pluralTypeName(resourceType))))
+	}
+	return km
+}
+
+// pluralTypeName names the resources of a type in the help
+func pluralTypeName(resourceType k8s.ResourceType) string {
+	name := string(resourceType)
+	if strings.HasSuffix(name, "y") {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
+// resourceTypeFor returns the type a number key switches to
+func (km keyMap) resourceTypeFor(msg tea.KeyMsg) (k8s.ResourceType, bool) {
+	for i, binding := range km.ResourceTypes {
+		if key.Matches(msg, binding) {
+			return resourceTypeKeys[i], true
+		}
+	}
+	return "", false
+}
+
+// keyGroup is a category of bindings in the help overlay
+type keyGroup struct {
+	title    string
+	bindings []key.Binding
+}
+
+// groups returns the bindings by category, in the order the help lists them
+func (km keyMap) groups() []keyGroup {
+	return []keyGroup{
+		{"Navigation", []key.Binding{km.Up, km.Down, km.Columns, km.HalfPageDown, km.HalfPageUp, km.PageDown, km.PageUp,
+			km.Top, km.Bottom, km.ViewTop, km.ViewMiddle, km.ViewBottom, km.Details, km.SwitchView}},
+		{"Resource Types", append(append([]key.Binding{}, km.ResourceTypes...), km.NextType, km.PrevType)},
+		{"Clusters", []key.Binding{km.PrevCluster, km.NextCluster, km.Namespace, km.Context}},
+		{"Filter and Sort", []key.Binding{km.Filter, km.Readiness, km.Sort, km.SortOrder, km.Tenant, km.GroupNamespace, km.Inventory}},
+		{"Layout", []key.Binding{km.ShowNamespace, km.NameWidth}},
+		{"Actions", []key.Binding{km.Actions, km.Mark, km.ReconcileWithSource, km.Command, km.Refresh}},
+		{"Inspect", []key.Binding{km.Source, km.Events, km.Watch, km.Manifest, km.Tree, km.Diff, km.Logs, km.Copy, km.CopyName, km.UTC}},
+		{"General", []key.Binding{km.Help, km.Back, km.Quit}},
+	}
+}
+
+// handleHelp handles keyboard input while the help overlay is shown
+func (m *AppModel) handleHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Help, keys.Back):
+		m.state.ShowHelp = false
+	case key.Matches(msg, keys.Down):
+		m.helpOffset++
+	case key.Matches(msg, keys.Up):
+		if m.helpOffset > 0 {
+			m.helpOffset--
+		}
+	}
+	return m, nil
+}
+
+// scrolledHelp renders the part of the help overlay that fits the body from
+// the scroll offset on
+func (m *AppModel) scrolledHelp() string {
+	lines := strings.Split(m.renderHelp(), "\n")
+	last := len(lines) - m.bodyHeight
+	if last < 0 {
+		last = 0
+	}
+	if m.helpOffset > last {
+		m.helpOffset = last
+	}
+	return strings.Join(lines[m.helpOffset:], "\n")
+}
+
+// renderHelp renders the help overlay: the key bindings by category, laid
+// out in as many columns as fit, followed by the commands
+func (m *AppModel) renderHelp() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	desc := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var blocks []string
+	for _, group := range keys.groups() {
+		var b strings.Builder
+		b.WriteString(title.Render(group.title))
+		for _, binding := range group.bindings {
+			if !binding.Enabled() {
+				continue
+			}
+			help := binding.Help()
+			b.WriteString("\n")
+			b.WriteString("  " + keyStyle.Render(fmt.Sprintf("%-9s", help.Key)) + " " + desc.Render(help.Desc))
+		}
+		blocks = append(blocks, asciiSafe(m.config, b.String()))
+	}
+
+	// Fill rows of blocks up to the terminal width
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, block := range blocks {
+		width := lipgloss.Width(block) + 4
+		if len(row) > 0 && rowWidth+width > m.width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...), "")
+			row, rowWidth = nil, 0
+		}
+		row = append(row, lipgloss.NewStyle().PaddingRight(4).Render(block))
+		rowWidth += width
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...), "")
+	}
+
+	rows = append(rows, title.Render("Commands"), desc.Render(asciiSafe(m.config, m.renderCommandHelp())))
+	rows = append(rows, "", desc.Render(asciiSafe(m.config, "↑↓/jk scroll | ? or esc close")))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderCommandHelp lists the commands of command mode
+func (m *AppModel) renderCommandHelp() string {
+	commandText := `
+  suspend [n]      Suspend resource, or all selected ones without a name%s
+  resume [n]       Resume resource, or all selected ones without a name%s
+  reconcile <n>    Trigger reconciliation%s
+  rs <n>           Fetch the source, then reconcile (Kustomizations, HelmReleases)
+  reset <n>        Reset HelmRelease remediation retries%s
+  snooze <n> [d]   Mute resource in triage views for d (default 1h), keeps reconciling
+  unsnooze <n>     Lift a snooze
+  delete <n>       Delete resource after typing its name or pressing y
+  export <file>    Write the listed resources as JSON, or YAML for .yaml/.yml files
+  compare <n> <c>  Diff resource against cluster <c>
+  ns [name|all]    Switch namespace, picking from a list without a name
+  label [sel]      Show only resources matching a label selector, clearing it without one
+  ctx [name]       Reconnect to a kubeconfig context, picking from a list without a name
+  type <t>         Show resource type t, e.g. alerts, providers, receivers
+  about            Show version and build information
+`
+	return strings.Trim(fmt.Sprintf(commandText,
+		m.unsupportedNote(k8s.ActionSuspend),
+		m.unsupportedNote(k8s.ActionResume),
+		m.unsupportedNote(k8s.ActionReconcile),
+		m.unsupportedNote(k8s.ActionReset)), "\n")
+}
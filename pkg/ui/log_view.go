@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// controllerLogWindow is how far back the log view reaches
+const controllerLogWindow = 15 * time.Minute
+
+// LogsMsg carries the fetched controller logs of a resource
+type LogsMsg struct {
+	Resource k8s.Resource
+	Logs     string
+	Err      error
+}
+
+// LogView displays the logs of the controller reconciling a resource,
+// narrowed to the lines mentioning it unless toggled off
+type LogView struct {
+	config   *config.Config
+	viewport viewport.Model
+	resource k8s.Resource
+	logs     string
+	showAll  bool // Show every line instead of those mentioning the resource
+	width    int
+	height   int
+}
+
+// NewLogView creates a new log view
+func NewLogView(cfg *config.Config) *LogView {
+	return &LogView{
+		config:   cfg,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// Update handles messages for the log view
+func (v *LogView) Update(msg tea.Msg) (*LogView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "f":
+			v.showAll = !v.showAll
+			v.refresh()
+			v.viewport.GotoBottom()
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		default:
+			v.viewport, cmd = v.viewport.Update(msg)
+		}
+	}
+
+	return v, cmd
+}
+
+// View renders the log view
+func (v *LogView) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("81")).
+		Render(v.title())
+
+	return title + "\n" + v.viewport.View()
+}
+
+// SetLogs sets the logs to display, scrolled to the newest line
+func (v *LogView) SetLogs(resource k8s.Resource, logs string) {
+	v.resource = resource
+	v.logs = strings.TrimSuffix(logs, "\n")
+	v.showAll = false
+	v.refresh()
+	v.viewport.GotoBottom()
+}
+
+// GetResource returns the resource whose logs are shown
+func (v *LogView) GetResource() *k8s.Resource {
+	return &v.resource
+}
+
+// SetSize sets the view dimensions
+func (v *LogView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height - 1 // Reserve space for the title
+}
+
+// title names the controller, the resource and the toggle
+func (v *LogView) title() string {
+	info, _ := k8s.LookupResource(v.resource.Type)
+	scope := fmt.Sprintf("lines mentioning %s", v.resource.Name)
+	toggle := "f show all"
+	if v.showAll {
+		scope = "all lines"
+		toggle = fmt.Sprintf("f only %s", v.resource.Name)
+	}
+	return asciiSafe(v.config, fmt.Sprintf("%s logs, %s, last %s (%s, esc to return)", info.Controller, scope, formatAge(controllerLogWindow), toggle))
+}
+
+// refresh re-renders the viewport content
+func (v *LogView) refresh() {
+	logs := v.logs
+	if !v.showAll {
+		logs = k8s.GrepLogs(logs, v.resource.Name)
+	}
+	if logs == "" {
+		logs = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("No log lines")
+	}
+	v.viewport.SetContent(logs)
+}
+
+// showLogs fetches the logs of the controller reconciling a resource
+func (m *AppModel) showLogs(resource k8s.Resource) tea.Cmd {
+	m.statusMessage = fmt.Sprintf("Fetching controller logs for %s...", resource.Name)
+	return func() tea.Msg {
+		logs, err := m.manager.GetControllerLogs(resource, int64(controllerLogWindow.Seconds()))
+		return LogsMsg{Resource: resource, Logs: logs, Err: err}
+	}
+}
+
+// handleLogs opens the log view, returning to the current view on esc
+func (m *AppModel) handleLogs(msg LogsMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to fetch controller logs for %s: %v", msg.Resource.Name, msg.Err)
+		return
+	}
+
+	m.logView.SetLogs(msg.Resource, msg.Logs)
+	if m.currentView != ViewLogs {
+		m.logReturn = m.currentView
+	}
+	m.currentView = ViewLogs
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/core"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// markMarker prefixes the name of rows selected for a bulk action
+const markMarker = "✓ "
+
+// BulkActionMsg carries the outcome of a bulk action
+type BulkActionMsg struct {
+	Action  k8s.Action
+	Results []core.BulkResult
+}
+
+// ToggleMark selects or deselects the row under the cursor for bulk actions
+// and moves to the next row
+func (v *ResourceView) ToggleMark() {
+	resource := v.GetSelectedResource()
+	if resource == nil {
+		return
+	}
+	if v.marked == nil {
+		v.marked = make(map[string]bool)
+	}
+
+	key := rowKey(*resource)
+	if v.marked[key] {
+		delete(v.marked, key)
+	} else {
+		v.marked[key] = true
+	}
+	v.updateTable()
+	v.table.MoveDown(1)
+}
+
+// MarkedResources returns the selected resources in display order. Rows hidden
+// by a filter are left out.
+func (v *ResourceView) MarkedResources() []k8s.Resource {
+	var marked []k8s.Resource
+	for _, resource := range v.resources {
+		if v.isMarked(resource) {
+			marked = append(marked, resource)
+		}
+	}
+	return marked
+}
+
+// SetMarked replaces the selection with the given resources
+func (v *ResourceView) SetMarked(resources []k8s.Resource) {
+	v.marked = make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		v.marked[rowKey(resource)] = true
+	}
+	v.updateTable()
+}
+
+// ClearMarks deselects all rows
+func (v *ResourceView) ClearMarks() {
+	v.SetMarked(nil)
+}
+
+// isMarked reports whether a resource is selected for bulk actions
+func (v *ResourceView) isMarked(resource k8s.Resource) bool {
+	return v.marked[rowKey(resource)]
+}
+
+// confirmBulk asks for confirmation before running a bulk action on the
+// selected resources
+func (m *AppModel) confirmBulk(action k8s.Action, resources []k8s.Resource) {
+	verb := string(action)
+	count := fmt.Sprintf("%d %s resources", len(resources), m.state.CurrentResource)
+	for _, resource := range resources {
+		if resource.Type != m.state.CurrentResource {
+			count = fmt.Sprintf("%d resources of all types", len(resources))
+			break
+		}
+	}
+	m.confirm = &confirmPrompt{
+		message: fmt.Sprintf("%s %s? [y/N]", strings.ToUpper(verb[:1])+verb[1:], count),
+		onConfirm: func() tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Running %s on %d resources...", action, len(resources))
+			return m.runBulk(action, resources)
+		},
+	}
+}
+
+// runBulk runs a bulk action in the background
+func (m *AppModel) runBulk(action k8s.Action, resources []k8s.Resource) tea.Cmd {
+	return func() tea.Msg {
+		var results []core.BulkResult
+		switch action {
+		case k8s.ActionSuspend:
+			results = m.manager.SuspendResources(resources)
+		case k8s.ActionResume:
+			results = m.manager.ResumeResources(resources)
+		case k8s.ActionReconcile:
+			results = m.manager.ReconcileResources(resources)
+		}
+		return BulkActionMsg{Action: action, Results: results}
+	}
+}
+
+// handleBulkAction reports a summary of a bulk action. Resources that failed
+// stay selected so the action can be retried on them.
+func (m *AppModel) handleBulkAction(msg BulkActionMsg) tea.Cmd {
+	m.statusMessage = ""
+
+	var failed []k8s.Resource
+	var failures []string
+	for _, result := range msg.Results {
+		if result.Err != nil {
+			failed = append(failed, result.Resource)
+			failures = append(failures, fmt.Sprintf("%s (%v)", result.Resource.Name, result.Err))
+		}
+	}
+	m.resourceView.SetMarked(failed)
+
+	verb := bulkVerbs[msg.Action]
+	succeeded := len(msg.Results) - len(failed)
+	if len(failed) == 0 {
+		m.statusMessage = fmt.Sprintf("%s %d resources", verb, succeeded)
+	} else {
+		m.errorMessage = fmt.Sprintf("%s %d of %d resources, failed: %s", verb, succeeded, len(msg.Results), strings.Join(failures, ", "))
+	}
+	return tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+}
+
+// bulkVerbs are the past tense of the bulk actions for the summary
+var bulkVerbs = map[k8s.Action]string{
+	k8s.ActionSuspend:   "Suspended",
+	k8s.ActionResume:    "Resumed",
+	k8s.ActionReconcile: "Reconciled",
+}
+
+// reconcilable returns the listed resources of every type that can be
+// reconciled, across all clusters in fleet mode
+func (m *AppModel) reconcilable() []k8s.Resource {
+	clusters := []string{m.state.CurrentCluster}
+	if m.config.Fleet {
+		clusters = clusters[:0]
+		for cluster := range m.state.Resources {
+			clusters = append(clusters, cluster)
+		}
+		sort.Strings(clusters)
+	}
+
+	var resources []k8s.Resource
+	for _, cluster := range clusters {
+		for _, resourceType := range k8s.ResourceTypes() {
+			if !k8s.SupportsAction(resourceType, k8s.ActionReconcile) {
+				continue
+			}
+			for _, resource := range m.state.Resources[cluster][resourceType] {
+				if !isUnreachableRow(resource) {
+					resources = append(resources, resource)
+				}
+			}
+		}
+	}
+	return resources
+}
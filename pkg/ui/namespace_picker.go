@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// NamespacesMsg carries the namespaces of the current cluster for the picker
+type NamespacesMsg struct {
+	Namespaces []string
+	Err        error
+}
+
+// openNamespacePicker lists the namespaces of the current cluster for the picker
+func (m *AppModel) openNamespacePicker() tea.Cmd {
+	m.statusMessage = "Listing namespaces..."
+	return func() tea.Msg {
+		namespaces, err := m.manager.ListNamespaces()
+		return NamespacesMsg{Namespaces: namespaces, Err: err}
+	}
+}
+
+// handleNamespaces opens the picker on the listed namespaces, with all
+// namespaces as the first entry
+func (m *AppModel) handleNamespaces(msg NamespacesMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to list namespaces: %v (use :ns <name> instead)", msg.Err)
+		return
+	}
+
+	items := append([]string{""}, msg.Namespaces...)
+	labels := make([]string, len(items))
+	for i, namespace := range items {
+		labels[i] = displayNamespace(namespace)
+	}
+	title := fmt.Sprintf("Select namespace (%d)", len(msg.Namespaces))
+	m.picker = newPicker(title, items, labels, m.manager.GetCurrentNamespace(), m.selectNamespace)
+}
+
+// LargeListMsg carries the outcome of counting resources before listing all
+// namespaces, Warning is "" when the lists look small enough
+type LargeListMsg struct {
+	Warning string
+}
+
+// selectNamespace switches to a namespace ("" for all), asking first when
+// listing all namespaces would be slow
+func (m *AppModel) selectNamespace(namespace string) tea.Cmd {
+	if namespace == "" && m.manager.GetCurrentNamespace() != "" && m.config.Defaults.LargeListWarning > 0 {
+		// Guard against accidentally listing a huge cluster, counting takes a
+		// round trip per type so it runs off the update loop
+		m.statusMessage = "Counting resources across all namespaces..."
+		threshold := int64(m.config.Defaults.LargeListWarning)
+		return func() tea.Msg {
+			return LargeListMsg{Warning: m.largeListWarning(threshold)}
+		}
+	}
+	m.switchNamespace(namespace)
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+}
+
+// handleLargeList switches to all namespaces once the count is in, asking
+// first when it found large lists
+func (m *AppModel) handleLargeList(msg LargeListMsg) tea.Cmd {
+	m.statusMessage = ""
+	if msg.Warning == "" {
+		m.switchNamespace("")
+		return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+	// The prompt guards against a mistyped toggle, so Enter keeps the namespace
+	m.confirm = &confirmPrompt{
+		message: msg.Warning,
+		onConfirm: func() tea.Cmd {
+			m.switchNamespace("")
+			return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return ClearStatusMsg{} })
+		},
+		enterDeclines: true,
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// picker is a full-screen list the user selects one entry from, used to
+// pick namespaces and kubeconfig contexts
+type picker struct {
+	title    string
+	items    []string
+	labels   []string // Displayed text of each item
+	current  string   // Item marked as current
+	cursor   int
+	onSelect func(item string) tea.Cmd
+}
+
+// newPicker builds a picker starting at the current item
+func newPicker(title string, items, labels []string, current string, onSelect func(string) tea.Cmd) *picker {
+	p := &picker{title: title, items: items, labels: labels, current: current, onSelect: onSelect}
+	for i, item := range items {
+		if item == current {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+// handlePicker handles keyboard input while a picker is open
+func (m *AppModel) handlePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.picker
+
+	switch msg.String() {
+	case "esc", "q", "ctrl+c":
+		m.picker = nil
+	case "j", "down":
+		p.cursor = (p.cursor + 1) % len(p.items)
+	case "k", "up":
+		p.cursor = (p.cursor - 1 + len(p.items)) % len(p.items)
+	case "g", "home":
+		p.cursor = 0
+	case "G", "end":
+		p.cursor = len(p.items) - 1
+	case "enter":
+		m.picker = nil
+		return m, p.onSelect(p.items[p.cursor])
+	}
+
+	return m, nil
+}
+
+// renderPicker renders the open picker, scrolled to keep the cursor visible
+func (m *AppModel) renderPicker() string {
+	p := m.picker
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	selected := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("226"))
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	rows := m.bodyHeight - 2 // Title and hint
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if p.cursor >= rows {
+		start = p.cursor - rows + 1
+	}
+	end := start + rows
+	if end > len(p.items) {
+		end = len(p.items)
+	}
+
+	var b strings.Builder
+	b.WriteString(title.Render(p.title))
+	for i := start; i < end; i++ {
+		line := "  " + p.labels[i]
+		if p.items[i] == p.current {
+			line += " (current)"
+		}
+		if i == p.cursor {
+			line = selected.Render("> " + line[2:])
+		}
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+	b.WriteString("\n")
+	b.WriteString(hint.Render(fmt.Sprintf("%d/%d | j/k select | enter switch | esc cancel", p.cursor+1, len(p.items))))
+
+	return asciiSafe(m.config, b.String())
+}
@@ -2,25 +2,84 @@ package ui
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/malagant/fluxcli/internal/config"
 	"github.com/malagant/fluxcli/pkg/k8s"
 )
 
 // ResourceView displays FluxCD resources in a table
 type ResourceView struct {
-	config       *config.Config
-	table        table.Model
-	resources    []k8s.Resource
-	resourceType k8s.ResourceType
-	width        int
-	height       int
+	config        *config.Config
+	table         table.Model
+	allResources  []k8s.Resource // As received from the manager
+	resources     []k8s.Resource // Displayed order
+	rowIndex      []int          // Table row -> index into resources, -1 for group headers
+	rowGroup      []string       // Table row -> group of header rows
+	resourceType  k8s.ResourceType
+	groupByTenant bool
+	groupByNamespace bool        // Group rows by namespace, see namespaceGrouping
+	allNamespaces bool           // The manager lists all namespaces
+	collapsed     map[string]bool // Groups whose rows are hidden under their header
+	managedBy     *inventoryFilter // Restricts rows to a Kustomization's inventory
+	query         string           // Name filter, see matchesQuery
+	filtering     bool             // The filter query is being typed
+	readiness     readinessFilter  // Restricts rows by Ready state
+	sortBy        sortColumn       // Column rows are sorted by, see sortResources
+	sortDesc      bool             // Sort descending
+	marked        map[string]bool  // rowKey of rows selected for bulk actions
+	notInstalled  map[k8s.ResourceType]bool // Types whose CRD is missing
+	truncated     map[k8s.ResourceType]bool // Types whose list stopped at the list limit
+	controllers   map[k8s.ResourceType]*k8s.ControllerStatus // The controller reconciling each type, when known
+	previous      map[string]k8s.Resource   // Last snapshot by rowKey, for change highlights
+	changed       map[string]rowChange      // rowKey -> its change highlight
+	fadePending   bool                      // New highlights need a fade tick
+	summary       resourceSummary           // Health counts of allResources
+	width         int
+	height        int
 }
 
+// OverdueCount returns how many of the displayed resources are overdue
+func (v *ResourceView) OverdueCount() int {
+	count := 0
+	for _, resource := range v.resources {
+		if resource.Overdue {
+			count++
+		}
+	}
+	return count
+}
+
+// DisplayedResources returns the resources passing the filters, in display
+// order
+func (v *ResourceView) DisplayedResources() []k8s.Resource {
+	return v.resources
+}
+
+// inventoryFilter holds the objects applied by a Kustomization
+type inventoryFilter struct {
+	owner   k8s.Resource
+	objects map[string]bool // inventoryKey of each object
+}
+
+// inventoryKey identifies an object by kind, namespace and name
+func inventoryKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// noTenantGroup is the group for resources without the tenant label
+const noTenantGroup = "(no tenant)"
+
+// suspendedMarker prefixes the names of suspended resources, which may still
+// report Ready from their last reconcile
+const suspendedMarker = "⏸ "
+
 // NewResourceView creates a new resource view
 func NewResourceView(cfg *config.Config) *ResourceView {
 	columns := []table.Column{
@@ -37,22 +96,14 @@ func NewResourceView(cfg *config.Config) *ResourceView {
 		table.WithHeight(10),
 	)
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
-	t.SetStyles(s)
+	t.SetStyles(tableStyles(cfg))
 
 	return &ResourceView{
-		config:       cfg,
-		table:        t,
-		resourceType: k8s.ResourceTypeGitRepository,
+		config:        cfg,
+		table:         t,
+		resourceType:  k8s.ResourceTypeGitRepository,
+		groupByTenant: cfg.UI.TenantLabel != "",
+		groupByNamespace: cfg.UI.GroupByNamespace && cfg.UI.TenantLabel == "",
 	}
 }
 
@@ -67,76 +118,66 @@ func (v *ResourceView) Update(msg tea.Msg) (*ResourceView, tea.Cmd) {
 	
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Handle arrow keys by checking Type directly
-		switch msg.Type {
-		case tea.KeyDown:
-			v.table, cmd = v.table.Update(msg)
-		case tea.KeyUp:
-			v.table, cmd = v.table.Update(msg)
-		case tea.KeyLeft:
-			v.table, cmd = v.table.Update(msg)
-		case tea.KeyRight:
-			v.table, cmd = v.table.Update(msg)
-		case tea.KeyPgDown:
-			v.table, cmd = v.table.Update(msg)
-		case tea.KeyPgUp:
+		if v.filtering {
+			v.handleFilterInput(msg)
+			return v, nil
+		}
+
+		switch {
+		case key.Matches(msg, keys.Up, keys.Down, keys.Columns, keys.PageDown, keys.PageUp):
 			v.table, cmd = v.table.Update(msg)
-		case tea.KeyHome:
+		case key.Matches(msg, keys.HalfPageDown):
+			v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+		case key.Matches(msg, keys.HalfPageUp):
+			v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+		case key.Matches(msg, keys.Top, keys.ViewTop):
 			if len(v.resources) > 0 {
 				v.table.GotoTop()
 			}
-		case tea.KeyEnd:
+		case key.Matches(msg, keys.Bottom, keys.ViewBottom):
 			if len(v.resources) > 0 {
 				v.table.GotoBottom()
 			}
-		case tea.KeyEnter, tea.KeySpace:
-			// TODO: Show resource details
-			return v, nil
-		default:
-			// Handle string-based keys
-			switch msg.String() {
-			// Vertical navigation - j/k for vim users
-			case "j":
-				v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyDown})
-			case "k":
-				v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyUp})
-			
-			// Page navigation with vim-style shortcuts
-			case "ctrl+d":
-				v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyPgDown})
-			case "ctrl+u":
-				v.table, cmd = v.table.Update(tea.KeyMsg{Type: tea.KeyPgUp})
-			
-			// Vim-style navigation
-			case "g":
-				// Go to top
-				if len(v.resources) > 0 {
-					v.table.GotoTop()
-				}
-			case "G":
-				// Go to bottom
-				if len(v.resources) > 0 {
-					v.table.GotoBottom()
-				}
-			case "H":
-				// Go to top of visible area
-				if len(v.resources) > 0 {
-					v.table.GotoTop()
-				}
-			case "M":
-				// Go to middle of visible area
-				if len(v.resources) > 0 {
-					middle := len(v.resources) / 2
-					for i := 0; i < middle; i++ {
-						v.table, _ = v.table.Update(tea.KeyMsg{Type: tea.KeyDown})
-					}
-				}
-			case "L":
-				// Go to bottom of visible area
-				if len(v.resources) > 0 {
-					v.table.GotoBottom()
+		case key.Matches(msg, keys.ViewMiddle):
+			// Go to middle of visible area
+			if len(v.resources) > 0 {
+				middle := len(v.resources) / 2
+				for i := 0; i < middle; i++ {
+					v.table, _ = v.table.Update(tea.KeyMsg{Type: tea.KeyDown})
 				}
 			}
+		case key.Matches(msg, keys.Mark):
+			v.ToggleMark()
+		case key.Matches(msg, keys.Details):
+			if group, ok := v.selectedGroup(); ok {
+				v.ToggleCollapsed(group)
+				return v, nil
+			}
+			if resource := v.GetSelectedResource(); resource != nil {
+				selected := *resource
+				return v, func() tea.Msg { return ShowDetailsMsg{Resource: selected} }
+			}
+			return v, nil
+		case key.Matches(msg, keys.Filter):
+			v.StartFilter()
+		case key.Matches(msg, keys.Readiness):
+			// Cycle showing all, not ready and ready resources
+			v.CycleReadiness()
+		case key.Matches(msg, keys.Sort):
+			v.CycleSort()
+		case key.Matches(msg, keys.SortOrder):
+			v.ToggleSortOrder()
+		case key.Matches(msg, keys.Tenant):
+			// Toggle grouping by tenant label
+			if v.config.UI.TenantLabel != "" {
+				v.groupByTenant = !v.groupByTenant
+				v.groupByNamespace = false
+				v.collapsed = nil
+				v.applyOrdering()
+				v.updateTable()
+			}
+		case key.Matches(msg, keys.GroupNamespace):
+			v.ToggleNamespaceGrouping()
 		}
 	}
 	
@@ -146,24 +187,33 @@ func (v *ResourceView) Update(msg tea.Msg) (*ResourceView, tea.Cmd) {
 // View renders the resource view
 func (v *ResourceView) View() string {
 	if len(v.resources) == 0 {
-		emptyMsg := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
-			Render(fmt.Sprintf("No %s resources found", v.resourceType))
-		return emptyMsg
+		return v.renderEmptyState()
 	}
 	
-	return v.table.View()
+	return colorCells(tableView(v.table, v.height))
 }
 
 // SetResources sets the resources to display
 func (v *ResourceView) SetResources(resources []k8s.Resource) {
-	v.resources = resources
+	v.trackChanges(resources, time.Now())
+	v.allResources = resources
+	v.summary = summarize(resources)
+	v.applyOrdering()
 	v.updateTableColumns()
 	v.updateTable()
 }
 
 // SetResourceType sets the current resource type
 func (v *ResourceView) SetResourceType(resourceType k8s.ResourceType) {
+	if resourceType != v.resourceType {
+		v.marked = nil // Bulk actions apply to one type
+		// Start from the top, and drop the old rows since they don't fit
+		// the new type's columns. An empty table would clamp the cursor to -1.
+		if len(v.table.Rows()) > 0 {
+			v.table.SetCursor(0)
+		}
+		v.table.SetRows(nil)
+	}
 	v.resourceType = resourceType
 	v.updateTableColumns()
 	v.updateTable()
@@ -173,22 +223,200 @@ func (v *ResourceView) SetResourceType(resourceType k8s.ResourceType) {
 func (v *ResourceView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.table.SetHeight(height - 2) // Reserve space for borders
+	sizeTable(&v.table, v.config, height)
 	v.updateTableColumns()
 }
 
+// SetInventoryFilter restricts the view to the objects in a Kustomization's inventory
+func (v *ResourceView) SetInventoryFilter(owner k8s.Resource, refs []k8s.ObjectRef) {
+	filter := &inventoryFilter{owner: owner, objects: make(map[string]bool, len(refs))}
+	for _, ref := range refs {
+		filter.objects[inventoryKey(ref.Kind, ref.Namespace, ref.Name)] = true
+	}
+	v.managedBy = filter
+	v.applyOrdering()
+	v.updateTable()
+}
+
+// ClearInventoryFilter shows all resources again
+func (v *ResourceView) ClearInventoryFilter() {
+	v.managedBy = nil
+	v.applyOrdering()
+	v.updateTable()
+}
+
+// InventoryOwner returns the Kustomization the view is filtered to, or nil
+func (v *ResourceView) InventoryOwner() *k8s.Resource {
+	if v.managedBy == nil {
+		return nil
+	}
+	return &v.managedBy.owner
+}
+
+// managed reports whether a resource passes the inventory filter
+func (v *ResourceView) managed(resource k8s.Resource) bool {
+	if v.managedBy == nil {
+		return true
+	}
+	if v.managedBy.owner.Cluster != resource.Cluster {
+		return false
+	}
+	return v.managedBy.objects[inventoryKey(string(resource.Type), resource.Namespace, resource.Name)]
+}
+
+// applyOrdering derives the displayed resources from the received ones
+func (v *ResourceView) applyOrdering() {
+	v.resources = make([]k8s.Resource, 0, len(v.allResources))
+	for _, resource := range v.allResources {
+		if v.managed(resource) && v.matchesQuery(resource) && v.matchesReadiness(resource) {
+			v.resources = append(v.resources, resource)
+		}
+	}
+	v.sortResources()
+
+	// Grouping is stable, so rows stay sorted within each group
+	if v.grouping() {
+		sort.SliceStable(v.resources, func(i, j int) bool {
+			a, b := v.group(v.resources[i]), v.group(v.resources[j])
+			// Keep untenanted resources at the end
+			if (a == noTenantGroup) != (b == noTenantGroup) {
+				return b == noTenantGroup
+			}
+			return a < b
+		})
+	}
+}
+
+// grouping reports whether rows are grouped under header rows
+func (v *ResourceView) grouping() bool {
+	return v.tenantGrouping() || v.namespaceGrouping()
+}
+
+// group returns the header a resource is listed under
+func (v *ResourceView) group(resource k8s.Resource) string {
+	if v.tenantGrouping() {
+		return v.tenantGroup(resource)
+	}
+	return resource.Namespace
+}
+
+// namespaceGrouping reports whether rows are grouped by namespace, which
+// only applies while all namespaces are listed
+func (v *ResourceView) namespaceGrouping() bool {
+	return v.groupByNamespace && v.allNamespaces
+}
+
+// SetAllNamespaces tells the view whether all namespaces are listed
+func (v *ResourceView) SetAllNamespaces(all bool) {
+	v.allNamespaces = all
+	v.collapsed = nil
+	v.applyOrdering()
+	v.updateTable()
+}
+
+// ToggleNamespaceGrouping turns grouping by namespace on or off, replacing
+// grouping by tenant
+func (v *ResourceView) ToggleNamespaceGrouping() {
+	v.groupByNamespace = !v.groupByNamespace
+	if v.groupByNamespace {
+		v.groupByTenant = false
+	}
+	v.collapsed = nil
+	v.applyOrdering()
+	v.updateTable()
+}
+
+// ToggleCollapsed hides or shows the rows of a group. The cursor stays on
+// the group's header, which keeps its row.
+func (v *ResourceView) ToggleCollapsed(group string) {
+	if v.collapsed == nil {
+		v.collapsed = make(map[string]bool)
+	}
+	v.collapsed[group] = !v.collapsed[group]
+	v.updateTable()
+}
+
+// selectedGroup returns the group whose header row is under the cursor
+func (v *ResourceView) selectedGroup() (string, bool) {
+	cursor := v.table.Cursor()
+	if cursor < 0 || cursor >= len(v.rowIndex) || v.rowIndex[cursor] >= 0 {
+		return "", false
+	}
+	return v.rowGroup[cursor], true
+}
+
+// tenantGrouping reports whether rows are grouped by tenant label
+func (v *ResourceView) tenantGrouping() bool {
+	return v.groupByTenant && v.config.UI.TenantLabel != ""
+}
+
+// tenantGroup returns the tenant group name of a resource
+func (v *ResourceView) tenantGroup(resource k8s.Resource) string {
+	if tenant := resource.Labels[v.config.UI.TenantLabel]; tenant != "" {
+		return tenant
+	}
+	return noTenantGroup
+}
+
 // updateTable updates the table with current resources
 func (v *ResourceView) updateTable() {
 	rows := make([]table.Row, 0, len(v.resources))
+	v.rowIndex = make([]int, 0, len(v.resources))
+	v.rowGroup = make([]string, 0, len(v.resources))
 	
-	for _, resource := range v.resources {
+	grouping := v.grouping()
+	counts := make(map[string]int)
+	if grouping {
+		for _, resource := range v.resources {
+			counts[v.group(resource)]++
+		}
+	}
+	
+	lastGroup := ""
+	for i, resource := range v.resources {
+		group := ""
+		if grouping {
+			group = v.group(resource)
+			if i == 0 || group != lastGroup {
+				rows = append(rows, v.createGroupRow(group, counts[group]))
+				v.rowIndex = append(v.rowIndex, -1)
+				v.rowGroup = append(v.rowGroup, group)
+				lastGroup = group
+			}
+			if v.collapsed[group] {
+				continue
+			}
+		}
+		
 		row := v.createTableRow(resource)
+		if v.config.UI.ShowNextSync {
+			row = append(row, formatNextSync(resource, time.Now()))
+		}
+		if v.config.Fleet {
+			row = append(table.Row{resource.Cluster}, row...)
+		}
 		rows = append(rows, row)
+		v.rowIndex = append(v.rowIndex, i)
+		v.rowGroup = append(v.rowGroup, group)
 	}
 	
 	v.table.SetRows(rows)
 }
 
+// createGroupRow creates a group header row spanning the table's columns,
+// marked as expanded or collapsed
+func (v *ResourceView) createGroupRow(group string, count int) table.Row {
+	row := make(table.Row, len(v.table.Columns()))
+	if len(row) > 0 {
+		marker := "▾"
+		if v.collapsed[group] {
+			marker = "▸"
+		}
+		row[0] = asciiSafe(v.config, fmt.Sprintf("%s %s (%d)", marker, group, count))
+	}
+	return row
+}
+
 // createTableRow creates a table row for a resource
 func (v *ResourceView) createTableRow(resource k8s.Resource) table.Row {
 	// Format name with namespace if shown
@@ -196,46 +424,100 @@ func (v *ResourceView) createTableRow(resource k8s.Resource) table.Row {
 	if v.config.UI.ShowNamespace && resource.Namespace != "" {
 		name = fmt.Sprintf("%s/%s", resource.Namespace, resource.Name)
 	}
+	if resource.Suspended {
+		name = asciiSafe(v.config, suspendedMarker+name)
+	}
+	if marker, ok := v.highlight(resource, time.Now()); ok {
+		name = asciiSafe(v.config, marker+name)
+	}
+	if v.isMarked(resource) {
+		name = asciiSafe(v.config, markMarker+name)
+	}
 	
-	// Format ready status (plain text)
-	ready := "False"
-	if resource.Ready {
-		ready = "True"
+	// Format ready status, colored by health
+	ready := colorCell(v.config, "False", cellColorNotReady)
+	if resource.Suspended {
+		ready = colorCell(v.config, "False", cellColorSuspended)
+		if resource.Ready {
+			ready = colorCell(v.config, "True", cellColorSuspended)
+		}
+	} else if resource.Ready {
+		ready = colorCell(v.config, "True", cellColorReady)
 	}
 	
 	// Format status (plain text)
 	status := resource.Status
-	if status == "" {
+	if status == "" && resource.Ready {
+		status = "Ready"
+	} else if status == "" {
 		status = "Unknown"
 	}
 	if resource.Suspended {
 		status = "Suspended"
+	} else if resource.Stalled {
+		// Retries have stopped, the resource needs manual intervention
+		status = asciiSafe(v.config, "⛔ Stalled")
+	}
+	if resource.Overdue {
+		status = asciiSafe(v.config, "⌛ "+status)
+	}
+	if resource.Snoozed(time.Now()) {
+		status = asciiSafe(v.config, "💤 "+status)
 	}
 	
 	// Truncate status if too long
-	if len(status) > 12 {
-		status = status[:9] + "…"
-	}
+	status = truncate(v.config, status, 12)
 	
 	// Format age (plain text)
-	age := formatAge(resource.Age)
+	age := formatAge(v.age(resource))
 	
-	// Format message (truncate if too long)
-	message := resource.Message
-	if len(message) > 35 {
-		message = message[:32] + "…"
-	}
+	// Format message (truncate if too long), preferring source fetch errors
+	message := resource.DisplayMessage()
+	message = truncate(v.config, message, 35)
 
 	// Resource-specific columns
 	switch v.resourceType {
 	case k8s.ResourceTypeGitRepository, k8s.ResourceTypeHelmRepository:
 		return table.Row{name, ready, status, age, message, resource.URL}
+	case k8s.ResourceTypeOCIRepository:
+		return table.Row{name, ready, status, age, message, resource.URL, resource.Ref}
+	case k8s.ResourceTypeBucket:
+		bucket := resource.Source
+		if resource.Path != "" {
+			bucket += "/" + resource.Path
+		}
+		return table.Row{name, ready, status, age, message, resource.URL, bucket, resource.Provider}
+	case k8s.ResourceTypeImageRepository:
+		return table.Row{name, ready, status, age, message, resource.URL, formatScan(resource.LastScan)}
+	case k8s.ResourceTypeImagePolicy:
+		return table.Row{name, ready, status, age, message, resource.Source, resource.Ref}
+	case k8s.ResourceTypeImageUpdateAutomation:
+		return table.Row{name, ready, status, age, message, resource.Source, resource.Ref}
+	case k8s.ResourceTypeAlert:
+		return table.Row{name, ready, status, age, message, resource.Source, strings.Join(resource.EventSources, ", ")}
+	case k8s.ResourceTypeProvider:
+		return table.Row{name, ready, status, age, message, resource.Provider, resource.Path}
+	case k8s.ResourceTypeReceiver:
+		return table.Row{name, ready, status, age, message, resource.Provider, resource.URL, strings.Join(resource.EventSources, ", ")}
 	case k8s.ResourceTypeKustomization:
 		source := resource.Source
 		if resource.Path != "" {
 			source = fmt.Sprintf("%s/%s", source, resource.Path)
 		}
-		return table.Row{name, ready, status, age, message, source}
+		if resource.Drift {
+			// The source has a newer artifact than the one applied. Truncate
+			// first, the table would cut off the end marker and the color
+			// would run into the next cells.
+			if width := v.columnWidth("Source/Path"); width > 0 {
+				source = truncate(v.config, source, width)
+			}
+			source = colorCell(v.config, source, cellColorWarning)
+		}
+		objects := "-"
+		if resource.InventoryCount > 0 {
+			objects = strconv.Itoa(resource.InventoryCount)
+		}
+		return table.Row{name, ready, status, age, message, source, objects}
 	case k8s.ResourceTypeHelmRelease:
 		chart := resource.Chart
 		if resource.Version != "" {
@@ -247,13 +529,23 @@ func (v *ResourceView) createTableRow(resource k8s.Resource) table.Row {
 	}
 }
 
+// columnWidth returns the width of the titled column, 0 when it isn't shown
+func (v *ResourceView) columnWidth(title string) int {
+	for _, column := range v.table.Columns() {
+		if column.Title == title {
+			return column.Width
+		}
+	}
+	return 0
+}
+
 // updateTableColumns updates table columns based on resource type and width
 func (v *ResourceView) updateTableColumns() {
 	baseColumns := []table.Column{
 		{Title: "Name", Width: v.config.UI.ColumnsName},
 		{Title: "Ready", Width: 8},
 		{Title: "Status", Width: v.config.UI.ColumnsStatus},
-		{Title: "Age", Width: 10},
+		{Title: v.ageTitle(), Width: 10},
 		{Title: "Message", Width: 35},
 	}
 
@@ -264,9 +556,34 @@ func (v *ResourceView) updateTableColumns() {
 	case k8s.ResourceTypeHelmRepository:
 		baseColumns = append(baseColumns, table.Column{Title: "URL", Width: 40})
 	case k8s.ResourceTypeKustomization:
-		baseColumns = append(baseColumns, table.Column{Title: "Source/Path", Width: 30})
+		baseColumns = append(baseColumns, table.Column{Title: "Source/Path", Width: 30}, table.Column{Title: "Objects", Width: 8})
 	case k8s.ResourceTypeHelmRelease:
 		baseColumns = append(baseColumns, table.Column{Title: "Chart", Width: 25})
+	case k8s.ResourceTypeOCIRepository:
+		baseColumns = append(baseColumns, table.Column{Title: "URL", Width: 40}, table.Column{Title: "Tag", Width: 20})
+	case k8s.ResourceTypeBucket:
+		baseColumns = append(baseColumns, table.Column{Title: "Endpoint", Width: 30}, table.Column{Title: "Bucket", Width: 25}, table.Column{Title: "Provider", Width: 10})
+	case k8s.ResourceTypeImageRepository:
+		baseColumns = append(baseColumns, table.Column{Title: "Image", Width: 40}, table.Column{Title: "Last Scan", Width: 18})
+	case k8s.ResourceTypeImagePolicy:
+		baseColumns = append(baseColumns, table.Column{Title: "Repository", Width: 25}, table.Column{Title: "Latest Tag", Width: 20})
+	case k8s.ResourceTypeImageUpdateAutomation:
+		baseColumns = append(baseColumns, table.Column{Title: "Source", Width: 25}, table.Column{Title: "Push Ref", Width: 20})
+	case k8s.ResourceTypeAlert:
+		baseColumns = append(baseColumns, table.Column{Title: "Provider", Width: 20}, table.Column{Title: "Event Sources", Width: 40})
+	case k8s.ResourceTypeProvider:
+		baseColumns = append(baseColumns, table.Column{Title: "Type", Width: 12}, table.Column{Title: "Channel", Width: 20})
+	case k8s.ResourceTypeReceiver:
+		baseColumns = append(baseColumns, table.Column{Title: "Type", Width: 12}, table.Column{Title: "Webhook", Width: 40}, table.Column{Title: "Resources", Width: 30})
+	}
+
+	if v.config.UI.ShowNextSync {
+		baseColumns = append(baseColumns, table.Column{Title: "Next Sync", Width: 10})
+	}
+
+	// Fleet mode aggregates clusters into one table
+	if v.config.Fleet {
+		baseColumns = append([]table.Column{{Title: "Cluster", Width: 16}}, baseColumns...)
 	}
 
 	// Adjust column widths based on available space
@@ -296,27 +613,156 @@ func (v *ResourceView) updateTableColumns() {
 		}
 	}
 
+	v.markSortColumn(baseColumns)
 	v.table.SetColumns(baseColumns)
 }
 
+// Bounds of the Name column width adjusted with AdjustNameWidth
+const (
+	minNameWidth = 10
+	maxNameWidth = 80
+)
+
+// ToggleShowNamespace shows or hides namespaces in the Name column
+func (v *ResourceView) ToggleShowNamespace() {
+	v.config.UI.ShowNamespace = !v.config.UI.ShowNamespace
+	v.updateTable()
+}
+
+// AdjustNameWidth widens the Name column by delta, within its bounds,
+// returning the new width
+func (v *ResourceView) AdjustNameWidth(delta int) int {
+	v.config.UI.ColumnsName = max(minNameWidth, min(maxNameWidth, v.config.UI.ColumnsName+delta))
+	v.updateTableColumns()
+	v.updateTable()
+	return v.config.UI.ColumnsName
+}
+
 // GetSelectedResource returns the currently selected resource
 func (v *ResourceView) GetSelectedResource() *k8s.Resource {
 	cursor := v.table.Cursor()
-	if cursor >= 0 && cursor < len(v.resources) {
-		return &v.resources[cursor]
+	if cursor < 0 || cursor >= len(v.rowIndex) {
+		return nil
+	}
+	if index := v.rowIndex[cursor]; index >= 0 {
+		return &v.resources[index]
 	}
 	return nil
 }
 
-// formatAge formats a duration as a human-readable age string
+// SelectResource moves the cursor to a resource, reporting false when it is
+// not in the table
+func (v *ResourceView) SelectResource(resource k8s.Resource) bool {
+	// Expand the group hiding the resource
+	for _, candidate := range v.resources {
+		if v.grouping() && candidate.Type == resource.Type && candidate.Namespace == resource.Namespace && candidate.Name == resource.Name && v.collapsed[v.group(candidate)] {
+			v.collapsed[v.group(candidate)] = false
+			v.updateTable()
+			break
+		}
+	}
+	for row, index := range v.rowIndex {
+		if index < 0 {
+			continue
+		}
+		candidate := v.resources[index]
+		if candidate.Type == resource.Type && candidate.Namespace == resource.Namespace && candidate.Name == resource.Name {
+			v.table.SetCursor(row)
+			return true
+		}
+	}
+	return false
+}
+
+// formatNextSync renders the time until a resource's next scheduled
+// reconcile, "due" once it has passed and "-" when none is scheduled. The
+// schedule is estimated from the last known reconcile, hence the "~".
+func formatNextSync(resource k8s.Resource, now time.Time) string {
+	if resource.NextReconcile.IsZero() {
+		return "-"
+	}
+	until := resource.UntilReconcile(now)
+	if until <= 0 {
+		return "due"
+	}
+	return "~in " + formatAge(until)
+}
+
+// age returns what the Age column shows for a resource: its age, or how long
+// it has been in its current Ready state when the config asks for that.
+// Resources without a Ready condition fall back to their age.
+func (v *ResourceView) age(resource k8s.Resource) time.Duration {
+	if v.config.UI.ReadyAge() && !resource.ReadySince.IsZero() {
+		return time.Since(resource.ReadySince)
+	}
+	return resource.Age
+}
+
+// ageTitle returns the title of the Age column
+func (v *ResourceView) ageTitle() string {
+	if v.config.UI.ReadyAge() {
+		return "In State"
+	}
+	return "Age"
+}
+
+// formatTimestamp renders an absolute timestamp using the configured layout
+// and time zone, or as "3m ago" when the format is "relative"
+func formatTimestamp(cfg *config.Config, t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if cfg.UI.TimeFormat == config.TimeFormatRelative {
+		return formatAge(time.Since(t)) + " ago"
+	}
+	layout := cfg.UI.TimeFormat
+	if layout == "" {
+		layout = time.DateTime
+	}
+	return t.In(cfg.UI.Location()).Format(layout)
+}
+
+// Calendar-free units for ages beyond a day
+const (
+	ageDay  = 24 * time.Hour
+	ageWeek = 7 * ageDay
+	ageYear = 365 * ageDay
+)
+
+// formatAge formats a duration as a human-readable age string. Ages of a week
+// or more show the two most significant units, e.g. "3w2d" or "1y5w".
 func formatAge(d time.Duration) string {
-	if d < time.Minute {
+	switch {
+	case d < time.Minute:
 		return fmt.Sprintf("%ds", int(d.Seconds()))
-	} else if d < time.Hour {
+	case d < time.Hour:
 		return fmt.Sprintf("%dm", int(d.Minutes()))
-	} else if d < 24*time.Hour {
+	case d < ageDay:
 		return fmt.Sprintf("%dh", int(d.Hours()))
-	} else {
-		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < ageWeek:
+		return fmt.Sprintf("%dd", int(d/ageDay))
+	case d < ageYear:
+		return twoUnits(int(d/ageWeek), "w", int(d%ageWeek/ageDay), "d")
+	default:
+		return twoUnits(int(d/ageYear), "y", int(d%ageYear/ageWeek), "w")
+	}
+}
+
+// twoUnits renders a major and minor unit, dropping the minor one when zero
+func twoUnits(major int, majorUnit string, minor int, minorUnit string) string {
+	if minor == 0 {
+		return fmt.Sprintf("%d%s", major, majorUnit)
+	}
+	return fmt.Sprintf("%d%s%d%s", major, majorUnit, minor, minorUnit)
+}
+
+// formatScan renders an ImageRepository's last scan as its tag count and age
+func formatScan(scan *k8s.ScanResult) string {
+	if scan == nil {
+		return "-"
+	}
+	if scan.ScanTime.IsZero() {
+		return fmt.Sprintf("%d tags", scan.TagCount)
 	}
+	return fmt.Sprintf("%d tags, %s ago", scan.TagCount, formatAge(time.Since(scan.ScanTime)))
 }
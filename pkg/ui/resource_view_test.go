@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	
@@ -108,6 +113,22 @@ func TestResourceView_GetSelectedResource(t *testing.T) {
 	assert.Equal(t, "test-repo", selected.Name)
 }
 
+func TestResourceView_EnterShowsDetails(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetSize(120, 40)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{createTestResource("apps", "flux-system", k8s.ResourceTypeKustomization)})
+
+	_, cmd := rv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(ShowDetailsMsg)
+	require.True(t, ok)
+	assert.Equal(t, "apps", msg.Resource.Name)
+}
+
 // Helper function to create test Resource
 func createTestResource(name, namespace string, resourceType k8s.ResourceType) k8s.Resource {
 	return k8s.Resource{
@@ -133,3 +154,583 @@ func createTestResource(name, namespace string, resourceType k8s.ResourceType) k
 		Revision:  "main@sha256:abc123",
 	}
 }
+
+func TestResourceView_GroupByTenant(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	cfg.UI.TenantLabel = "tenant"
+
+	rv := NewResourceView(cfg)
+
+	payments := createTestResource("payments-repo", "default", k8s.ResourceTypeGitRepository)
+	payments.Labels = map[string]string{"tenant": "payments"}
+	untenanted := createTestResource("shared-repo", "default", k8s.ResourceTypeGitRepository)
+	billing := createTestResource("billing-repo", "default", k8s.ResourceTypeGitRepository)
+	billing.Labels = map[string]string{"tenant": "billing"}
+
+	rv.SetResources([]k8s.Resource{payments, untenanted, billing})
+
+	rows := rv.table.Rows()
+	require.Len(t, rows, 6)
+	assert.Contains(t, rows[0][0], "billing (1)")
+	assert.Contains(t, rows[2][0], "payments (1)")
+	assert.Contains(t, rows[4][0], "(no tenant) (1)")
+
+	// Group headers are not selectable resources
+	assert.Nil(t, rv.GetSelectedResource())
+
+	rv.table.SetCursor(1)
+	selected := rv.GetSelectedResource()
+	require.NotNil(t, selected)
+	assert.Equal(t, "billing-repo", selected.Name)
+}
+
+func TestResourceView_GroupByNamespace(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	cfg.UI.GroupByNamespace = true
+
+	rv := NewResourceView(cfg)
+	rv.SetResources([]k8s.Resource{
+		createTestResource("team-b-repo", "team-b", k8s.ResourceTypeGitRepository),
+		createTestResource("team-a-repo", "team-a", k8s.ResourceTypeGitRepository),
+		createTestResource("team-a-other", "team-a", k8s.ResourceTypeGitRepository),
+	})
+
+	// Only applies while all namespaces are listed
+	require.Len(t, rv.table.Rows(), 3)
+
+	rv.SetAllNamespaces(true)
+	rows := rv.table.Rows()
+	require.Len(t, rows, 5)
+	assert.Contains(t, rows[0][0], "team-a (2)")
+	assert.Contains(t, rows[3][0], "team-b (1)")
+
+	// Enter on a header collapses its rows
+	rv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	rows = rv.table.Rows()
+	require.Len(t, rows, 3)
+	assert.Contains(t, rows[0][0], "▸ team-a (2)")
+	assert.Nil(t, rv.GetSelectedResource())
+
+	// Rows after a collapsed group still map to their resources
+	rv.table.SetCursor(2)
+	selected := rv.GetSelectedResource()
+	require.NotNil(t, selected)
+	assert.Equal(t, "team-b-repo", selected.Name)
+
+	// Selecting a hidden resource expands its group
+	require.True(t, rv.SelectResource(createTestResource("team-a-other", "team-a", k8s.ResourceTypeGitRepository)))
+	require.Len(t, rv.table.Rows(), 5)
+	assert.Equal(t, "team-a-other", rv.GetSelectedResource().Name)
+
+	rv.ToggleNamespaceGrouping()
+	assert.Len(t, rv.table.Rows(), 3)
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	cfg.UI.TimeFormat = time.RFC3339
+	assert.Equal(t, ts.In(cfg.UI.Location()).Format(time.RFC3339), formatTimestamp(cfg, ts))
+
+	cfg.UI.TimeFormat = config.TimeFormatRelative
+	assert.Equal(t, "5m ago", formatTimestamp(cfg, time.Now().Add(-5*time.Minute)))
+
+	assert.Equal(t, "-", formatTimestamp(cfg, time.Time{}))
+}
+
+func TestFormatAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{59 * time.Second, "59s"},
+		{60 * time.Second, "1m"},
+		{59 * time.Minute, "59m"},
+		{60 * time.Minute, "1h"},
+		{23 * time.Hour, "23h"},
+		{24 * time.Hour, "1d"},
+		{6 * day, "6d"},
+		{7 * day, "1w"},
+		{23 * day, "3w2d"},
+		{364 * day, "52w"},
+		{365 * day, "1y"},
+		{400 * day, "1y5w"},
+		{2*365*day + 3*day, "2y"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatAge(tt.age), tt.age.String())
+	}
+}
+
+func TestResourceView_EmptyStatus(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	healthy := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	healthy.Ready = true
+	healthy.Status = ""
+	assert.Equal(t, "Ready", rv.createTableRow(healthy)[2])
+
+	unknown := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	unknown.Ready = false
+	unknown.Status = ""
+	assert.Equal(t, "Unknown", rv.createTableRow(unknown)[2])
+}
+
+func TestResourceView_EmptyState(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeGitRepository)
+	assert.Contains(t, rv.View(), "No GitRepository resources found")
+	assert.Contains(t, rv.View(), "flux create source git")
+
+	rv.SetResourceType(k8s.ResourceTypeHelmRelease)
+	assert.Contains(t, rv.View(), "create a HelmRepository first")
+
+	// A missing CRD takes precedence over the hint
+	rv.SetNotInstalled(k8s.ResourceTypeHelmRelease, true)
+	assert.Contains(t, rv.View(), "The HelmRelease CRD is not installed")
+	assert.NotContains(t, rv.View(), "flux create helmrelease")
+
+	// Hints are cut to the view width instead of wrapping
+	rv.SetResourceType(k8s.ResourceTypeImageUpdateAutomation)
+	rv.SetSize(40, 10)
+	lines := strings.Split(rv.View(), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, lipgloss.Width(line), 40)
+	}
+	assert.Contains(t, lines[1], "…")
+}
+
+func TestResourceView_Overdue(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	late := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	late.Status = "Ready"
+	late.Overdue = true
+	onTime := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{late, onTime})
+
+	assert.Equal(t, 1, rv.OverdueCount())
+	assert.Equal(t, "⌛ Ready", rv.createTableRow(late)[2])
+
+	cfg.UI.Accessible = true
+	assert.Equal(t, "late Ready", rv.createTableRow(late)[2])
+}
+
+func TestResourceView_Stalled(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeHelmRelease)
+
+	stalled := createTestResource("podinfo", "apps", k8s.ResourceTypeHelmRelease)
+	stalled.Ready = false
+	stalled.Status = "UpgradeFailed"
+	stalled.Stalled = true
+	assert.Equal(t, "⛔ Stalled", rv.createTableRow(stalled)[2])
+
+	// Suspending a stalled release is what shows
+	stalled.Suspended = true
+	assert.Equal(t, "Suspended", rv.createTableRow(stalled)[2])
+
+	stalled.Suspended = false
+	cfg.UI.Accessible = true
+	assert.Equal(t, "!! Stalled", rv.createTableRow(stalled)[2])
+}
+
+func TestResourceView_NextSync(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	cfg.UI.ShowNextSync = true
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	now := time.Now()
+	soon := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	soon.NextReconcile = now.Add(3*time.Minute + 30*time.Second)
+	due := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	due.NextReconcile = now.Add(-time.Minute)
+	suspended := createTestResource("tenants", "default", k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{soon, due, suspended})
+
+	columns := rv.table.Columns()
+	assert.Equal(t, "Next Sync", columns[len(columns)-1].Title)
+	rows := rv.table.Rows()
+	require.Len(t, rows, 3)
+	assert.Equal(t, "~in 3m", rows[0][len(columns)-1])
+	assert.Equal(t, "due", rows[1][len(columns)-1])
+	assert.Equal(t, "-", rows[2][len(columns)-1])
+}
+
+func TestResourceView_ReadyAge(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	// Created long ago, but only Ready since a recent fix
+	fixed := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	fixed.Age = 30 * 24 * time.Hour
+	fixed.ReadySince = time.Now().Add(-2 * time.Minute)
+	// No Ready condition yet, so the age stands in
+	pending := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	pending.Age = time.Hour
+	rv.SetResources([]k8s.Resource{fixed, pending})
+
+	assert.Equal(t, "Age", rv.table.Columns()[3].Title)
+	assert.Equal(t, "4w2d", rv.table.Rows()[0][3])
+
+	cfg.UI.AgeColumn = config.AgeColumnReady
+	rv.SetResources([]k8s.Resource{fixed, pending})
+	assert.Equal(t, "In State", rv.table.Columns()[3].Title)
+	rows := rv.table.Rows()
+	assert.Equal(t, "2m", rows[0][3])
+	assert.Equal(t, "1h", rows[1][3])
+
+	// Sorting follows the column
+	rv.sortBy = sortAge
+	rv.SetResources([]k8s.Resource{pending, fixed})
+	assert.Equal(t, "In State ↑", rv.table.Columns()[3].Title)
+	assert.Contains(t, rv.table.Rows()[0][0], "apps")
+}
+
+func TestResourceView_InventoryCount(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	apps := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	apps.InventoryCount = 42
+	rv.SetResources([]k8s.Resource{apps, createTestResource("infra", "default", k8s.ResourceTypeKustomization)})
+
+	columns := rv.table.Columns()
+	assert.Equal(t, "Objects", columns[len(columns)-1].Title)
+	rows := rv.table.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "42", rows[0][len(columns)-1])
+	assert.Equal(t, "-", rows[1][len(columns)-1])
+}
+
+func TestResourceView_ReadyColors(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	rv := NewResourceView(cfg)
+	rv.SetSize(120, 20)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	ready := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	failing := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	failing.Ready = false
+	paused := createTestResource("tenants", "default", k8s.ResourceTypeKustomization)
+	paused.Suspended = true
+	rv.SetResources([]k8s.Resource{ready, failing, paused})
+
+	// Colored cells are neither truncated nor reset the selected row's background
+	view := rv.View()
+	// The cursor row goes back to the selected foreground after the cell
+	assert.Contains(t, view, "\x1b[38;5;42mTrue\x1b[38;5;229m")
+	assert.Contains(t, view, "\x1b[38;5;196mFalse\x1b[39m")
+	assert.Contains(t, view, "\x1b[38;5;226mTrue\x1b[39m")
+	assert.NotContains(t, view, "\u200b")
+	assert.NotContains(t, view, "Tr…")
+
+	// Colors can be turned off without switching to ASCII glyphs
+	cfg.UI.NoColor = true
+	assert.Equal(t, "True", rv.createTableRow(ready)[1])
+	assert.Equal(t, "False", rv.createTableRow(failing)[1])
+
+	// Suspended resources stand out even when Ready
+	assert.Equal(t, "⏸ default/tenants", rv.createTableRow(paused)[0])
+	cfg.UI.Accessible = true
+	assert.Equal(t, "|| default/tenants", rv.createTableRow(paused)[0])
+}
+
+func TestResourceView_DriftLongPath(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	rv := NewResourceView(cfg)
+	rv.SetSize(160, 20)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	apps := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	apps.Source = "GitRepository/fleet-infrastructure"
+	apps.Path = "./clusters/production/eu-west-1/apps"
+	apps.Drift = true
+	apps.InventoryCount = 7
+	rv.SetResources([]k8s.Resource{apps})
+
+	// The color ends within the cell instead of bleeding into the next ones,
+	// back to the foreground of the cursor row it is on
+	view := rv.View()
+	assert.Contains(t, view, "\x1b[38;5;214mGitRepository/fleet-infrastru…\x1b[38;5;229m")
+	assert.NotContains(t, view, "\u200b")
+	assert.NotContains(t, view, "\u200c")
+}
+
+func TestResourceView_TruncatesByDisplayWidth(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	resource := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	resource.Status = "ÜberprüfungFehlgeschlagen"
+	resource.Message = "健康检查失败：等待部署超时，请检查控制器日志以获取更多信息"
+	row := rv.createTableRow(resource)
+
+	// Multibyte runes are never split, and wide ones count twice
+	assert.True(t, utf8.ValidString(row[2]))
+	assert.Equal(t, "Überprüfung…", row[2])
+	assert.True(t, utf8.ValidString(row[4]))
+	assert.Equal(t, 35, runewidth.StringWidth(row[4]))
+	assert.Equal(t, "…", row[4][len(row[4])-len("…"):])
+
+	// Short values stay as they are
+	resource.Message = "部署成功"
+	assert.Equal(t, "部署成功", rv.createTableRow(resource)[4])
+}
+
+func TestResourceView_TinyHeight(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{
+		createTestResource("apps", "default", k8s.ResourceTypeKustomization),
+		createTestResource("infra", "default", k8s.ResourceTypeKustomization),
+	})
+
+	require.NotPanics(t, func() { rv.SetSize(80, 1) })
+	view := rv.View()
+	assert.Contains(t, view, "apps", "the selected row stays visible")
+	assert.NotContains(t, view, "Name", "the header is dropped")
+	assert.Equal(t, 1, rv.table.Height())
+
+	// Navigation still works at the minimum height
+	rv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "infra", rv.GetSelectedResource().Name)
+	assert.Contains(t, rv.View(), "infra")
+
+	// Small heights keep the header but drop its rule
+	rv.SetSize(80, 4)
+	assert.Contains(t, rv.View(), "Name")
+	assert.NotContains(t, rv.View(), "───")
+}
+
+func TestResourceView_ChangeHighlight(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	cfg.UI.ShowNamespace = false
+
+	rv := NewResourceView(cfg)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	apps := createTestResource("apps", "default", k8s.ResourceTypeKustomization)
+	infra := createTestResource("infra", "default", k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{apps, infra})
+
+	// The first snapshot marks nothing
+	assert.Equal(t, "apps", rv.createTableRow(apps)[0])
+	assert.False(t, rv.fadePending)
+
+	apps.Revision = "main@sha1:def"
+	rv.SetResources([]k8s.Resource{apps, infra})
+	assert.Equal(t, "• apps", rv.createTableRow(apps)[0])
+	assert.Equal(t, "infra", rv.createTableRow(infra)[0])
+	assert.True(t, rv.fadePending)
+
+	rv.fadeChanges(time.Now().Add(cfg.UI.ChangeHighlightDuration))
+	assert.Equal(t, "apps", rv.createTableRow(apps)[0])
+
+	// Becoming Ready and losing it are told apart
+	apps.Ready, infra.Ready = false, true
+	rv.SetResources([]k8s.Resource{apps, infra})
+	apps.Ready, infra.Ready = true, false
+	rv.SetResources([]k8s.Resource{apps, infra})
+	assert.Equal(t, "▲ apps", rv.createTableRow(apps)[0])
+	assert.Equal(t, "▼ infra", rv.createTableRow(infra)[0])
+	rv.fadeChanges(time.Now().Add(cfg.UI.ChangeHighlightDuration))
+
+	// Disabled highlights never mark rows
+	cfg.UI.ChangeHighlight = false
+	apps.Ready = !apps.Ready
+	rv.SetResources([]k8s.Resource{apps, infra})
+	assert.Equal(t, "apps", rv.createTableRow(apps)[0])
+}
+
+func TestResourceView_NameFilter(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+	cfg.UI.ShowNamespace = true
+
+	rv := NewResourceView(cfg)
+	rv.SetSize(120, 40)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+	rv.SetResources([]k8s.Resource{
+		createTestResource("apps", "team-a", k8s.ResourceTypeKustomization),
+		createTestResource("infra", "team-a", k8s.ResourceTypeKustomization),
+		createTestResource("apps", "team-b", k8s.ResourceTypeKustomization),
+	})
+
+	typeQuery := func(s string) {
+		for _, r := range s {
+			rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	assert.True(t, rv.Filtering())
+	typeQuery("APPS")
+	assert.Len(t, rv.resources, 2)
+
+	// The namespace matches too when it is shown
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, rv.Filtering())
+	assert.Equal(t, "APPS", rv.FilterQuery())
+	rv.ClearFilter()
+	rv.StartFilter()
+	typeQuery("team-b/")
+	require.Len(t, rv.resources, 1)
+	assert.Equal(t, "team-b", rv.GetSelectedResource().Namespace)
+
+	// Updates keep the filter, while the full list is kept for clearing it
+	rv.SetResources(append(rv.allResources, createTestResource("web", "team-b", k8s.ResourceTypeKustomization)))
+	assert.Len(t, rv.resources, 2)
+
+	typeQuery("x")
+	assert.Empty(t, rv.resources)
+	assert.Contains(t, rv.View(), "No Kustomization match /team-b/x")
+
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Len(t, rv.resources, 2)
+
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, rv.Filtering())
+	assert.Empty(t, rv.FilterQuery())
+	assert.Len(t, rv.resources, 4)
+}
+
+func TestResourceView_ReadinessFilter(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetSize(120, 40)
+	rv.SetResourceType(k8s.ResourceTypeHelmRelease)
+
+	failing := createTestResource("failing", "default", k8s.ResourceTypeHelmRelease)
+	failing.Ready = false
+	snoozed := failing
+	snoozed.Name = "snoozed"
+	snoozed.SnoozedUntil = time.Now().Add(time.Hour)
+	rv.SetResources([]k8s.Resource{createTestResource("healthy", "default", k8s.ResourceTypeHelmRelease), failing, snoozed})
+
+	assert.Empty(t, rv.ReadinessLabel())
+	assert.Len(t, rv.resources, 3)
+
+	// Snoozed resources stay out of the not ready view
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	assert.Equal(t, "not ready", rv.ReadinessLabel())
+	require.Len(t, rv.resources, 1)
+	assert.Equal(t, "failing", rv.GetSelectedResource().Name)
+
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	assert.Equal(t, "ready", rv.ReadinessLabel())
+	require.Len(t, rv.resources, 1)
+	assert.Equal(t, "healthy", rv.resources[0].Name)
+
+	rv.SetResources(nil)
+	assert.Contains(t, rv.View(), "No ready HelmRelease resources")
+
+	rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	assert.Empty(t, rv.ReadinessLabel())
+}
+
+func TestResourceView_Sorting(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	rv := NewResourceView(cfg)
+	rv.SetSize(120, 40)
+	rv.SetResourceType(k8s.ResourceTypeKustomization)
+
+	// 2h sorts after 90m numerically, unlike the formatted "2h" < "90m"
+	older := createTestResource("b-older", "default", k8s.ResourceTypeKustomization)
+	older.Age = 2 * time.Hour
+	newer := createTestResource("c-newer", "default", k8s.ResourceTypeKustomization)
+	newer.Age = 90 * time.Minute
+	failing := createTestResource("a-failing", "default", k8s.ResourceTypeKustomization)
+	failing.Ready, failing.Status, failing.Age = false, "BuildFailed", 3*time.Hour
+	rv.SetResources([]k8s.Resource{older, newer, failing})
+
+	names := func() []string {
+		var names []string
+		for _, resource := range rv.resources {
+			names = append(names, resource.Name)
+		}
+		return names
+	}
+	press := func(key string) {
+		rv, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	assert.Equal(t, []string{"b-older", "c-newer", "a-failing"}, names())
+
+	press("s")
+	assert.Equal(t, []string{"a-failing", "b-older", "c-newer"}, names())
+	assert.Equal(t, "Name ↑", rv.table.Columns()[0].Title)
+
+	press("s")
+	assert.Equal(t, []string{"a-failing", "b-older", "c-newer"}, names())
+	assert.Equal(t, "Ready ↑", rv.table.Columns()[1].Title)
+
+	press("s")
+	press("s")
+	assert.Equal(t, []string{"c-newer", "b-older", "a-failing"}, names())
+
+	// The selection follows its resource when the order flips
+	rv.table.SetCursor(0)
+	press("S")
+	assert.Equal(t, []string{"a-failing", "b-older", "c-newer"}, names())
+	assert.Equal(t, "Age ↓", rv.table.Columns()[3].Title)
+	assert.Equal(t, "c-newer", rv.GetSelectedResource().Name)
+
+	// Updates keep the sort order
+	rv.SetResources([]k8s.Resource{newer, older, failing})
+	assert.Equal(t, []string{"a-failing", "b-older", "c-newer"}, names())
+
+	press("s")
+	assert.Equal(t, "Name", rv.table.Columns()[0].Title)
+	assert.Equal(t, []string{"c-newer", "b-older", "a-failing"}, names())
+}
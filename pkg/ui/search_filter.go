@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// readinessFilter restricts the table by the Ready state of resources
+type readinessFilter int
+
+const (
+	readinessAll readinessFilter = iota
+	readinessNotReady
+	readinessReady
+)
+
+// readinessLabels describe each readiness filter, "" when showing all
+var readinessLabels = map[readinessFilter]string{
+	readinessNotReady: "not ready",
+	readinessReady:    "ready",
+}
+
+// CycleReadiness cycles the readiness filter: all, not ready, ready
+func (v *ResourceView) CycleReadiness() {
+	v.readiness = (v.readiness + 1) % 3
+	v.applyOrdering()
+	v.updateTable()
+	v.table.SetCursor(0)
+}
+
+// ReadinessLabel describes the readiness filter, "" when showing all
+func (v *ResourceView) ReadinessLabel() string {
+	return readinessLabels[v.readiness]
+}
+
+// matchesReadiness reports whether a resource passes the readiness filter.
+// Snoozed resources are left out of the not ready triage view.
+func (v *ResourceView) matchesReadiness(resource k8s.Resource) bool {
+	switch v.readiness {
+	case readinessNotReady:
+		return !resource.Ready && !resource.Snoozed(time.Now())
+	case readinessReady:
+		return resource.Ready
+	}
+	return true
+}
+
+// StartFilter starts capturing a name filter query
+func (v *ResourceView) StartFilter() {
+	v.filtering = true
+}
+
+// Filtering reports whether a filter query is being typed
+func (v *ResourceView) Filtering() bool {
+	return v.filtering
+}
+
+// FilterQuery returns the active name filter, "" when unfiltered
+func (v *ResourceView) FilterQuery() string {
+	return v.query
+}
+
+// ClearFilter drops the name filter and shows all resources again
+func (v *ResourceView) ClearFilter() {
+	v.filtering = false
+	v.setQuery("")
+}
+
+// handleFilterInput edits the filter query: enter keeps it, esc clears it
+func (v *ResourceView) handleFilterInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		v.filtering = false
+	case tea.KeyEsc:
+		v.ClearFilter()
+	case tea.KeyBackspace:
+		if query := []rune(v.query); len(query) > 0 {
+			v.setQuery(string(query[:len(query)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.setQuery(v.query + string(msg.Runes))
+	}
+}
+
+// setQuery re-filters the table, starting over at the first match
+func (v *ResourceView) setQuery(query string) {
+	if query == v.query {
+		return
+	}
+	v.query = query
+	v.applyOrdering()
+	v.updateTable()
+	v.table.SetCursor(0)
+}
+
+// matchesQuery reports whether a resource passes the name filter. The
+// namespace is matched too when it is shown.
+func (v *ResourceView) matchesQuery(resource k8s.Resource) bool {
+	if v.query == "" {
+		return true
+	}
+	name := resource.Name
+	if v.config.UI.ShowNamespace && resource.Namespace != "" {
+		name = fmt.Sprintf("%s/%s", resource.Namespace, resource.Name)
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(v.query))
+}
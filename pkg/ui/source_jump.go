@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// SourceResolvedMsg carries the source a Kustomization or HelmRelease references
+type SourceResolvedMsg struct {
+	Resource k8s.Resource
+	Source   *k8s.Resource
+	Err      error
+}
+
+// jumpToSource looks up the source of a Kustomization or HelmRelease
+func (m *AppModel) jumpToSource(resource k8s.Resource) tea.Cmd {
+	if resource.Type != k8s.ResourceTypeKustomization && resource.Type != k8s.ResourceTypeHelmRelease {
+		m.statusMessage = "Only Kustomizations and HelmReleases have a source"
+		return nil
+	}
+
+	m.statusMessage = fmt.Sprintf("Resolving source of %s...", resource.Name)
+	return func() tea.Msg {
+		source, err := m.manager.ResolveSource(resource)
+		return SourceResolvedMsg{Resource: resource, Source: source, Err: err}
+	}
+}
+
+// handleSourceResolved switches the table to the source's type and selects it
+func (m *AppModel) handleSourceResolved(msg SourceResolvedMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to resolve source of %s: %v", msg.Resource.Name, msg.Err)
+		return
+	}
+
+	source := *msg.Source
+	m.currentView = ViewResources
+	m.state.CurrentResource = source.Type
+	m.resourceView.SetResourceType(source.Type)
+	m.resourceView.SetResources(m.currentResources())
+	if !m.resourceView.SelectResource(source) {
+		m.statusMessage = fmt.Sprintf("%s %s/%s is hidden by the current filters", source.Type, source.Namespace, source.Name)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Source of %s: %s %s/%s (ctrl+r on %s reconciles both)", msg.Resource.Name, source.Type, source.Namespace, source.Name, msg.Resource.Name)
+}
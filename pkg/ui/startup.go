@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ManagerStartedMsg reports that the manager connected, or failed to, after
+// the UI was already shown
+type ManagerStartedMsg struct {
+	Cluster string
+	Err     error
+}
+
+// newSpinner creates the loading spinner, ASCII-only in accessibility mode
+func (m *AppModel) newSpinner() spinner.Model {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	if m.config.UI.Accessible {
+		s.Spinner = spinner.Line
+	}
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return s
+}
+
+// startManager connects to the clusters in the background so the UI renders
+// immediately instead of blocking on slow clusters
+func (m *AppModel) startManager() tea.Cmd {
+	return func() tea.Msg {
+		err := m.manager.Start()
+		return ManagerStartedMsg{Cluster: m.manager.GetCurrentCluster(), Err: err}
+	}
+}
+
+// handleManagerStarted records the startup outcome
+func (m *AppModel) handleManagerStarted(msg ManagerStartedMsg) {
+	m.starting = false
+	if msg.Err != nil {
+		m.startErr = fmt.Errorf("failed to start manager: %w", msg.Err)
+		return
+	}
+	// The manager may pick a different cluster, e.g. when replaying a recording
+	m.state.CurrentCluster = msg.Cluster
+}
+
+// loading reports whether the current type has not been listed yet
+func (m *AppModel) loading() bool {
+	if m.startErr != nil {
+		return false
+	}
+	if m.starting {
+		return true
+	}
+	if m.config.Fleet {
+		for _, types := range m.state.Resources {
+			if _, listed := types[m.state.CurrentResource]; listed {
+				return false
+			}
+		}
+		return true
+	}
+	_, listed := m.state.Resources[m.state.CurrentCluster][m.state.CurrentResource]
+	return !listed
+}
+
+// tickSpinner keeps the spinner animating while something is loading
+func (m *AppModel) tickSpinner(msg spinner.TickMsg) tea.Cmd {
+	if !m.loading() {
+		m.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// ensureSpinner restarts the spinner when loading begins again, e.g. after a
+// namespace switch dropped the cached resources
+func (m *AppModel) ensureSpinner() tea.Cmd {
+	if m.spinning || !m.loading() {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
+
+// renderStartup renders the body while connecting or waiting for the first list
+func (m *AppModel) renderStartup() string {
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	if m.startErr != nil {
+		failure := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+		return fmt.Sprintf("%s\n\n%s", failure.Render(m.startErr.Error()), hint.Render("q to quit"))
+	}
+
+	what := fmt.Sprintf("Loading %s resources...", m.state.CurrentResource)
+	if m.starting {
+		what = "Connecting to cluster..."
+	}
+	body := fmt.Sprintf("%s %s", m.spinner.View(), what)
+	if m.errorMessage != "" {
+		// Keep retrying on the refresh interval, but say why nothing shows up yet
+		body += "\n\n" + hint.Render(fmt.Sprintf("Last error: %s", m.errorMessage))
+	}
+	return asciiSafe(m.config, body)
+}
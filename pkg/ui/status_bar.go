@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// resourceSummary counts the loaded resources by health
+type resourceSummary struct {
+	Total     int
+	Ready     int // Ready, whether suspended or not
+	Suspended int
+	Failing   int // Neither Ready nor suspended
+}
+
+// summarize counts resources by health. Ready and Suspended overlap, a
+// suspended resource keeps the Ready condition of its last reconcile
+func summarize(resources []k8s.Resource) resourceSummary {
+	summary := resourceSummary{Total: len(resources)}
+	for _, resource := range resources {
+		if resource.Ready {
+			summary.Ready++
+		}
+		if resource.Suspended {
+			summary.Suspended++
+		}
+		if !resource.Ready && !resource.Suspended {
+			summary.Failing++
+		}
+	}
+	return summary
+}
+
+// Summary returns the health counts of the loaded resources, as of the last
+// SetResources
+func (v *ResourceView) Summary() resourceSummary {
+	return v.summary
+}
+
+// SetTruncated records whether the latest list of a resource type stopped at
+// the configured list limit
+func (v *ResourceView) SetTruncated(resourceType k8s.ResourceType, truncated bool) {
+	if v.truncated == nil {
+		v.truncated = make(map[k8s.ResourceType]bool)
+	}
+	v.truncated[resourceType] = truncated
+}
+
+// SetController records the status of the controller reconciling a resource
+// type, nil when unknown
+func (v *ResourceView) SetController(resourceType k8s.ResourceType, status *k8s.ControllerStatus) {
+	if v.controllers == nil {
+		v.controllers = make(map[k8s.ResourceType]*k8s.ControllerStatus)
+	}
+	v.controllers[resourceType] = status
+}
+
+// renderControllerBanner explains why the current type's resources are stuck
+// when its controller isn't running, or returns ""
+func (m *AppModel) renderControllerBanner() string {
+	status := m.resourceView.controllers[m.state.CurrentResource]
+	if status == nil || status.Ready {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("196")).
+		Render(asciiSafe(m.config, fmt.Sprintf("⚠ %s is not ready (%s), %s won't reconcile until it runs",
+			status.Name, status.Detail, pluralTypeName(m.state.CurrentResource))))
+}
+
+// renderStatusBar renders the health counts of the loaded resources
+func (m *AppModel) renderStatusBar() string {
+	summary := m.resourceView.Summary()
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	failing := label.Render(fmt.Sprintf("%d failing", summary.Failing))
+	if summary.Failing > 0 {
+		failing = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196")).
+			Render(fmt.Sprintf("%d failing", summary.Failing))
+	}
+
+	total := fmt.Sprintf("%d resources", summary.Total)
+	if m.resourceView.truncated[m.state.CurrentResource] {
+		// The server holds more than the list limit let through
+		total = fmt.Sprintf("showing %d of many", summary.Total)
+	}
+	return label.Render(fmt.Sprintf("%s | %d ready | %d suspended | ", total, summary.Ready, summary.Suspended)) + failing
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+// asciiReplacer maps the unicode glyphs used by the UI to ASCII equivalents
+var asciiReplacer = strings.NewReplacer(
+	"…", "...",
+	"↑", "up",
+	"↓", "down",
+	"←", "left",
+	"→", "right",
+	"↔", "<->",
+	"—", "-",
+	"▸", ">",
+	"▾", "v",
+	"▲", "^",
+	"▼", "v",
+	"├", "|",
+	"└", "`",
+	"│", "|",
+	"─", "-",
+	"💤", "zz",
+	"⌛", "late",
+	"⛔", "!!",
+	"⏸", "||",
+	"•", "*",
+	"✓", "+",
+)
+
+// applyAccessibility disables color output globally when accessibility mode
+// is on or colors are turned off
+func applyAccessibility(cfg *config.Config) {
+	if !cfg.UI.Colors() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// asciiSafe replaces unicode glyphs with ASCII equivalents in accessibility mode
+func asciiSafe(cfg *config.Config, s string) string {
+	if !cfg.UI.Accessible {
+		return s
+	}
+	return asciiReplacer.Replace(s)
+}
+
+// ellipsis returns the marker appended to truncated cell values
+func ellipsis(cfg *config.Config) string {
+	return asciiSafe(cfg, "…")
+}
+
+// truncate cuts s to at most width terminal cells, ending in the ellipsis
+// when it was cut. Widths are measured per rune, so multibyte characters are
+// never split and wide ones count twice.
+func truncate(cfg *config.Config, s string, width int) string {
+	return runewidth.Truncate(s, width, ellipsis(cfg))
+}
+
+// compactTableHeight is the height below which tables drop the header rule
+// to leave room for a data row
+const compactTableHeight = 5
+
+// headerlessTableHeight is the height at or below which tables hide their
+// header entirely and show only rows around the cursor
+const headerlessTableHeight = 2
+
+// sizeTable fits a table into height rows, shedding header chrome on tiny
+// terminals so at least one data row stays visible. The table height never
+// drops below what the header and one row need, which bubbles expects.
+func sizeTable(t *table.Model, cfg *config.Config, height int) {
+	styles := tableStyles(cfg)
+	headerHeight := 2
+	if height < compactTableHeight {
+		styles.Header = styles.Header.BorderBottom(false)
+		headerHeight = 1
+	}
+	t.SetStyles(styles)
+
+	tableHeight := height - 2 // Reserve space for borders
+	if tableHeight < headerHeight+1 {
+		tableHeight = headerHeight + 1
+	}
+	t.SetHeight(tableHeight)
+}
+
+// tableView renders a table sized by sizeTable, without the header when
+// height leaves no room for it
+func tableView(t table.Model, height int) string {
+	view := t.View()
+	if height <= 0 || height > headerlessTableHeight {
+		return view // Not sized yet, or room for the header
+	}
+	if _, rows, found := strings.Cut(view, "\n"); found {
+		return rows
+	}
+	return view
+}
+
+// tableStyles returns the styles shared by all tables
+func tableStyles(cfg *config.Config) table.Styles {
+	s := table.DefaultStyles()
+
+	if cfg.UI.Accessible {
+		// Plain text table: ASCII header rule and reverse video for the cursor
+		s.Header = s.Header.
+			BorderStyle(lipgloss.Border{Bottom: "-"}).
+			BorderBottom(true).
+			Bold(false)
+		s.Selected = lipgloss.NewStyle().Reverse(true)
+		return s
+	}
+	if !cfg.UI.Colors() {
+		// The cursor colors would be dropped, leaving it invisible
+		s.Selected = lipgloss.NewStyle().Reverse(true)
+	} else {
+		s.Selected = s.Selected.
+			Foreground(lipgloss.Color(selectedForeground)).
+			Background(lipgloss.Color(selectedBackground)).
+			Bold(false)
+	}
+
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	return s
+}
+
+// Colors of the table row under the cursor
+const (
+	selectedForeground = "229"
+	selectedBackground = "57"
+)
+
+// Cell colors are carried through the table as zero-width markers and
+// swapped for escape sequences once it is rendered: bubbles truncates cells
+// by counting escape sequences as visible width, which would cut them off.
+const (
+	cellColorEnd = "\u200c\u200c"
+)
+
+// cellColor is the start marker of a colored cell, with the foreground it
+// stands for on light and dark backgrounds
+type cellColor struct {
+	marker      string
+	light, dark string
+}
+
+var (
+	cellColorReady     = cellColor{marker: "\u200b\u200b", light: "28", dark: "42"}
+	cellColorNotReady  = cellColor{marker: "\u200b\u200c", light: "160", dark: "196"}
+	cellColorSuspended = cellColor{marker: "\u200b\ufeff", light: "136", dark: "226"}
+	cellColorWarning   = cellColor{marker: "\ufeff\u200b", light: "166", dark: "214"}
+)
+
+// colorCell marks a table cell's text to be colored by colorCells
+func colorCell(cfg *config.Config, text string, color cellColor) string {
+	if !cfg.UI.Colors() {
+		return text
+	}
+	return color.marker + text + cellColorEnd
+}
+
+// colorCells replaces the markers of colored cells in a rendered table.
+// Only the foreground is set, so the selected row keeps its background across
+// the cell. After the cell the foreground goes back to the terminal default,
+// or to the selected foreground on the cursor row.
+func colorCells(s string) string {
+	dark := lipgloss.HasDarkBackground()
+	profile := lipgloss.ColorProfile()
+
+	replacements := make([]string, 0, 10)
+	for _, color := range []cellColor{cellColorReady, cellColorNotReady, cellColorSuspended, cellColorWarning} {
+		code := color.dark
+		if !dark {
+			code = color.light
+		}
+		sequence := ""
+		if seq := profile.Color(code).Sequence(false); seq != "" {
+			sequence = termenv.CSI + seq + "m"
+		}
+		replacements = append(replacements, color.marker, sequence)
+	}
+	if profile == termenv.Ascii {
+		replacements = append(replacements, cellColorEnd, "")
+		return strings.NewReplacer(replacements...).Replace(s)
+	}
+
+	replacer := strings.NewReplacer(append(replacements, cellColorEnd, termenv.CSI+"39m")...)
+	selectedRow := profile.Color(selectedBackground).Sequence(true)
+	selectedReplacer := strings.NewReplacer(append(replacements, cellColorEnd, termenv.CSI+profile.Color(selectedForeground).Sequence(false)+"m")...)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, cellColorEnd) {
+			continue
+		}
+		if strings.Contains(line, selectedRow) {
+			lines[i] = selectedReplacer.Replace(line)
+		} else {
+			lines[i] = replacer.Replace(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// sortColumn is a resource table column rows can be sorted by
+type sortColumn int
+
+const (
+	sortNone sortColumn = iota // API order
+	sortName
+	sortReady
+	sortStatus
+	sortAge
+)
+
+// sortColumnTitles are the table column titles of each sort column
+var sortColumnTitles = map[sortColumn]string{
+	sortName:   "Name",
+	sortReady:  "Ready",
+	sortStatus: "Status",
+	sortAge:    "Age",
+}
+
+// CycleSort cycles the sort column: API order, Name, Ready, Status, Age
+func (v *ResourceView) CycleSort() {
+	v.sortBy = (v.sortBy + 1) % (sortAge + 1)
+	v.resort()
+}
+
+// ToggleSortOrder flips between ascending and descending order
+func (v *ResourceView) ToggleSortOrder() {
+	v.sortDesc = !v.sortDesc
+	v.resort()
+}
+
+// resort reorders the table, keeping the selected resource under the cursor
+func (v *ResourceView) resort() {
+	selected := v.GetSelectedResource()
+	var keep *k8s.Resource
+	if selected != nil {
+		resource := *selected
+		keep = &resource
+	}
+
+	v.applyOrdering()
+	v.updateTableColumns()
+	v.updateTable()
+
+	if keep == nil {
+		return
+	}
+	for row, index := range v.rowIndex {
+		if index >= 0 && sameResource(v.resources[index], *keep) {
+			v.table.SetCursor(row)
+			return
+		}
+	}
+}
+
+// sortResources orders resources by the sort column. Ties keep API order.
+func (v *ResourceView) sortResources() {
+	if v.sortBy == sortNone {
+		return
+	}
+
+	sort.SliceStable(v.resources, func(i, j int) bool {
+		a, b := v.resources[i], v.resources[j]
+		if v.sortDesc {
+			a, b = b, a
+		}
+		switch v.sortBy {
+		case sortName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Namespace < b.Namespace
+		case sortReady:
+			return !a.Ready && b.Ready
+		case sortStatus:
+			return strings.ToLower(a.Status) < strings.ToLower(b.Status)
+		case sortAge:
+			return v.age(a) < v.age(b)
+		}
+		return false
+	})
+}
+
+// markSortColumn appends the sort direction to the sort column's title
+func (v *ResourceView) markSortColumn(columns []table.Column) {
+	title, sorted := sortColumnTitles[v.sortBy]
+	if !sorted {
+		return
+	}
+	if v.sortBy == sortAge {
+		title = v.ageTitle()
+	}
+	arrow := " ↑"
+	if v.sortDesc {
+		arrow = " ↓"
+	}
+	for i := range columns {
+		if columns[i].Title == title {
+			columns[i].Title = asciiSafe(v.config, title+arrow)
+		}
+	}
+}
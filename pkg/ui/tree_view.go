@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// TreeMsg carries the dependency tree of a resource
+type TreeMsg struct {
+	Resource k8s.Resource
+	Tree     *k8s.TreeNode
+	Err      error
+}
+
+// treeLine is a visible row of the tree
+type treeLine struct {
+	node   *k8s.TreeNode
+	prefix string // Connectors drawn before the node
+}
+
+// TreeView displays the objects a Kustomization or HelmRelease manages as a
+// tree whose Flux objects can be collapsed
+type TreeView struct {
+	config    *config.Config
+	resource  k8s.Resource
+	root      *k8s.TreeNode
+	collapsed map[*k8s.TreeNode]bool
+	lines     []treeLine
+	cursor    int
+	offset    int // First visible line
+	width     int
+	height    int
+}
+
+// NewTreeView creates a new dependency tree view
+func NewTreeView(cfg *config.Config) *TreeView {
+	return &TreeView{
+		config:    cfg,
+		collapsed: make(map[*k8s.TreeNode]bool),
+	}
+}
+
+// Update handles messages for the tree view
+func (v *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
+	msgKey, ok := msg.(tea.KeyMsg)
+	if !ok || len(v.lines) == 0 {
+		return v, nil
+	}
+
+	switch msgKey.String() {
+	case "down", "j":
+		v.moveCursor(1)
+	case "up", "k":
+		v.moveCursor(-1)
+	case "pgdown", "ctrl+d":
+		v.moveCursor(v.visibleLines())
+	case "pgup", "ctrl+u":
+		v.moveCursor(-v.visibleLines())
+	case "g", "home":
+		v.moveCursor(-len(v.lines))
+	case "G", "end":
+		v.moveCursor(len(v.lines))
+	case "enter", " ":
+		node := v.lines[v.cursor].node
+		if len(node.Children) > 0 {
+			v.collapsed[node] = !v.collapsed[node]
+			v.flatten()
+		}
+	case "left":
+		node := v.lines[v.cursor].node
+		if len(node.Children) > 0 && !v.collapsed[node] {
+			v.collapsed[node] = true
+			v.flatten()
+		}
+	case "right":
+		node := v.lines[v.cursor].node
+		if v.collapsed[node] {
+			v.collapsed[node] = false
+			v.flatten()
+		}
+	}
+
+	return v, nil
+}
+
+// View renders the tree view
+func (v *TreeView) View() string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81")).
+		Render(asciiSafe(v.config, fmt.Sprintf("Objects managed by %s %s/%s (enter collapse/expand, esc to return)", v.resource.Type, v.resource.Namespace, v.resource.Name)))
+
+	var b strings.Builder
+	b.WriteString(title)
+	if len(v.lines) <= 1 {
+		b.WriteString("\n")
+		b.WriteString(label.Render("  Nothing applied yet"))
+	}
+
+	selected := lipgloss.NewStyle().Reverse(true)
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	end := v.offset + v.visibleLines()
+	if end > len(v.lines) {
+		end = len(v.lines)
+	}
+	for i := v.offset; i < end; i++ {
+		line := v.lines[i]
+		text := line.prefix + v.nodeLabel(line.node)
+		if i == v.cursor {
+			text = selected.Render(text)
+		}
+		b.WriteString("\n")
+		b.WriteString(asciiSafe(v.config, text))
+		if line.node.Err != "" {
+			b.WriteString(errStyle.Render(" " + line.node.Err))
+		}
+	}
+	return b.String()
+}
+
+// SetTree sets the tree to display, fully expanded
+func (v *TreeView) SetTree(resource k8s.Resource, tree *k8s.TreeNode) {
+	v.resource = resource
+	v.root = tree
+	v.collapsed = make(map[*k8s.TreeNode]bool)
+	v.cursor = 0
+	v.offset = 0
+	v.flatten()
+}
+
+// GetResource returns the resource whose tree is shown
+func (v *TreeView) GetResource() *k8s.Resource {
+	return &v.resource
+}
+
+// SetSize sets the view dimensions
+func (v *TreeView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.moveCursor(0)
+}
+
+// visibleLines is the number of tree lines that fit below the title
+func (v *TreeView) visibleLines() int {
+	if v.height <= 1 {
+		return 1
+	}
+	return v.height - 1
+}
+
+// moveCursor moves the cursor by delta lines and scrolls it into view
+func (v *TreeView) moveCursor(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.lines) {
+		v.cursor = len(v.lines) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.visibleLines() {
+		v.offset = v.cursor - v.visibleLines() + 1
+	}
+}
+
+// flatten lays out the expanded nodes as lines
+func (v *TreeView) flatten() {
+	v.lines = v.lines[:0]
+	if v.root != nil {
+		v.lines = append(v.lines, treeLine{node: v.root})
+		v.flattenChildren(v.root, "")
+	}
+	v.moveCursor(0)
+}
+
+// flattenChildren appends the lines of a node's children
+func (v *TreeView) flattenChildren(node *k8s.TreeNode, indent string) {
+	if v.collapsed[node] {
+		return
+	}
+	for i, child := range node.Children {
+		connector, childIndent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			connector, childIndent = "└── ", "    "
+		}
+		v.lines = append(v.lines, treeLine{node: child, prefix: indent + connector})
+		v.flattenChildren(child, indent+childIndent)
+	}
+}
+
+// nodeLabel renders a node as Kind/namespace/name, marking nodes with
+// children as expanded or collapsed
+func (v *TreeView) nodeLabel(node *k8s.TreeNode) string {
+	switch {
+	case len(node.Children) == 0:
+		return node.Object.String()
+	case v.collapsed[node]:
+		return fmt.Sprintf("▸ %s (%d)", node.Object, len(node.Children))
+	default:
+		return "▾ " + node.Object.String()
+	}
+}
+
+// showTree fetches the dependency tree of a Kustomization or HelmRelease
+func (m *AppModel) showTree(resource k8s.Resource) tea.Cmd {
+	if resource.Type != k8s.ResourceTypeKustomization && resource.Type != k8s.ResourceTypeHelmRelease {
+		m.statusMessage = "Only Kustomizations and HelmReleases manage objects"
+		return nil
+	}
+
+	m.statusMessage = fmt.Sprintf("Building the tree of %s...", resource.Name)
+	return func() tea.Msg {
+		tree, err := m.manager.BuildDependencyTree(resource)
+		return TreeMsg{Resource: resource, Tree: tree, Err: err}
+	}
+}
+
+// handleTree opens the tree view, returning to the current view on esc
+func (m *AppModel) handleTree(msg TreeMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to build the tree of %s: %v", msg.Resource.Name, msg.Err)
+		return
+	}
+
+	m.treeView.SetTree(msg.Resource, msg.Tree)
+	if m.currentView != ViewTree {
+		m.treeReturn = m.currentView
+	}
+	m.currentView = ViewTree
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// typeLabels maps each resource type to the short prefix shown in views that
+// mix resource types
+var typeLabels = map[k8s.ResourceType]string{
+	k8s.ResourceTypeGitRepository:  "GR",
+	k8s.ResourceTypeHelmRepository: "HR",
+	k8s.ResourceTypeKustomization:  "KS",
+	k8s.ResourceTypeHelmRelease:    "HL",
+	k8s.ResourceTypeOCIRepository:  "OC",
+	k8s.ResourceTypeBucket:         "BK",
+	k8s.ResourceTypeImageRepository:       "IR",
+	k8s.ResourceTypeImagePolicy:           "IP",
+	k8s.ResourceTypeImageUpdateAutomation: "IU",
+	k8s.ResourceTypeAlert:                 "AL",
+	k8s.ResourceTypeProvider:              "PR",
+	k8s.ResourceTypeReceiver:              "RC",
+}
+
+// typeColors maps each resource type to the color of its prefix
+var typeColors = map[k8s.ResourceType]lipgloss.Color{
+	k8s.ResourceTypeGitRepository:  lipgloss.Color("214"),
+	k8s.ResourceTypeHelmRepository: lipgloss.Color("39"),
+	k8s.ResourceTypeKustomization:  lipgloss.Color("141"),
+	k8s.ResourceTypeHelmRelease:    lipgloss.Color("45"),
+	k8s.ResourceTypeOCIRepository:  lipgloss.Color("170"),
+	k8s.ResourceTypeBucket:         lipgloss.Color("108"),
+	k8s.ResourceTypeImageRepository:       lipgloss.Color("204"),
+	k8s.ResourceTypeImagePolicy:           lipgloss.Color("177"),
+	k8s.ResourceTypeImageUpdateAutomation: lipgloss.Color("79"),
+	k8s.ResourceTypeAlert:                 lipgloss.Color("203"),
+	k8s.ResourceTypeProvider:              lipgloss.Color("110"),
+	k8s.ResourceTypeReceiver:              lipgloss.Color("150"),
+}
+
+// typeLabel returns the short label for a resource type. Labels can be
+// overridden with ui.type_labels; unknown types have no label.
+func typeLabel(cfg *config.Config, resourceType k8s.ResourceType) string {
+	for name, label := range cfg.UI.TypeLabels {
+		// Viper lowercases map keys, so match type names case-insensitively
+		if strings.EqualFold(name, string(resourceType)) {
+			return asciiSafe(cfg, label)
+		}
+	}
+	return typeLabels[resourceType]
+}
+
+// styledTypeLabel returns the colored short label for a resource type
+func styledTypeLabel(cfg *config.Config, resourceType k8s.ResourceType) string {
+	label := typeLabel(cfg, resourceType)
+	if label == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(typeColors[resourceType]).Render(label)
+}
+
+// objectLabel renders a kind and name, prefixing Flux kinds with their type
+// label and falling back to kind/name for everything else
+func objectLabel(cfg *config.Config, kind, name string) string {
+	if label := typeLabel(cfg, k8s.ResourceType(kind)); label != "" {
+		return fmt.Sprintf("%s %s", label, name)
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+func TestTypeLabel(t *testing.T) {
+	cfg, err := config.Load("", "", "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "GR", typeLabel(cfg, k8s.ResourceTypeGitRepository))
+	assert.Equal(t, "HR", typeLabel(cfg, k8s.ResourceTypeHelmRepository))
+	assert.Equal(t, "KS", typeLabel(cfg, k8s.ResourceTypeKustomization))
+	assert.Equal(t, "HL", typeLabel(cfg, k8s.ResourceTypeHelmRelease))
+	assert.Equal(t, "", typeLabel(cfg, k8s.ResourceType("Deployment")))
+
+	assert.Equal(t, "KS apps", objectLabel(cfg, "Kustomization", "apps"))
+	assert.Equal(t, "Deployment/podinfo", objectLabel(cfg, "Deployment", "podinfo"))
+
+	// Overrides match type names case-insensitively since viper lowercases keys
+	cfg.UI.TypeLabels = map[string]string{"kustomization": "K"}
+	assert.Equal(t, "K", typeLabel(cfg, k8s.ResourceTypeKustomization))
+	assert.Equal(t, "GR", typeLabel(cfg, k8s.ResourceTypeGitRepository))
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// watchEventLimit is how many of the pinned resource's events the watch screen shows
+const watchEventLimit = 10
+
+// WatchView pins a single resource and shows its live status, conditions
+// timeline and recent events on one screen
+type WatchView struct {
+	config    *config.Config
+	viewport  viewport.Model
+	resource  *k8s.Resource
+	events    []Event // Events of the pinned resource, in arrival order
+	refreshed time.Time
+	width     int
+	height    int
+}
+
+// WatchReconcileMsg requests reconciliation of the pinned resource
+type WatchReconcileMsg struct {
+	Resource k8s.Resource
+}
+
+// NewWatchView creates a new watch view
+func NewWatchView(cfg *config.Config) *WatchView {
+	return &WatchView{
+		config:   cfg,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// Update handles messages for the watch view
+func (v *WatchView) Update(msg tea.Msg) (*WatchView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "R":
+			if v.resource != nil {
+				resource := *v.resource
+				cmd = func() tea.Msg { return WatchReconcileMsg{Resource: resource} }
+			}
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		default:
+			v.viewport, cmd = v.viewport.Update(msg)
+		}
+	}
+
+	return v, cmd
+}
+
+// View renders the watch view
+func (v *WatchView) View() string {
+	if v.resource == nil {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("244")).
+			Render("No resource pinned")
+	}
+	return v.viewport.View()
+}
+
+// Pin starts watching a resource, dropping the events of any previous one
+func (v *WatchView) Pin(resource k8s.Resource) {
+	if v.resource == nil || !sameResource(*v.resource, resource) {
+		v.events = nil
+		v.viewport.GotoTop()
+	}
+	v.SetResource(resource)
+}
+
+// SetResource updates the pinned resource with a fresh snapshot
+func (v *WatchView) SetResource(resource k8s.Resource) {
+	v.resource = &resource
+	v.refreshed = time.Now()
+	v.refresh()
+}
+
+// SetEvents keeps the events that belong to the pinned resource
+func (v *WatchView) SetEvents(events []Event) {
+	if v.resource == nil {
+		return
+	}
+	v.events = v.events[:0]
+	for _, event := range events {
+		if k8s.EventMatchesResource(corev1.Event{InvolvedObject: event.InvolvedObject}, *v.resource) {
+			v.events = append(v.events, event)
+		}
+	}
+	v.refresh()
+}
+
+// GetResource returns the pinned resource
+func (v *WatchView) GetResource() *k8s.Resource {
+	return v.resource
+}
+
+// SetSize sets the view dimensions
+func (v *WatchView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height
+	v.refresh()
+}
+
+// refresh re-renders the viewport content
+func (v *WatchView) refresh() {
+	if v.resource == nil {
+		return
+	}
+	v.viewport.SetContent(v.renderContent())
+}
+
+// renderContent renders the status, conditions timeline and events panes
+func (v *WatchView) renderContent() string {
+	r := v.resource
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81"))
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var b strings.Builder
+	b.WriteString(styledTypeLabel(v.config, r.Type))
+	b.WriteString(" ")
+	b.WriteString(title.Render(fmt.Sprintf("Watching %s %s/%s", r.Type, r.Namespace, r.Name)))
+	b.WriteString(label.Render(fmt.Sprintf("  updated %s", formatTimestamp(v.config, v.refreshed))))
+	b.WriteString("\n\n")
+
+	status := r.Status
+	if status == "" {
+		status = "Unknown"
+	}
+	statusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	if r.Ready {
+		statusStyle = statusStyle.Foreground(lipgloss.Color("46"))
+	}
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Status:  "), statusStyle.Render(status))
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Message: "), r.DisplayMessage())
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Revision:"), valueOrDash(r.Revision))
+	if flags := watchFlags(*r); flags != "" {
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Flags:   "), flags)
+	}
+	b.WriteString("\n")
+
+	b.WriteString(title.Render("Conditions timeline"))
+	b.WriteString("\n")
+	timeline := conditionTimeline(r.Conditions)
+	if len(timeline) == 0 {
+		b.WriteString(label.Render("  No conditions reported"))
+		b.WriteString("\n")
+	}
+	for _, cond := range timeline {
+		fmt.Fprintf(&b, "  %s  %s=%s %s\n", formatTimestamp(v.config, cond.LastTransitionTime), cond.Type, cond.Status, cond.Reason)
+		if cond.Message != "" {
+			fmt.Fprintf(&b, "    %s\n", cond.Message)
+		}
+	}
+	b.WriteString("\n")
+
+	events := v.events
+	if len(events) > watchEventLimit {
+		events = events[len(events)-watchEventLimit:]
+	}
+	b.WriteString(title.Render(fmt.Sprintf("Recent events (%d)", len(v.events))))
+	b.WriteString("\n")
+	if len(events) == 0 {
+		b.WriteString(label.Render("  No recent events"))
+		b.WriteString("\n")
+	}
+	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	for _, event := range events {
+		line := fmt.Sprintf("  %s  %-8s %-24s %s", formatEventTime(v.config, event.Timestamp), event.Type, event.Reason, event.Message)
+		if event.Type == corev1.EventTypeWarning {
+			line = warning.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(label.Render("R reconcile | a actions | esc back"))
+
+	return asciiSafe(v.config, b.String())
+}
+
+// watchFlags lists the states that change how a resource is reconciled
+func watchFlags(r k8s.Resource) string {
+	var flags []string
+	if r.Suspended {
+		flags = append(flags, "suspended")
+	}
+	if r.Stalled {
+		flags = append(flags, "⛔ stalled")
+	} else if r.Reconciling {
+		flags = append(flags, "reconciling")
+	}
+	if r.Overdue {
+		flags = append(flags, "⌛ overdue")
+	}
+	if r.Snoozed(time.Now()) {
+		flags = append(flags, "💤 snoozed")
+	}
+	return strings.Join(flags, ", ")
+}
+
+// conditionTimeline orders conditions by their last transition, newest first
+func conditionTimeline(conditions []k8s.Condition) []k8s.Condition {
+	timeline := append([]k8s.Condition(nil), conditions...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].LastTransitionTime.After(timeline[j].LastTransitionTime)
+	})
+	return timeline
+}
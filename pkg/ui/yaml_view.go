@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/malagant/fluxcli/internal/config"
+	"github.com/malagant/fluxcli/pkg/k8s"
+)
+
+// ManifestMsg carries the fetched manifest of a resource
+type ManifestMsg struct {
+	Resource k8s.Resource
+	Manifest string
+	Err      error
+}
+
+// YAMLView displays the full manifest of a resource in a scrollable viewport
+type YAMLView struct {
+	config   *config.Config
+	viewport viewport.Model
+	title    string
+	width    int
+	height   int
+}
+
+// NewYAMLView creates a new manifest view
+func NewYAMLView(cfg *config.Config) *YAMLView {
+	return &YAMLView{
+		config:   cfg,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// Update handles messages for the manifest view
+func (v *YAMLView) Update(msg tea.Msg) (*YAMLView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		default:
+			v.viewport, cmd = v.viewport.Update(msg)
+		}
+	}
+
+	return v, cmd
+}
+
+// View renders the manifest view
+func (v *YAMLView) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("81")).
+		Render(v.title)
+
+	return title + "\n" + v.viewport.View()
+}
+
+// SetManifest sets the manifest to display and scrolls to the top
+func (v *YAMLView) SetManifest(resource k8s.Resource, manifest string) {
+	v.title = asciiSafe(v.config, fmt.Sprintf("%s %s/%s (esc to return, y to copy)", resource.Type, resource.Namespace, resource.Name))
+	v.viewport.SetContent(colorizeYAML(manifest))
+	v.viewport.GotoTop()
+}
+
+// SetSize sets the view dimensions
+func (v *YAMLView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height - 1 // Reserve space for the title
+}
+
+// colorizeYAML highlights the keys of a YAML document
+func colorizeYAML(manifest string) string {
+	key := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	lines := strings.Split(strings.TrimSuffix(manifest, "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(trimmed)]
+		if strings.HasPrefix(trimmed, "- ") {
+			indent += "- "
+			trimmed = trimmed[2:]
+		}
+		name, rest, found := strings.Cut(trimmed, ":")
+		if !found || name == "" || strings.ContainsAny(name, " \"'") || (rest != "" && rest[0] != ' ') {
+			continue
+		}
+		lines[i] = indent + key.Render(name) + ":" + rest
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showManifest fetches a resource's manifest for the manifest view
+func (m *AppModel) showManifest(resource k8s.Resource) tea.Cmd {
+	m.statusMessage = fmt.Sprintf("Fetching manifest of %s...", resource.Name)
+	return func() tea.Msg {
+		manifest, err := m.manager.GetResourceYAML(resource)
+		return ManifestMsg{Resource: resource, Manifest: manifest, Err: err}
+	}
+}
+
+// handleManifest opens the manifest view, returning to the current view on esc
+func (m *AppModel) handleManifest(msg ManifestMsg) {
+	m.statusMessage = ""
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to fetch manifest of %s: %v", msg.Resource.Name, msg.Err)
+		return
+	}
+
+	m.yamlView.SetManifest(msg.Resource, msg.Manifest)
+	if m.currentView != ViewYAML {
+		m.yamlReturn = m.currentView
+	}
+	m.yamlResource = msg.Resource
+	m.currentView = ViewYAML
+}